@@ -0,0 +1,247 @@
+package unify4g
+
+import "fmt"
+
+// BiMap is a generic bidirectional map in which both keys of type `K` and values of type `V` are unique.
+// It keeps a forward and an inverse index in sync so that lookups are O(1) in both directions,
+// replacing the pattern of maintaining two hand-synchronized maps.
+type BiMap[K, V comparable] struct {
+	forward map[K]V
+	inverse map[V]K
+}
+
+// NewBiMap creates a new, empty `BiMap`.
+//
+// Returns:
+//   - A pointer to an empty `BiMap`.
+//
+// Example usage:
+//
+//	labels := NewBiMap[string, string]()
+//	labels.Put("US", "United States")
+func NewBiMap[K, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward: make(map[K]V),
+		inverse: make(map[V]K),
+	}
+}
+
+// Put associates `key` with `value`. If `key` already exists, its previous value is replaced.
+// If `value` is already bound to a different key, the map is left unchanged and an error is returned.
+//
+// Parameters:
+//   - `key`: The key to add or update.
+//   - `value`: The value to associate with the key.
+//
+// Returns:
+//   - An error if `value` is already associated with another key.
+//
+// Example usage:
+//
+//	err := labels.Put("US", "United States")
+func (bi *BiMap[K, V]) Put(key K, value V) error {
+	if existing, ok := bi.inverse[value]; ok && existing != key {
+		return fmt.Errorf("value %v is already bound to key %v", value, existing)
+	}
+	bi.ForcePut(key, value)
+	return nil
+}
+
+// ForcePut associates `key` with `value`, removing any existing entry that uses either
+// the same key or the same value, so that uniqueness holds on both sides.
+//
+// Parameters:
+//   - `key`: The key to add or update.
+//   - `value`: The value to associate with the key.
+//
+// Example usage:
+//
+//	labels.ForcePut("USA", "United States") // Replaces the "US" entry.
+func (bi *BiMap[K, V]) ForcePut(key K, value V) {
+	if old, ok := bi.forward[key]; ok {
+		delete(bi.inverse, old)
+	}
+	if old, ok := bi.inverse[value]; ok {
+		delete(bi.forward, old)
+	}
+	bi.forward[key] = value
+	bi.inverse[value] = key
+}
+
+// Get retrieves the value associated with `key`.
+//
+// Parameters:
+//   - `key`: The key to look up.
+//
+// Returns:
+//   - The value associated with the key and `true`, or the zero value of `V` and `false` if absent.
+//
+// Example usage:
+//
+//	label, ok := labels.Get("US")
+func (bi *BiMap[K, V]) Get(key K) (V, bool) {
+	value, ok := bi.forward[key]
+	return value, ok
+}
+
+// GetKey retrieves the key associated with `value` (inverse lookup).
+//
+// Parameters:
+//   - `value`: The value to look up.
+//
+// Returns:
+//   - The key associated with the value and `true`, or the zero value of `K` and `false` if absent.
+//
+// Example usage:
+//
+//	code, ok := labels.GetKey("United States")
+func (bi *BiMap[K, V]) GetKey(value V) (K, bool) {
+	key, ok := bi.inverse[value]
+	return key, ok
+}
+
+// RemoveKey deletes the entry identified by `key`. If the key does not exist, no action is taken.
+//
+// Parameters:
+//   - `key`: The key of the entry to remove.
+//
+// Example usage:
+//
+//	labels.RemoveKey("US")
+func (bi *BiMap[K, V]) RemoveKey(key K) {
+	if value, ok := bi.forward[key]; ok {
+		delete(bi.forward, key)
+		delete(bi.inverse, value)
+	}
+}
+
+// RemoveValue deletes the entry identified by `value`. If the value does not exist, no action is taken.
+//
+// Parameters:
+//   - `value`: The value of the entry to remove.
+//
+// Example usage:
+//
+//	labels.RemoveValue("United States")
+func (bi *BiMap[K, V]) RemoveValue(value V) {
+	if key, ok := bi.inverse[value]; ok {
+		delete(bi.inverse, value)
+		delete(bi.forward, key)
+	}
+}
+
+// ContainsKey checks whether `key` exists in the map.
+//
+// Parameters:
+//   - `key`: The key to check.
+//
+// Returns:
+//   - `true` if the key exists, `false` otherwise.
+//
+// Example usage:
+//
+//	exists := labels.ContainsKey("US")
+func (bi *BiMap[K, V]) ContainsKey(key K) bool {
+	_, ok := bi.forward[key]
+	return ok
+}
+
+// ContainsValue checks whether `value` exists in the map.
+//
+// Parameters:
+//   - `value`: The value to check.
+//
+// Returns:
+//   - `true` if the value exists, `false` otherwise.
+//
+// Example usage:
+//
+//	exists := labels.ContainsValue("United States")
+func (bi *BiMap[K, V]) ContainsValue(value V) bool {
+	_, ok := bi.inverse[value]
+	return ok
+}
+
+// Inverse returns a new `BiMap` with keys and values swapped.
+// The returned map is a copy; changes to it do not affect the original.
+//
+// Returns:
+//   - A new `BiMap[V, K]` mapping each value back to its key.
+//
+// Example usage:
+//
+//	byLabel := labels.Inverse()
+func (bi *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	result := NewBiMap[V, K]()
+	for k, v := range bi.forward {
+		result.forward[v] = k
+		result.inverse[k] = v
+	}
+	return result
+}
+
+// Keys returns all keys of the map. The order is not specified.
+//
+// Returns:
+//   - A slice containing the keys of the map.
+//
+// Example usage:
+//
+//	codes := labels.Keys()
+func (bi *BiMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(bi.forward))
+	for k := range bi.forward {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Values returns all values of the map. The order is not specified.
+//
+// Returns:
+//   - A slice containing the values of the map.
+//
+// Example usage:
+//
+//	names := labels.Values()
+func (bi *BiMap[K, V]) Values() []V {
+	values := make([]V, 0, len(bi.inverse))
+	for v := range bi.inverse {
+		values = append(values, v)
+	}
+	return values
+}
+
+// Size returns the number of entries in the map.
+//
+// Returns:
+//   - The number of key-value pairs.
+//
+// Example usage:
+//
+//	size := labels.Size()
+func (bi *BiMap[K, V]) Size() int {
+	return len(bi.forward)
+}
+
+// IsEmpty checks whether the map contains no entries.
+//
+// Returns:
+//   - `true` if the map is empty, `false` otherwise.
+//
+// Example usage:
+//
+//	isEmpty := labels.IsEmpty()
+func (bi *BiMap[K, V]) IsEmpty() bool {
+	return len(bi.forward) == 0
+}
+
+// Clear removes all entries from the map.
+//
+// Example usage:
+//
+//	labels.Clear()
+func (bi *BiMap[K, V]) Clear() {
+	bi.forward = make(map[K]V)
+	bi.inverse = make(map[V]K)
+}
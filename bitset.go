@@ -0,0 +1,588 @@
+package unify4g
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// wordSize is the number of bits stored in each word of a `BitSet`.
+const wordSize = 64
+
+// BitSet is a dense set of non-negative integers backed by a slice of 64-bit words.
+// Each integer `i` in the set is represented by a single bit, which makes the structure
+// far more compact than a `HashSet[int]` when the values are small and densely packed
+// (for example feature flags or user-ID segments).
+//
+// The set grows automatically when a bit beyond the current capacity is set. Negative
+// indexes are never members of the set; operations receiving them are no-ops.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet is a constructor function that creates a new `BitSet` and optionally sets
+// any provided indexes.
+//
+// Parameters:
+//   - `indexes`: A variadic list of bit indexes to set initially (optional). Negative indexes are ignored.
+//
+// Returns:
+//   - A pointer to the newly created `BitSet`.
+//
+// Example usage:
+//
+//	bitset := NewBitSet(1, 3, 5) // Creates a bit set with bits 1, 3 and 5 set.
+func NewBitSet(indexes ...int) *BitSet {
+	bitset := &BitSet{words: make([]uint64, 0)}
+	for _, i := range indexes {
+		bitset.Set(i)
+	}
+	return bitset
+}
+
+// NewBitSetFromHashSet creates a new `BitSet` containing every non-negative element of the given `HashSet`.
+//
+// Parameters:
+//   - `hash`: The source `HashSet[int]`. Negative elements are ignored.
+//
+// Returns:
+//   - A pointer to a new `BitSet` holding the same non-negative integers as `hash`.
+//
+// Example usage:
+//
+//	bitset := NewBitSetFromHashSet(NewHashSet(1, 2, 3))
+func NewBitSetFromHashSet(hash *HashSet[int]) *BitSet {
+	bitset := NewBitSet()
+	if hash == nil {
+		return bitset
+	}
+	for item := range hash.items {
+		bitset.Set(item)
+	}
+	return bitset
+}
+
+// NewBitSetFromBase64 decodes a `BitSet` previously encoded with `ToBase64`.
+//
+// Parameters:
+//   - `encoded`: The standard base64 representation produced by `ToBase64`.
+//
+// Returns:
+//   - A pointer to the decoded `BitSet`.
+//   - An error if `encoded` is not valid base64 or its payload is not a valid bit set encoding.
+//
+// Example usage:
+//
+//	bitset, err := NewBitSetFromBase64(other.ToBase64())
+func NewBitSetFromBase64(encoded string) (*BitSet, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode bitset base64 error:[%v]", err)
+	}
+	bitset := NewBitSet()
+	if err := bitset.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return bitset, nil
+}
+
+// Set adds the bit at index `i` to the set, growing the underlying storage if needed.
+//
+// Parameters:
+//   - `i`: The bit index to set. Negative indexes are ignored.
+//
+// Example usage:
+//
+//	bitset.Set(10) // Sets bit 10.
+func (bitset *BitSet) Set(i int) {
+	if i < 0 {
+		return
+	}
+	bitset.grow(i/wordSize + 1)
+	bitset.words[i/wordSize] |= 1 << uint(i%wordSize)
+}
+
+// Clear removes the bit at index `i` from the set. If the bit is not set, no action is taken.
+//
+// Parameters:
+//   - `i`: The bit index to clear.
+//
+// Example usage:
+//
+//	bitset.Clear(10) // Clears bit 10.
+func (bitset *BitSet) Clear(i int) {
+	if i < 0 || i/wordSize >= len(bitset.words) {
+		return
+	}
+	bitset.words[i/wordSize] &^= 1 << uint(i%wordSize)
+}
+
+// Test reports whether the bit at index `i` is set.
+//
+// Parameters:
+//   - `i`: The bit index to check.
+//
+// Returns:
+//   - `true` if the bit is set, `false` otherwise (including for negative indexes).
+//
+// Example usage:
+//
+//	isSet := bitset.Test(10)
+func (bitset *BitSet) Test(i int) bool {
+	if i < 0 || i/wordSize >= len(bitset.words) {
+		return false
+	}
+	return bitset.words[i/wordSize]&(1<<uint(i%wordSize)) != 0
+}
+
+// Flip toggles the bit at index `i`: a set bit is cleared and a cleared bit is set.
+//
+// Parameters:
+//   - `i`: The bit index to toggle. Negative indexes are ignored.
+//
+// Example usage:
+//
+//	bitset.Flip(10) // Toggles bit 10.
+func (bitset *BitSet) Flip(i int) {
+	if i < 0 {
+		return
+	}
+	bitset.grow(i/wordSize + 1)
+	bitset.words[i/wordSize] ^= 1 << uint(i%wordSize)
+}
+
+// SetRange sets every bit in the half-open range [from, to).
+//
+// Parameters:
+//   - `from`: The first bit index to set (inclusive). Negative values are clamped to 0.
+//   - `to`: The bit index at which to stop (exclusive).
+//
+// Example usage:
+//
+//	bitset.SetRange(0, 128) // Sets bits 0 through 127.
+func (bitset *BitSet) SetRange(from, to int) {
+	bitset.applyRange(from, to, func(word *uint64, mask uint64) { *word |= mask })
+}
+
+// ClearRange clears every bit in the half-open range [from, to).
+//
+// Parameters:
+//   - `from`: The first bit index to clear (inclusive). Negative values are clamped to 0.
+//   - `to`: The bit index at which to stop (exclusive).
+//
+// Example usage:
+//
+//	bitset.ClearRange(10, 20) // Clears bits 10 through 19.
+func (bitset *BitSet) ClearRange(from, to int) {
+	if to > len(bitset.words)*wordSize {
+		to = len(bitset.words) * wordSize
+	}
+	bitset.applyRange(from, to, func(word *uint64, mask uint64) { *word &^= mask })
+}
+
+// FlipRange toggles every bit in the half-open range [from, to).
+//
+// Parameters:
+//   - `from`: The first bit index to toggle (inclusive). Negative values are clamped to 0.
+//   - `to`: The bit index at which to stop (exclusive).
+//
+// Example usage:
+//
+//	bitset.FlipRange(0, 8) // Toggles bits 0 through 7.
+func (bitset *BitSet) FlipRange(from, to int) {
+	bitset.applyRange(from, to, func(word *uint64, mask uint64) { *word ^= mask })
+}
+
+// ClearAll removes all bits from the set, resetting it to an empty set.
+//
+// Example usage:
+//
+//	bitset.ClearAll()
+func (bitset *BitSet) ClearAll() {
+	bitset.words = make([]uint64, 0)
+}
+
+// Cardinality returns the number of bits currently set (the population count).
+//
+// Returns:
+//   - The number of integers contained in the set.
+//
+// Example usage:
+//
+//	count := NewBitSet(1, 2, 64).Cardinality() // count will be 3
+func (bitset *BitSet) Cardinality() int {
+	count := 0
+	for _, w := range bitset.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// IsEmpty checks whether no bit is set.
+//
+// Returns:
+//   - `true` if the set is empty, `false` otherwise.
+//
+// Example usage:
+//
+//	isEmpty := bitset.IsEmpty()
+func (bitset *BitSet) IsEmpty() bool {
+	for _, w := range bitset.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Len returns the number of bits the set can currently address without growing,
+// which is always a multiple of 64.
+//
+// Returns:
+//   - The current bit capacity of the set.
+//
+// Example usage:
+//
+//	capacity := NewBitSet(70).Len() // capacity will be 128
+func (bitset *BitSet) Len() int {
+	return len(bitset.words) * wordSize
+}
+
+// NextSet returns the index of the first set bit at or after `from`.
+//
+// Parameters:
+//   - `from`: The index at which to begin searching. Negative values are treated as 0.
+//
+// Returns:
+//   - The index of the next set bit and `true`, or -1 and `false` if there is none.
+//
+// Example usage:
+//
+//	for i, ok := bitset.NextSet(0); ok; i, ok = bitset.NextSet(i + 1) {
+//		fmt.Println(i)
+//	}
+func (bitset *BitSet) NextSet(from int) (int, bool) {
+	if from < 0 {
+		from = 0
+	}
+	idx := from / wordSize
+	if idx >= len(bitset.words) {
+		return -1, false
+	}
+	w := bitset.words[idx] >> uint(from%wordSize)
+	if w != 0 {
+		return from + bits.TrailingZeros64(w), true
+	}
+	for idx++; idx < len(bitset.words); idx++ {
+		if bitset.words[idx] != 0 {
+			return idx*wordSize + bits.TrailingZeros64(bitset.words[idx]), true
+		}
+	}
+	return -1, false
+}
+
+// NextClear returns the index of the first cleared bit at or after `from`.
+// Because the set is conceptually infinite, a cleared bit always exists.
+//
+// Parameters:
+//   - `from`: The index at which to begin searching. Negative values are treated as 0.
+//
+// Returns:
+//   - The index of the next cleared bit.
+//
+// Example usage:
+//
+//	free := NewBitSet(0, 1, 2).NextClear(0) // free will be 3
+func (bitset *BitSet) NextClear(from int) int {
+	if from < 0 {
+		from = 0
+	}
+	idx := from / wordSize
+	if idx >= len(bitset.words) {
+		return from
+	}
+	w := ^bitset.words[idx] >> uint(from%wordSize)
+	if w != 0 {
+		return from + bits.TrailingZeros64(w)
+	}
+	for idx++; idx < len(bitset.words); idx++ {
+		if bitset.words[idx] != ^uint64(0) {
+			return idx*wordSize + bits.TrailingZeros64(^bitset.words[idx])
+		}
+	}
+	return len(bitset.words) * wordSize
+}
+
+// And returns a new `BitSet` containing only the bits set in both the current set and `another`.
+//
+// Parameters:
+//   - `another`: The other `BitSet` to intersect with.
+//
+// Returns:
+//   - A new `BitSet` holding the intersection of the two sets.
+//
+// Example usage:
+//
+//	result := NewBitSet(1, 2, 3).And(NewBitSet(2, 3, 4)) // result contains 2 and 3
+func (bitset *BitSet) And(another *BitSet) *BitSet {
+	n := min(len(bitset.words), len(another.words))
+	result := &BitSet{words: make([]uint64, n)}
+	for i := 0; i < n; i++ {
+		result.words[i] = bitset.words[i] & another.words[i]
+	}
+	return result
+}
+
+// Or returns a new `BitSet` containing the bits set in either the current set or `another`.
+//
+// Parameters:
+//   - `another`: The other `BitSet` to combine with.
+//
+// Returns:
+//   - A new `BitSet` holding the union of the two sets.
+//
+// Example usage:
+//
+//	result := NewBitSet(1, 2).Or(NewBitSet(3)) // result contains 1, 2 and 3
+func (bitset *BitSet) Or(another *BitSet) *BitSet {
+	result := bitset.Clone()
+	result.grow(len(another.words))
+	for i, w := range another.words {
+		result.words[i] |= w
+	}
+	return result
+}
+
+// Xor returns a new `BitSet` containing the bits set in exactly one of the current set and `another`.
+//
+// Parameters:
+//   - `another`: The other `BitSet` to compare with.
+//
+// Returns:
+//   - A new `BitSet` holding the symmetric difference of the two sets.
+//
+// Example usage:
+//
+//	result := NewBitSet(1, 2).Xor(NewBitSet(2, 3)) // result contains 1 and 3
+func (bitset *BitSet) Xor(another *BitSet) *BitSet {
+	result := bitset.Clone()
+	result.grow(len(another.words))
+	for i, w := range another.words {
+		result.words[i] ^= w
+	}
+	return result
+}
+
+// AndNot returns a new `BitSet` containing the bits set in the current set but not in `another`.
+//
+// Parameters:
+//   - `another`: The `BitSet` whose bits are removed from the result.
+//
+// Returns:
+//   - A new `BitSet` holding the difference between the two sets.
+//
+// Example usage:
+//
+//	result := NewBitSet(1, 2, 3).AndNot(NewBitSet(2)) // result contains 1 and 3
+func (bitset *BitSet) AndNot(another *BitSet) *BitSet {
+	result := bitset.Clone()
+	for i := 0; i < len(result.words) && i < len(another.words); i++ {
+		result.words[i] &^= another.words[i]
+	}
+	return result
+}
+
+// Equal reports whether the current set and `another` contain exactly the same bits,
+// regardless of their underlying capacity.
+//
+// Parameters:
+//   - `another`: The `BitSet` to compare with.
+//
+// Returns:
+//   - `true` if both sets contain the same bits, `false` otherwise.
+//
+// Example usage:
+//
+//	same := NewBitSet(1).Equal(NewBitSet(1)) // same will be true
+func (bitset *BitSet) Equal(another *BitSet) bool {
+	n := max(len(bitset.words), len(another.words))
+	for i := 0; i < n; i++ {
+		if bitset.word(i) != another.word(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a deep copy of the `BitSet`.
+//
+// Returns:
+//   - A new `BitSet` with the same bits set as the original.
+//
+// Example usage:
+//
+//	copied := bitset.Clone()
+func (bitset *BitSet) Clone() *BitSet {
+	words := make([]uint64, len(bitset.words))
+	copy(words, bitset.words)
+	return &BitSet{words: words}
+}
+
+// Slice returns the indexes of all set bits in ascending order.
+//
+// Returns:
+//   - A slice containing every integer in the set, sorted ascending.
+//
+// Example usage:
+//
+//	indexes := NewBitSet(5, 1).Slice() // indexes will be []int{1, 5}
+func (bitset *BitSet) Slice() []int {
+	result := make([]int, 0, bitset.Cardinality())
+	for i, w := range bitset.words {
+		for w != 0 {
+			t := bits.TrailingZeros64(w)
+			result = append(result, i*wordSize+t)
+			w &= w - 1
+		}
+	}
+	return result
+}
+
+// HashSet converts the `BitSet` into a `HashSet[int]` holding the same integers.
+//
+// Returns:
+//   - A new `HashSet[int]` containing every set bit index.
+//
+// Example usage:
+//
+//	hash := NewBitSet(1, 2).HashSet()
+func (bitset *BitSet) HashSet() *HashSet[int] {
+	return NewHashSet(bitset.Slice()...)
+}
+
+// MarshalBinary encodes the `BitSet` as a sequence of little-endian 64-bit words,
+// omitting trailing zero words. It implements `encoding.BinaryMarshaler`.
+//
+// Returns:
+//   - The binary encoding of the set.
+//   - A nil error; the signature satisfies `encoding.BinaryMarshaler`.
+//
+// Example usage:
+//
+//	data, _ := bitset.MarshalBinary()
+func (bitset *BitSet) MarshalBinary() ([]byte, error) {
+	n := len(bitset.words)
+	for n > 0 && bitset.words[n-1] == 0 {
+		n--
+	}
+	data := make([]byte, n*8)
+	for i := 0; i < n; i++ {
+		binary.LittleEndian.PutUint64(data[i*8:], bitset.words[i])
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes data produced by `MarshalBinary`, replacing the current contents.
+// It implements `encoding.BinaryUnmarshaler`.
+//
+// Parameters:
+//   - `data`: The binary encoding to decode. Its length must be a multiple of 8.
+//
+// Returns:
+//   - An error if the length of `data` is not a multiple of 8.
+//
+// Example usage:
+//
+//	err := bitset.UnmarshalBinary(data)
+func (bitset *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return fmt.Errorf("invalid bitset encoding length:[%d]", len(data))
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	bitset.words = words
+	return nil
+}
+
+// ToBase64 encodes the `BitSet` as a standard base64 string of its binary encoding.
+//
+// Returns:
+//   - The base64 representation of the set, suitable for `NewBitSetFromBase64`.
+//
+// Example usage:
+//
+//	encoded := NewBitSet(1, 2, 3).ToBase64()
+func (bitset *BitSet) ToBase64() string {
+	data, _ := bitset.MarshalBinary()
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// String returns a string representation of the `BitSet`, with set bit indexes
+// in ascending order separated by commas.
+//
+// Returns:
+//   - A string that represents the set bits.
+//
+// Example usage:
+//
+//	str := NewBitSet(3, 1).String() // str will be "1,3"
+func (bitset *BitSet) String() string {
+	var builder strings.Builder
+	for i, idx := range bitset.Slice() {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteString(strconv.Itoa(idx))
+	}
+	return builder.String()
+}
+
+// grow ensures that the set holds at least `n` words.
+func (bitset *BitSet) grow(n int) {
+	if n <= len(bitset.words) {
+		return
+	}
+	if n <= cap(bitset.words) {
+		bitset.words = bitset.words[:n]
+		return
+	}
+	words := make([]uint64, n, max(n, 2*cap(bitset.words)))
+	copy(words, bitset.words)
+	bitset.words = words
+}
+
+// word returns the word at index `i`, or 0 if `i` is beyond the current capacity.
+func (bitset *BitSet) word(i int) uint64 {
+	if i < len(bitset.words) {
+		return bitset.words[i]
+	}
+	return 0
+}
+
+// applyRange invokes `fn` with each word touched by the half-open range [from, to)
+// and the mask of bits within that word that belong to the range.
+func (bitset *BitSet) applyRange(from, to int, fn func(word *uint64, mask uint64)) {
+	if from < 0 {
+		from = 0
+	}
+	if to <= from {
+		return
+	}
+	bitset.grow((to-1)/wordSize + 1)
+	first, last := from/wordSize, (to-1)/wordSize
+	for i := first; i <= last; i++ {
+		mask := ^uint64(0)
+		if i == first {
+			mask &= ^uint64(0) << uint(from%wordSize)
+		}
+		if i == last {
+			mask &= ^uint64(0) >> uint(wordSize-1-(to-1)%wordSize)
+		}
+		fn(&bitset.words[i], mask)
+	}
+}
@@ -0,0 +1,450 @@
+package unify4g
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CaseConverter splits identifiers and phrases into words and joins them back in a naming convention,
+// such as camelCase, snake_case or Title Case.
+//
+// Words are separated by any character that is not a letter or a digit, by a lowercase letter followed
+// by an uppercase one ("userName"), and at the end of an uppercase run followed by a lowercase letter
+// ("HTTPServer" gives "HTTP" and "Server"), unless the run is a known acronym followed by a plural or
+// a suffix ("URLs", "IPv4"). Runs of known acronyms are split apart ("JSONAPI" gives "JSON" and "API")
+// and mixed-case acronyms such as "GraphQL" are kept whole. By default digits belong
+// to the word they follow and an uppercase letter after a digit starts a new word ("UTF8Encoder" gives
+// "UTF8" and "Encoder"); `SetSplitDigits` makes every run of digits a word of its own.
+//
+// Words found in the acronym list, and their plurals, are written in their listed form (usually
+// uppercase) by the capitalizing conventions, so that "user_ids" becomes "userIDs" rather than "userIds".
+// Converting a result to another convention and back gives the same words, except by default for digits
+// forming a word of their own after a lowercase word: "version_2" becomes "version2" in camelCase,
+// which is a single word unless `SetSplitDigits` is enabled.
+//
+// A CaseConverter must not be modified while it is in use by other goroutines.
+type CaseConverter struct {
+	acronyms    map[string]string
+	splitDigits bool
+}
+
+// defaultCaseAcronyms lists the initialisms recognized by `NewCaseConverter`, following the
+// conventions of Go identifiers.
+var defaultCaseAcronyms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "CSV", "DB", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS",
+	"ID", "IO", "IP", "JSON", "JWT", "LHS", "OS", "QPS", "RAM", "RHS", "RPC", "SDK", "SLA", "SMTP",
+	"SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "URI", "URL", "UTF8", "UUID", "VM", "XML",
+	"XMPP", "XSRF", "XSS", "YAML",
+}
+
+// defaultCaseConverter is the CaseConverter used by the package-level case conversion functions.
+var defaultCaseConverter = NewCaseConverter()
+
+// NewCaseConverter creates a CaseConverter recognizing the common initialisms of Go identifiers
+// (API, HTTP, ID, JSON, SQL, URL, UUID, ...), keeping digits with the word they follow.
+//
+// Returns:
+//   - A pointer to a new CaseConverter.
+//
+// Example:
+//
+//	converter := NewCaseConverter().AddAcronyms("GraphQL", "OAuth")
+//	converter.Pascal("graphql_endpoint") // "GraphQLEndpoint"
+func NewCaseConverter() *CaseConverter {
+	converter := &CaseConverter{}
+	return converter.SetAcronyms(defaultCaseAcronyms...)
+}
+
+// SetAcronyms replaces the acronym list of the converter. Acronyms are matched case-insensitively
+// against whole words and written as given. Calling it without arguments disables acronyms.
+//
+// Parameters:
+//   - `acronyms`: The acronyms to recognize.
+//
+// Returns:
+//   - The converter, for chaining.
+//
+// Example:
+//
+//	NewCaseConverter().SetAcronyms().Camel("user_id") // "userId"
+func (converter *CaseConverter) SetAcronyms(acronyms ...string) *CaseConverter {
+	converter.acronyms = make(map[string]string, len(acronyms))
+	return converter.AddAcronyms(acronyms...)
+}
+
+// AddAcronyms adds acronyms to the list of the converter, keeping the existing ones.
+//
+// Parameters:
+//   - `acronyms`: The acronyms to recognize, written as they should appear, e.g. "HTTP" or "OAuth".
+//
+// Returns:
+//   - The converter, for chaining.
+//
+// Example:
+//
+//	NewCaseConverter().AddAcronyms("K8S").Pascal("k8s_cluster") // "K8SCluster"
+func (converter *CaseConverter) AddAcronyms(acronyms ...string) *CaseConverter {
+	for _, acronym := range acronyms {
+		if acronym != "" {
+			converter.acronyms[strings.ToLower(acronym)] = acronym
+		}
+	}
+	return converter
+}
+
+// SetSplitDigits sets whether runs of digits form words of their own ("version2" gives "version" and
+// "2") instead of belonging to the word they follow ("version2"). Default is false.
+//
+// Parameters:
+//   - `split`: true to split digits from letters.
+//
+// Returns:
+//   - The converter, for chaining.
+//
+// Example:
+//
+//	NewCaseConverter().SetSplitDigits(true).Snake("Version2Beta") // "version_2_beta"
+func (converter *CaseConverter) SetSplitDigits(split bool) *CaseConverter {
+	converter.splitDigits = split
+	return converter
+}
+
+// Words splits `str` into its words, keeping their original case.
+//
+// Parameters:
+//   - `str`: An identifier or a phrase in any convention.
+//
+// Returns:
+//   - The words of `str`; an empty slice if it has none.
+//
+// Example:
+//
+//	NewCaseConverter().Words("HTTPServerID")     // []string{"HTTP", "Server", "ID"}
+//	NewCaseConverter().Words("parse-json_v2 API") // []string{"parse", "json", "v2", "API"}
+func (converter *CaseConverter) Words(str string) []string {
+	words := []string{}
+	for _, chunk := range strings.FieldsFunc(str, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		runes := []rune(chunk)
+		var parts []string
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			if converter.isWordBoundary(runes, i) {
+				parts = append(parts, converter.splitAcronyms(string(runes[start:i]))...)
+				start = i
+			}
+		}
+		parts = append(parts, converter.splitAcronyms(string(runes[start:]))...)
+		words = append(words, converter.mergeAcronyms(parts)...)
+	}
+	return words
+}
+
+// Camel converts `str` to camelCase: the first word in lowercase, the others capitalized.
+//
+// Example:
+//
+//	NewCaseConverter().Camel("HTTP server id") // "httpServerID"
+func (converter *CaseConverter) Camel(str string) string {
+	words := converter.Words(str)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+		} else {
+			words[i] = converter.capitalize(word)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// Pascal converts `str` to PascalCase: every word capitalized, without separators.
+//
+// Example:
+//
+//	NewCaseConverter().Pascal("http_server_id") // "HTTPServerID"
+func (converter *CaseConverter) Pascal(str string) string {
+	return converter.join(str, "", converter.capitalize)
+}
+
+// Snake converts `str` to snake_case: lowercase words joined by underscores.
+//
+// Example:
+//
+//	NewCaseConverter().Snake("HTTPServerID") // "http_server_id"
+func (converter *CaseConverter) Snake(str string) string {
+	return converter.join(str, "_", strings.ToLower)
+}
+
+// ScreamingSnake converts `str` to SCREAMING_SNAKE_CASE: uppercase words joined by underscores.
+//
+// Example:
+//
+//	NewCaseConverter().ScreamingSnake("maxRetryCount") // "MAX_RETRY_COUNT"
+func (converter *CaseConverter) ScreamingSnake(str string) string {
+	return converter.join(str, "_", strings.ToUpper)
+}
+
+// Kebab converts `str` to kebab-case: lowercase words joined by hyphens.
+//
+// Example:
+//
+//	NewCaseConverter().Kebab("UserProfileURL") // "user-profile-url"
+func (converter *CaseConverter) Kebab(str string) string {
+	return converter.join(str, "-", strings.ToLower)
+}
+
+// Train converts `str` to Train-Case: capitalized words joined by hyphens, as in HTTP header names.
+//
+// Example:
+//
+//	NewCaseConverter().Train("content_type") // "Content-Type"
+func (converter *CaseConverter) Train(str string) string {
+	return converter.join(str, "-", converter.capitalize)
+}
+
+// Dot converts `str` to dot.case: lowercase words joined by dots, as in configuration keys.
+//
+// Example:
+//
+//	NewCaseConverter().Dot("ServerReadTimeout") // "server.read.timeout"
+func (converter *CaseConverter) Dot(str string) string {
+	return converter.join(str, ".", strings.ToLower)
+}
+
+// Title converts `str` to Title Case: capitalized words joined by spaces. Every word is capitalized,
+// including short ones such as "of" or "and".
+//
+// Example:
+//
+//	NewCaseConverter().Title("api_key_id") // "API Key ID"
+func (converter *CaseConverter) Title(str string) string {
+	return converter.join(str, " ", converter.capitalize)
+}
+
+// SplitWords splits `str` into words with the default CaseConverter; see `CaseConverter.Words`.
+// Unlike `SplitCamelCase`, it also splits on separators and keeps acronyms such as "HTTP" together.
+//
+// Parameters:
+//   - `str`: An identifier or a phrase in any convention.
+//
+// Returns:
+//   - The words of `str`.
+//
+// Example:
+//
+//	words := SplitWords("HTTPServerID") // []string{"HTTP", "Server", "ID"}
+func SplitWords(str string) []string {
+	return defaultCaseConverter.Words(str)
+}
+
+// CamelCase converts `str` to camelCase with the default CaseConverter.
+// Unlike `ToCamelCase`, words are detected in any input convention and common acronyms are kept uppercase.
+//
+// Example:
+//
+//	CamelCase("user_id")      // "userID"
+//	CamelCase("HTTPServerID") // "httpServerID"
+func CamelCase(str string) string {
+	return defaultCaseConverter.Camel(str)
+}
+
+// PascalCase converts `str` to PascalCase with the default CaseConverter.
+//
+// Example:
+//
+//	PascalCase("json-api_url") // "JSONAPIURL"
+//	PascalCase("order item")   // "OrderItem"
+func PascalCase(str string) string {
+	return defaultCaseConverter.Pascal(str)
+}
+
+// SnakeCase converts `str` to snake_case with the default CaseConverter.
+// Unlike `ToSnakeCase`, word boundaries inside identifiers are detected, which makes it suitable for
+// deriving database column names from field names.
+//
+// Example:
+//
+//	SnakeCase("CreatedAt")    // "created_at"
+//	SnakeCase("HTTPServerID") // "http_server_id"
+func SnakeCase(str string) string {
+	return defaultCaseConverter.Snake(str)
+}
+
+// ScreamingSnakeCase converts `str` to SCREAMING_SNAKE_CASE with the default CaseConverter.
+//
+// Example:
+//
+//	ScreamingSnakeCase("dbHost") // "DB_HOST"
+func ScreamingSnakeCase(str string) string {
+	return defaultCaseConverter.ScreamingSnake(str)
+}
+
+// KebabCase converts `str` to kebab-case with the default CaseConverter.
+//
+// Example:
+//
+//	KebabCase("MyComponentName") // "my-component-name"
+func KebabCase(str string) string {
+	return defaultCaseConverter.Kebab(str)
+}
+
+// TrainCase converts `str` to Train-Case with the default CaseConverter.
+//
+// Example:
+//
+//	TrainCase("x_request_id") // "X-Request-ID"
+func TrainCase(str string) string {
+	return defaultCaseConverter.Train(str)
+}
+
+// DotCase converts `str` to dot.case with the default CaseConverter.
+//
+// Example:
+//
+//	DotCase("LogLevel") // "log.level"
+func DotCase(str string) string {
+	return defaultCaseConverter.Dot(str)
+}
+
+// TitleCase converts `str` to Title Case with the default CaseConverter.
+//
+// Example:
+//
+//	TitleCase("userProfileURL") // "User Profile URL"
+func TitleCase(str string) string {
+	return defaultCaseConverter.Title(str)
+}
+
+// isWordBoundary reports whether a new word starts at `runes[i]`, a letter or digit preceded by
+// another letter or digit of the current word.
+func (converter *CaseConverter) isWordBoundary(runes []rune, i int) bool {
+	previous, current := runes[i-1], runes[i]
+	previousDigit, currentDigit := unicode.IsDigit(previous), unicode.IsDigit(current)
+	switch {
+	case previousDigit != currentDigit:
+		if converter.splitDigits {
+			return true
+		}
+		return previousDigit && unicode.IsUpper(current)
+	case unicode.IsLower(previous) && unicode.IsUpper(current):
+		return true
+	case unicode.IsUpper(previous) && unicode.IsUpper(current):
+		if i+1 >= len(runes) || !unicode.IsLower(runes[i+1]) {
+			return false
+		}
+		// the last capital of a run starts the next word ("HTTPServer"), unless the run is only
+		// an acronym with it, followed by a plural or a suffix ("URLs", "IPv4")
+		start := i - 1
+		for start > 0 && unicode.IsUpper(runes[start-1]) {
+			start--
+		}
+		return converter.segmentAcronyms(string(runes[start:i])) != nil || converter.segmentAcronyms(string(runes[start:i+1])) == nil
+	}
+	return false
+}
+
+// splitAcronyms splits an uppercase word made of consecutive acronyms, such as "JSONAPI", into the
+// fewest acronyms possible; a plural "s" stays with the last acronym ("JSONAPIs"). Other words, and
+// words that are not entirely made of acronyms, are kept whole.
+func (converter *CaseConverter) splitAcronyms(word string) []string {
+	stem, plural := strings.CutSuffix(word, "s")
+	if stem != strings.ToUpper(stem) {
+		return []string{word}
+	}
+	parts := converter.segmentAcronyms(stem)
+	if len(parts) < 2 {
+		return []string{word}
+	}
+	if plural {
+		parts[len(parts)-1] += "s"
+	}
+	return parts
+}
+
+// segmentAcronyms splits `word` into the fewest acronyms, matched as written, or returns nil if it
+// is not entirely made of acronyms.
+func (converter *CaseConverter) segmentAcronyms(word string) []string {
+	if len(converter.acronyms) == 0 || word == "" {
+		return nil
+	}
+	runes := []rune(word)
+	// best[i] holds the start of the last acronym of the best segmentation of runes[:i], or -1.
+	best := make([]int, len(runes)+1)
+	counts := make([]int, len(runes)+1)
+	for i := 1; i <= len(runes); i++ {
+		best[i] = -1
+		for j := 0; j < i; j++ {
+			if j > 0 && best[j] < 0 {
+				continue
+			}
+			candidate := string(runes[j:i])
+			if acronym, ok := converter.acronyms[strings.ToLower(candidate)]; ok && acronym == candidate {
+				if best[i] < 0 || counts[j]+1 < counts[i] {
+					best[i], counts[i] = j, counts[j]+1
+				}
+			}
+		}
+	}
+	if best[len(runes)] < 0 {
+		return nil
+	}
+	var parts []string
+	for i := len(runes); i > 0; i = best[i] {
+		parts = append([]string{string(runes[best[i]:i])}, parts...)
+	}
+	return parts
+}
+
+// mergeAcronyms joins consecutive words that spell a mixed-case acronym, such as "Graph" and "QL"
+// for "GraphQL", which the boundary rules would otherwise split.
+func (converter *CaseConverter) mergeAcronyms(words []string) []string {
+	merged := make([]string, 0, len(words))
+	for i := 0; i < len(words); {
+		next := i + 1
+		for k := min(len(words), i+4); k > i+1; k-- {
+			joined := strings.Join(words[i:k], "")
+			if acronym, ok := converter.acronyms[strings.ToLower(joined)]; ok && acronym == joined {
+				next = k
+				break
+			}
+		}
+		merged = append(merged, strings.Join(words[i:next], ""))
+		i = next
+	}
+	return merged
+}
+
+// acronym returns the listed form of `word` if it is an acronym, or the plural of one ("IDs").
+func (converter *CaseConverter) acronym(word string) (string, bool) {
+	lower := strings.ToLower(word)
+	if acronym, ok := converter.acronyms[lower]; ok {
+		return acronym, true
+	}
+	if stem, ok := strings.CutSuffix(lower, "s"); ok {
+		if acronym, ok := converter.acronyms[stem]; ok {
+			return acronym + "s", true
+		}
+	}
+	return "", false
+}
+
+// capitalize returns `word` in its acronym form if it is one, and otherwise with its first letter
+// in uppercase and the others in lowercase.
+func (converter *CaseConverter) capitalize(word string) string {
+	if acronym, ok := converter.acronym(word); ok {
+		return acronym
+	}
+	runes := []rune(strings.ToLower(word))
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+	return string(runes)
+}
+
+// join splits `str` into words, transforms each of them and joins them with `separator`.
+func (converter *CaseConverter) join(str, separator string, transform func(string) string) string {
+	words := converter.Words(str)
+	for i, word := range words {
+		words[i] = transform(word)
+	}
+	return strings.Join(words, separator)
+}
@@ -0,0 +1,620 @@
+package unify4g
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// castTimeLayouts lists the layouts tried, in order, when converting a string to a `time.Time`.
+var castTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.ANSIC,
+	time.UnixDate,
+	time.RubyDate,
+}
+
+// ToInt64E converts `v` to an int64.
+//
+// The conversion accepts every integer, unsigned integer and float kind (including named types such
+// as `time.Duration`), booleans (1 or 0), `json.Number`, and strings holding an integer or an
+// integral float, with surrounding spaces ignored. Pointers are dereferenced and nil converts to 0.
+// Floats with a fractional part and values outside the int64 range are rejected rather than truncated.
+//
+// Parameters:
+//   - `v`: The value to convert.
+//
+// Returns:
+//   - The converted value.
+//   - An error if `v` cannot be converted without loss.
+//
+// Example:
+//
+//	n, err := ToInt64E(json.Number("42")) // 42, nil
+//	n, err = ToInt64E(" 7 ")              // 7, nil
+//	n, err = ToInt64E(2.5)                // 0, error
+func ToInt64E(v interface{}) (int64, error) {
+	return castInt64(v, "int64")
+}
+
+// castInt64 implements `ToInt64E`, naming `target` in the errors it returns.
+func castInt64(v interface{}, target string) (int64, error) {
+	value := castIndirect(v)
+	if !value.IsValid() {
+		return 0, nil
+	}
+	if number, ok := value.Interface().(json.Number); ok {
+		return parseCastInt(string(number), v, target)
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if value.Uint() > math.MaxInt64 {
+			return 0, castError(v, target, "value out of range")
+		}
+		return int64(value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return floatToInt64(value.Float(), v, target)
+	case reflect.Bool:
+		if value.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.String:
+		return parseCastInt(value.String(), v, target)
+	default:
+		return 0, castError(v, target, "")
+	}
+}
+
+// ToIntE converts `v` to an int, like `ToInt64E`, and fails if the value does not fit in an int.
+//
+// Parameters:
+//   - `v`: The value to convert.
+//
+// Returns:
+//   - The converted value.
+//   - An error if `v` cannot be converted without loss.
+//
+// Example:
+//
+//	var decoded map[string]interface{}
+//	_ = UnmarshalFromStringN(`{"port": 8080}`, &decoded)
+//	port, err := ToIntE(decoded["port"]) // 8080, nil
+func ToIntE(v interface{}) (int, error) {
+	n, err := castInt64(v, "int")
+	if err != nil {
+		return 0, err
+	}
+	if n < math.MinInt || n > math.MaxInt {
+		return 0, castError(v, "int", "value out of range")
+	}
+	return int(n), nil
+}
+
+// ToFloat64E converts `v` to a float64.
+//
+// The conversion accepts every numeric kind, booleans (1 or 0), `json.Number`, and numeric strings
+// with surrounding spaces ignored. Pointers are dereferenced and nil converts to 0.
+//
+// Parameters:
+//   - `v`: The value to convert.
+//
+// Returns:
+//   - The converted value.
+//   - An error if `v` cannot be converted.
+//
+// Example:
+//
+//	f, err := ToFloat64E("3.14") // 3.14, nil
+func ToFloat64E(v interface{}) (float64, error) {
+	value := castIndirect(v)
+	if !value.IsValid() {
+		return 0, nil
+	}
+	if number, ok := value.Interface().(json.Number); ok {
+		return parseCastFloat(string(number), v)
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.Bool:
+		if value.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.String:
+		return parseCastFloat(value.String(), v)
+	default:
+		return 0, castError(v, "float64", "")
+	}
+}
+
+// ToBoolE converts `v` to a bool.
+//
+// Numbers convert to true when they are not zero. Strings accept the `strconv.ParseBool` syntax
+// ("1", "t", "true", "0", "f", "false", ...) as well as "yes", "y", "on", "no", "n" and "off",
+// case-insensitively. Pointers are dereferenced and nil converts to false.
+//
+// Parameters:
+//   - `v`: The value to convert.
+//
+// Returns:
+//   - The converted value.
+//   - An error if `v` cannot be converted.
+//
+// Example:
+//
+//	b, err := ToBoolE("yes") // true, nil
+//	b, err = ToBoolE(0)      // false, nil
+func ToBoolE(v interface{}) (bool, error) {
+	value := castIndirect(v)
+	if !value.IsValid() {
+		return false, nil
+	}
+	if number, ok := value.Interface().(json.Number); ok {
+		f, err := parseCastFloat(string(number), v)
+		return f != 0, err
+	}
+	switch value.Kind() {
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() != 0, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint() != 0, nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float() != 0, nil
+	case reflect.String:
+		switch strings.ToLower(strings.TrimSpace(value.String())) {
+		case "yes", "y", "on":
+			return true, nil
+		case "no", "n", "off":
+			return false, nil
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(value.String()))
+		if err != nil {
+			return false, castError(v, "bool", "invalid syntax")
+		}
+		return b, nil
+	default:
+		return false, castError(v, "bool", "")
+	}
+}
+
+// ToStringE converts `v` to a string.
+//
+// Strings and byte slices are returned as is, numbers use their shortest `strconv` representation,
+// `time.Time` values are formatted with RFC 3339, and types implementing `fmt.Stringer` or `error`
+// use their own text. Pointers are dereferenced and nil converts to "".
+//
+// Parameters:
+//   - `v`: The value to convert.
+//
+// Returns:
+//   - The converted value.
+//   - An error if `v` is a composite value, such as a map or a struct, with no text representation.
+//
+// Example:
+//
+//	s, err := ToStringE(3.50)          // "3.5", nil
+//	s, err = ToStringE([]byte("data")) // "data", nil
+func ToStringE(v interface{}) (string, error) {
+	value := castIndirect(v)
+	if !value.IsValid() {
+		return "", nil
+	}
+	switch s := value.Interface().(type) {
+	case time.Time:
+		return s.Format(time.RFC3339Nano), nil
+	case []byte:
+		return string(s), nil
+	case json.Number:
+		return string(s), nil
+	case fmt.Stringer:
+		return s.String(), nil
+	case error:
+		return s.Error(), nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	default:
+		return "", castError(v, "string", "")
+	}
+}
+
+// ToDurationE converts `v` to a `time.Duration`.
+//
+// Strings are parsed with `time.ParseDuration` ("1h30m", "250ms"); strings holding a bare number and
+// numeric values are interpreted as nanoseconds, like the underlying type of `time.Duration`.
+//
+// Parameters:
+//   - `v`: The value to convert.
+//
+// Returns:
+//   - The converted value.
+//   - An error if `v` cannot be converted.
+//
+// Example:
+//
+//	d, err := ToDurationE("1m30s")          // 90 * time.Second, nil
+//	d, err = ToDurationE(int64(time.Second)) // time.Second, nil
+func ToDurationE(v interface{}) (time.Duration, error) {
+	value := castIndirect(v)
+	if !value.IsValid() {
+		return 0, nil
+	}
+	if value.Kind() == reflect.String {
+		s := strings.TrimSpace(value.String())
+		if d, err := time.ParseDuration(s); err == nil {
+			return d, nil
+		}
+		if n, err := ToInt64E(s); err == nil {
+			return time.Duration(n), nil
+		}
+		return 0, castError(v, "time.Duration", "invalid duration")
+	}
+	n, err := castInt64(v, "time.Duration")
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(n), nil
+}
+
+// ToTimeE converts `v` to a `time.Time`.
+//
+// Strings are parsed with the common layouts, from RFC 3339 to "2006-01-02" and the formats of the
+// `time` package; strings without a zone are interpreted as UTC. Numbers, including `json.Number`
+// and numeric strings, are interpreted as Unix timestamps in seconds.
+//
+// Parameters:
+//   - `v`: The value to convert.
+//
+// Returns:
+//   - The converted value.
+//   - An error if `v` cannot be converted.
+//
+// Example:
+//
+//	t, err := ToTimeE("2024-03-01")  // 2024-03-01 00:00:00 UTC, nil
+//	t, err = ToTimeE(1700000000)     // 2023-11-14 22:13:20 UTC, nil
+func ToTimeE(v interface{}) (time.Time, error) {
+	value := castIndirect(v)
+	if !value.IsValid() {
+		return time.Time{}, nil
+	}
+	if t, ok := value.Interface().(time.Time); ok {
+		return t, nil
+	}
+	if value.Kind() == reflect.String {
+		s := strings.TrimSpace(value.String())
+		for _, layout := range castTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+		if seconds, err := ToInt64E(s); err == nil {
+			return time.Unix(seconds, 0).UTC(), nil
+		}
+		return time.Time{}, castError(v, "time.Time", "unrecognized time format")
+	}
+	if value.Kind() == reflect.Bool {
+		return time.Time{}, castError(v, "time.Time", "")
+	}
+	seconds, err := castInt64(v, "time.Time")
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
+// ToSliceE converts `v`, which must be a slice or an array, to a `[]T`, converting every element.
+//
+// Elements are converted with the matching `To...E` function when `T` is int, int64, float64, bool,
+// string, `time.Duration` or `time.Time`; for other types they must be assignable or convertible to `T`.
+// Pointers are dereferenced and nil converts to a nil slice.
+//
+// Parameters:
+//   - `v`: The value to convert.
+//
+// Returns:
+//   - The converted slice.
+//   - An error naming the first element that cannot be converted.
+//
+// Example:
+//
+//	ids, err := ToSliceE[int]([]interface{}{1.0, "2", json.Number("3")}) // []int{1, 2, 3}, nil
+func ToSliceE[T any](v interface{}) ([]T, error) {
+	value := castIndirect(v)
+	if !value.IsValid() {
+		return nil, nil
+	}
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, castError(v, fmt.Sprintf("[]%s", reflect.TypeOf((*T)(nil)).Elem()), "")
+	}
+	result := make([]T, value.Len())
+	for i := range result {
+		item, err := castElement[T](value.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("index %d: %w", i, err)
+		}
+		result[i] = item
+	}
+	return result, nil
+}
+
+// ToStringMapE converts `v` to a `map[string]interface{}`.
+//
+// A `map[string]interface{}` is returned as is. Other maps are copied, with their keys converted by
+// `ToStringE`; structs are converted by `StructToMap`; strings are decoded as JSON objects.
+// Pointers are dereferenced and nil converts to a nil map.
+//
+// Parameters:
+//   - `v`: The value to convert.
+//
+// Returns:
+//   - The converted map.
+//   - An error if `v` cannot be converted.
+//
+// Example:
+//
+//	m, err := ToStringMapE(map[interface{}]interface{}{"a": 1, 2: "b"}) // map[string]interface{}{"a": 1, "2": "b"}, nil
+//	m, err = ToStringMapE(`{"a": 1}`)                                  // map[string]interface{}{"a": 1.0}, nil
+func ToStringMapE(v interface{}) (map[string]interface{}, error) {
+	value := castIndirect(v)
+	if !value.IsValid() {
+		return nil, nil
+	}
+	if m, ok := value.Interface().(map[string]interface{}); ok {
+		return m, nil
+	}
+	switch value.Kind() {
+	case reflect.Map:
+		result := make(map[string]interface{}, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			key, err := ToStringE(iter.Key().Interface())
+			if err != nil {
+				return nil, castError(v, "map[string]interface{}", fmt.Sprintf("unsupported key %v", iter.Key().Interface()))
+			}
+			result[key] = iter.Value().Interface()
+		}
+		return result, nil
+	case reflect.Struct:
+		if value.Type() == reflect.TypeOf(time.Time{}) {
+			return nil, castError(v, "map[string]interface{}", "")
+		}
+		return StructToMap(value.Interface(), nil)
+	case reflect.String:
+		var result map[string]interface{}
+		if err := json.Unmarshal([]byte(value.String()), &result); err != nil {
+			return nil, castError(v, "map[string]interface{}", "invalid JSON object")
+		}
+		return result, nil
+	default:
+		return nil, castError(v, "map[string]interface{}", "")
+	}
+}
+
+// ToInt converts `v` to an int like `ToIntE`, returning `defaultValue` (or 0) when the conversion fails.
+//
+// Example:
+//
+//	port := ToInt(config["port"], 8080)
+func ToInt(v interface{}, defaultValue ...int) int {
+	value, err := ToIntE(v)
+	return castOrDefault(value, err, defaultValue)
+}
+
+// ToInt64 converts `v` to an int64 like `ToInt64E`, returning `defaultValue` (or 0) when the conversion fails.
+//
+// Example:
+//
+//	size := ToInt64(headers["size"], -1)
+func ToInt64(v interface{}, defaultValue ...int64) int64 {
+	value, err := ToInt64E(v)
+	return castOrDefault(value, err, defaultValue)
+}
+
+// ToFloat64 converts `v` to a float64 like `ToFloat64E`, returning `defaultValue` (or 0) when the conversion fails.
+//
+// Example:
+//
+//	ratio := ToFloat64(config["ratio"], 0.5)
+func ToFloat64(v interface{}, defaultValue ...float64) float64 {
+	value, err := ToFloat64E(v)
+	return castOrDefault(value, err, defaultValue)
+}
+
+// ToBool converts `v` to a bool like `ToBoolE`, returning `defaultValue` (or false) when the conversion fails.
+//
+// Example:
+//
+//	debug := ToBool(os.Getenv("DEBUG"))
+func ToBool(v interface{}, defaultValue ...bool) bool {
+	value, err := ToBoolE(v)
+	return castOrDefault(value, err, defaultValue)
+}
+
+// ToString converts `v` to a string like `ToStringE`, returning `defaultValue` (or "") when the conversion fails.
+//
+// Example:
+//
+//	name := ToString(config["name"], "anonymous")
+func ToString(v interface{}, defaultValue ...string) string {
+	value, err := ToStringE(v)
+	return castOrDefault(value, err, defaultValue)
+}
+
+// ToDuration converts `v` to a `time.Duration` like `ToDurationE`, returning `defaultValue` (or 0) when the conversion fails.
+//
+// Example:
+//
+//	timeout := ToDuration(config["timeout"], 30*time.Second)
+func ToDuration(v interface{}, defaultValue ...time.Duration) time.Duration {
+	value, err := ToDurationE(v)
+	return castOrDefault(value, err, defaultValue)
+}
+
+// ToTime converts `v` to a `time.Time` like `ToTimeE`, returning `defaultValue` (or the zero time) when the conversion fails.
+//
+// Example:
+//
+//	createdAt := ToTime(record["created_at"])
+func ToTime(v interface{}, defaultValue ...time.Time) time.Time {
+	value, err := ToTimeE(v)
+	return castOrDefault(value, err, defaultValue)
+}
+
+// ToSlice converts `v` to a `[]T` like `ToSliceE`, returning `defaultValue` (or nil) when the conversion fails.
+//
+// Example:
+//
+//	tags := ToSlice[string](payload["tags"], []string{})
+func ToSlice[T any](v interface{}, defaultValue ...[]T) []T {
+	value, err := ToSliceE[T](v)
+	return castOrDefault(value, err, defaultValue)
+}
+
+// ToStringMap converts `v` to a `map[string]interface{}` like `ToStringMapE`, returning `defaultValue` (or nil) when the conversion fails.
+//
+// Example:
+//
+//	labels := ToStringMap(payload["labels"], map[string]interface{}{})
+func ToStringMap(v interface{}, defaultValue ...map[string]interface{}) map[string]interface{} {
+	value, err := ToStringMapE(v)
+	return castOrDefault(value, err, defaultValue)
+}
+
+// castOrDefault returns `value`, or when `err` is not nil the first of `defaults` (or the zero value).
+func castOrDefault[T any](value T, err error, defaults []T) T {
+	if err == nil {
+		return value
+	}
+	if len(defaults) > 0 {
+		return defaults[0]
+	}
+	var zero T
+	return zero
+}
+
+// castElement converts a single slice element to `T` for `ToSliceE`.
+func castElement[T any](v interface{}) (T, error) {
+	var zero T
+	var result interface{}
+	var err error
+	switch any(zero).(type) {
+	case int:
+		result, err = ToIntE(v)
+	case int64:
+		result, err = ToInt64E(v)
+	case float64:
+		result, err = ToFloat64E(v)
+	case bool:
+		result, err = ToBoolE(v)
+	case string:
+		result, err = ToStringE(v)
+	case time.Duration:
+		result, err = ToDurationE(v)
+	case time.Time:
+		result, err = ToTimeE(v)
+	default:
+		if item, ok := v.(T); ok {
+			return item, nil
+		}
+		target := reflect.TypeOf((*T)(nil)).Elem()
+		value := castIndirect(v)
+		if value.IsValid() && value.Type().ConvertibleTo(target) && (value.Kind() == target.Kind() || isNumberKind(value.Kind()) && isNumberKind(target.Kind())) {
+			return value.Convert(target).Interface().(T), nil
+		}
+		return zero, castError(v, target.String(), "")
+	}
+	if err != nil {
+		return zero, err
+	}
+	return result.(T), nil
+}
+
+// castIndirect returns the value held by `v`, dereferencing pointers; it is invalid when `v` is nil
+// or a nil pointer.
+func castIndirect(v interface{}) reflect.Value {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// parseCastInt parses an integer, or a float without a fractional part, from `s`.
+func parseCastInt(s string, v interface{}, target string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, castError(v, target, "invalid syntax")
+	}
+	return floatToInt64(f, v, target)
+}
+
+// parseCastFloat parses a float from `s`.
+func parseCastFloat(s string, v interface{}) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, castError(v, "float64", "invalid syntax")
+	}
+	return f, nil
+}
+
+// floatToInt64 converts `f` to an int64, failing when it has a fractional part or is out of range.
+func floatToInt64(f float64, v interface{}, target string) (int64, error) {
+	if f != math.Trunc(f) || math.IsNaN(f) {
+		return 0, castError(v, target, "value has a fractional part")
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, castError(v, target, "value out of range")
+	}
+	return int64(f), nil
+}
+
+// castError returns the error reported when `v` cannot be converted to `target`.
+func castError(v interface{}, target, reason string) error {
+	if reason == "" {
+		return fmt.Errorf("cast: cannot convert %#v of type %T to %s", v, v, target)
+	}
+	return fmt.Errorf("cast: cannot convert %#v of type %T to %s: %s", v, v, target, reason)
+}
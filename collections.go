@@ -2746,3 +2746,904 @@ func RotateRight(collection interface{}, positions int) interface{} {
 	}
 	return collection
 }
+
+// ForEach calls `callback` for every element of `slice`, passing the element's index and value.
+//
+// It is the type-safe generic counterpart of `Iterate` for slices: the callback receives values of
+// type `T` directly, so no type assertion or reflection is involved.
+//
+// Parameters:
+//   - `slice`: The input slice to iterate over.
+//   - `callback`: A function invoked with the index and the value of each element.
+//
+// Example:
+//
+//	ForEach([]string{"a", "b"}, func(i int, s string) {
+//		fmt.Printf("%d=%s\n", i, s)
+//	})
+//	// Output:
+//	// 0=a
+//	// 1=b
+func ForEach[T any](slice []T, callback func(index int, value T)) {
+	for i, v := range slice {
+		callback(i, v)
+	}
+}
+
+// FindFirst returns the first element of `slice` that satisfies `predicate`.
+//
+// It is the type-safe generic counterpart of `Find`. Instead of returning `nil` when nothing
+// matches, it reports the outcome through its second return value, which also makes it usable
+// for slices whose zero value is a legitimate element.
+//
+// Parameters:
+//   - `slice`: The input slice to search.
+//   - `predicate`: The condition the element must satisfy.
+//
+// Returns:
+//   - The first matching element and `true`, or the zero value of `T` and `false` if none matches.
+//
+// Example:
+//
+//	numbers := []int{1, 3, 4, 6}
+//	even, ok := FindFirst(numbers, func(n int) bool { return n%2 == 0 })
+//	// even will be 4, ok will be true
+func FindFirst[T any](slice []T, predicate func(T) bool) (T, bool) {
+	for _, v := range slice {
+		if predicate(v) {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// CountIf returns the number of elements of `slice` that satisfy `condition`.
+//
+// It is the type-safe generic counterpart of `Count`.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `condition`: The condition evaluated on each element.
+//
+// Returns:
+//   - The number of elements for which `condition` returned `true`.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4}
+//	evens := CountIf(numbers, func(n int) bool { return n%2 == 0 })
+//	// evens will be 2
+func CountIf[T any](slice []T, condition func(T) bool) int {
+	count := 0
+	for _, v := range slice {
+		if condition(v) {
+			count++
+		}
+	}
+	return count
+}
+
+// RemoveIf returns a new slice containing the elements of `slice` that do not satisfy `condition`.
+//
+// It is the type-safe generic counterpart of `RemoveN`. The original slice is not modified.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `condition`: The condition identifying the elements to remove.
+//
+// Returns:
+//   - A new slice with the remaining elements in their original order.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5}
+//	odds := RemoveIf(numbers, func(n int) bool { return n%2 == 0 })
+//	// odds will be []int{1, 3, 5}
+func RemoveIf[T any](slice []T, condition func(T) bool) []T {
+	result := make([]T, 0, len(slice))
+	for _, v := range slice {
+		if !condition(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// SubSlice returns a new slice holding a copy of the elements of `slice` from `start` (inclusive)
+// to `end` (exclusive).
+//
+// It is the type-safe generic counterpart of `Slice`, with the same clamping rules: a negative
+// `start` is treated as 0 and an `end` past the length is treated as the length. When `start` is not
+// before `end`, an empty slice is returned. Unlike a plain slice expression, the result never shares
+// memory with the input.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `start`: The first index to include.
+//   - `end`: The index at which to stop.
+//
+// Returns:
+//   - A new slice with the selected elements.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5, 6, 7}
+//	result := SubSlice(numbers, 2, 5)
+//	// result will be []int{3, 4, 5}
+func SubSlice[T any](slice []T, start, end int) []T {
+	if start < 0 {
+		start = 0
+	}
+	if end > len(slice) {
+		end = len(slice)
+	}
+	if start >= end {
+		return []T{}
+	}
+	result := make([]T, end-start)
+	copy(result, slice[start:end])
+	return result
+}
+
+// SliceByIndices returns a new slice with the elements of `slice` found at the given `indices`,
+// in the order the indices are listed.
+//
+// It is the type-safe generic counterpart of `SliceWithIndices`: indices outside the bounds of the
+// slice are ignored.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `indices`: The positions of the elements to pick.
+//
+// Returns:
+//   - A new slice with the picked elements.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5, 6, 7}
+//	result := SliceByIndices(numbers, []int{1, 3, 5, 9})
+//	// result will be []int{2, 4, 6}
+func SliceByIndices[T any](slice []T, indices []int) []T {
+	result := make([]T, 0, len(indices))
+	for _, index := range indices {
+		if index >= 0 && index < len(slice) {
+			result = append(result, slice[index])
+		}
+	}
+	return result
+}
+
+// PartitionSlice splits `slice` into the elements that satisfy `condition` and those that do not.
+//
+// It is the type-safe generic counterpart of `Partition`. Both returned slices are non-nil and keep
+// the relative order of the input.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `condition`: The condition deciding which partition an element belongs to.
+//
+// Returns:
+//   - A slice of the elements for which `condition` returned `true`.
+//   - A slice of the elements for which `condition` returned `false`.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5, 6}
+//	evens, odds := PartitionSlice(numbers, func(n int) bool { return n%2 == 0 })
+//	// evens will be []int{2, 4, 6}, odds will be []int{1, 3, 5}
+func PartitionSlice[T any](slice []T, condition func(T) bool) ([]T, []T) {
+	truePartition := make([]T, 0)
+	falsePartition := make([]T, 0)
+	for _, v := range slice {
+		if condition(v) {
+			truePartition = append(truePartition, v)
+		} else {
+			falsePartition = append(falsePartition, v)
+		}
+	}
+	return truePartition, falsePartition
+}
+
+// Zip2 combines two slices element-wise into a slice of `Pair` values.
+//
+// It is the type-safe generic counterpart of `Zip` for two collections. The length of the result is
+// the length of the shorter input.
+//
+// Parameters:
+//   - `first`: The slice providing the `First` field of each pair.
+//   - `second`: The slice providing the `Second` field of each pair.
+//
+// Returns:
+//   - A slice of pairs combining elements at the same index.
+//
+// Example:
+//
+//	pairs := Zip2([]int{1, 2, 3}, []string{"a", "b"})
+//	// pairs will be []Pair[int, string]{{1, "a"}, {2, "b"}}
+func Zip2[A, B any](first []A, second []B) []Pair[A, B] {
+	n := min(len(first), len(second))
+	result := make([]Pair[A, B], n)
+	for i := 0; i < n; i++ {
+		result[i] = Pair[A, B]{First: first[i], Second: second[i]}
+	}
+	return result
+}
+
+// FoldRight reduces `slice` from right to left using `reducer`, starting from `initialValue`.
+//
+// It is the type-safe generic counterpart of `ReduceRight`.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `reducer`: A function combining the accumulator with the current element.
+//   - `initialValue`: The starting value of the accumulator.
+//
+// Returns:
+//   - The final accumulated value.
+//
+// Example:
+//
+//	letters := []string{"a", "b", "c"}
+//	reversed := FoldRight(letters, func(acc string, s string) string { return acc + s }, "")
+//	// reversed will be "cba"
+func FoldRight[T, U any](slice []T, reducer func(U, T) U, initialValue U) U {
+	accumulator := initialValue
+	for i := len(slice) - 1; i >= 0; i-- {
+		accumulator = reducer(accumulator, slice[i])
+	}
+	return accumulator
+}
+
+// RotateSliceLeft returns a new slice with the elements of `slice` rotated to the left by `positions`.
+//
+// It is the type-safe generic counterpart of `RotateLeft`: a negative `positions` rotates to the right,
+// and values larger than the length are normalized with modulo. Unlike `RotateLeft`, an empty slice is
+// handled gracefully and returns an empty slice.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `positions`: The number of positions to rotate left.
+//
+// Returns:
+//   - A new, rotated slice.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5}
+//	result := RotateSliceLeft(numbers, 2)
+//	// result will be []int{3, 4, 5, 1, 2}
+func RotateSliceLeft[T any](slice []T, positions int) []T {
+	length := len(slice)
+	result := make([]T, length)
+	if length == 0 {
+		return result
+	}
+	positions = (positions%length + length) % length
+	copy(result, slice[positions:])
+	copy(result[length-positions:], slice[:positions])
+	return result
+}
+
+// RotateSliceRight returns a new slice with the elements of `slice` rotated to the right by `positions`.
+//
+// It is the type-safe generic counterpart of `RotateRight` and follows the same rules: a negative
+// `positions` rotates right by its absolute value, and values larger than the length are normalized
+// with modulo.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `positions`: The number of positions to rotate right.
+//
+// Returns:
+//   - A new, rotated slice.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5}
+//	result := RotateSliceRight(numbers, 2)
+//	// result will be []int{4, 5, 1, 2, 3}
+func RotateSliceRight[T any](slice []T, positions int) []T {
+	if len(slice) == 0 {
+		return []T{}
+	}
+	length := len(slice)
+	if positions < 0 {
+		positions = (-positions%length + length) % length
+	} else {
+		positions = positions % length
+	}
+	result := make([]T, length)
+	for i, v := range slice {
+		result[(i+positions)%length] = v
+	}
+	return result
+}
+
+// UniqueBy returns a new slice containing the elements of `slice` whose key, computed with `key`,
+// has not been seen before. The first element for each key is kept and the original order is preserved.
+//
+// It is useful for deduplicating values that are not comparable, or that should be compared by a
+// single field such as an ID.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `key`: Returns the identity of an element.
+//
+// Returns:
+//   - A new slice with the first element for each distinct key, in order of first appearance.
+//
+// Example:
+//
+//	users := []User{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 1, Name: "c"}}
+//	result := UniqueBy(users, func(u User) int { return u.ID })
+//	// result will be []User{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+func UniqueBy[T any, K comparable](slice []T, key func(T) K) []T {
+	seen := make(map[K]struct{})
+	result := make([]T, 0)
+	for _, item := range slice {
+		k := key(item)
+		if _, found := seen[k]; found {
+			continue
+		}
+		seen[k] = struct{}{}
+		result = append(result, item)
+	}
+	return result
+}
+
+// UniqueFunc returns a new slice containing the elements of `slice` that are not equal, according to
+// `equal`, to an element kept before them. The original order is preserved.
+//
+// Since elements cannot be hashed, each element is compared against every kept element, so the cost
+// is quadratic. Prefer `UniqueBy` when a comparable key can be derived.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `equal`: Reports whether two elements are considered the same.
+//
+// Returns:
+//   - A new slice with the first element of each equivalence class, in order of first appearance.
+//
+// Example:
+//
+//	words := []string{"Go", "go", "Rust"}
+//	result := UniqueFunc(words, strings.EqualFold)
+//	// result will be []string{"Go", "Rust"}
+func UniqueFunc[T any](slice []T, equal func(a, b T) bool) []T {
+	result := make([]T, 0)
+	for _, item := range slice {
+		if !containsFunc(result, item, equal) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// IntersectBy returns the elements of `slice1` whose key also appears among the keys of `slice2`.
+// Each key is reported once, using its first element in `slice1`, and the order of `slice1` is preserved.
+//
+// Parameters:
+//   - `slice1`: The slice whose elements are returned.
+//   - `slice2`: The slice whose keys are matched against.
+//   - `key`: Returns the identity of an element.
+//
+// Returns:
+//   - A new slice with the elements common to both slices, in `slice1` order.
+//
+// Example:
+//
+//	result := IntersectBy(current, previous, func(u User) int { return u.ID })
+//	// result contains the users of current that were already present in previous
+func IntersectBy[T any, K comparable](slice1, slice2 []T, key func(T) K) []T {
+	keys := make(map[K]struct{}, len(slice2))
+	for _, item := range slice2 {
+		keys[key(item)] = struct{}{}
+	}
+	return UniqueBy(Filter(slice1, func(item T) bool {
+		_, found := keys[key(item)]
+		return found
+	}), key)
+}
+
+// IntersectFunc returns the elements of `slice1` that are equal, according to `equal`, to at least one
+// element of `slice2`. Duplicates within `slice1` are reported once and the order of `slice1` is preserved.
+//
+// Parameters:
+//   - `slice1`: The slice whose elements are returned.
+//   - `slice2`: The slice whose elements are matched against.
+//   - `equal`: Reports whether two elements are considered the same.
+//
+// Returns:
+//   - A new slice with the elements common to both slices, in `slice1` order.
+//
+// Example:
+//
+//	result := IntersectFunc([]string{"Go", "Java"}, []string{"go"}, strings.EqualFold)
+//	// result will be []string{"Go"}
+func IntersectFunc[T any](slice1, slice2 []T, equal func(a, b T) bool) []T {
+	return UniqueFunc(Filter(slice1, func(item T) bool {
+		return containsFunc(slice2, item, equal)
+	}), equal)
+}
+
+// DifferenceBy returns the elements of `slice1` whose key does not appear among the keys of `slice2`.
+// Each key is reported once, using its first element in `slice1`, and the order of `slice1` is preserved.
+//
+// Unlike `Difference`, the operation is one-sided: elements found only in `slice2` are not returned.
+// Use `SymmetricDifferenceBy` to obtain elements unique to either slice.
+//
+// Parameters:
+//   - `slice1`: The slice whose elements are returned.
+//   - `slice2`: The slice whose keys are excluded.
+//   - `key`: Returns the identity of an element.
+//
+// Returns:
+//   - A new slice with the elements of `slice1` absent from `slice2`, in `slice1` order.
+//
+// Example:
+//
+//	removed := DifferenceBy(previous, current, func(u User) int { return u.ID })
+//	// removed contains the users of previous that are no longer in current
+func DifferenceBy[T any, K comparable](slice1, slice2 []T, key func(T) K) []T {
+	keys := make(map[K]struct{}, len(slice2))
+	for _, item := range slice2 {
+		keys[key(item)] = struct{}{}
+	}
+	return UniqueBy(Filter(slice1, func(item T) bool {
+		_, found := keys[key(item)]
+		return !found
+	}), key)
+}
+
+// DifferenceFunc returns the elements of `slice1` that are not equal, according to `equal`, to any
+// element of `slice2`. Duplicates within `slice1` are reported once and the order of `slice1` is preserved.
+//
+// Parameters:
+//   - `slice1`: The slice whose elements are returned.
+//   - `slice2`: The slice whose elements are excluded.
+//   - `equal`: Reports whether two elements are considered the same.
+//
+// Returns:
+//   - A new slice with the elements of `slice1` absent from `slice2`, in `slice1` order.
+//
+// Example:
+//
+//	result := DifferenceFunc([]string{"Go", "Java"}, []string{"go"}, strings.EqualFold)
+//	// result will be []string{"Java"}
+func DifferenceFunc[T any](slice1, slice2 []T, equal func(a, b T) bool) []T {
+	return UniqueFunc(Filter(slice1, func(item T) bool {
+		return !containsFunc(slice2, item, equal)
+	}), equal)
+}
+
+// UnionBy returns the elements of `slice1` followed by those of `slice2`, keeping only the first
+// element for each key. Elements appear in order of first appearance across both slices.
+//
+// Parameters:
+//   - `slice1`: The first input slice.
+//   - `slice2`: The second input slice.
+//   - `key`: Returns the identity of an element.
+//
+// Returns:
+//   - A new slice with one element per distinct key.
+//
+// Example:
+//
+//	all := UnionBy(localUsers, remoteUsers, func(u User) int { return u.ID })
+//	// local entries win over remote entries sharing the same ID
+func UnionBy[T any, K comparable](slice1, slice2 []T, key func(T) K) []T {
+	return UniqueBy(append(append(make([]T, 0, len(slice1)+len(slice2)), slice1...), slice2...), key)
+}
+
+// UnionFunc returns the elements of `slice1` followed by those of `slice2`, keeping only the first
+// element of each group of elements that are equal according to `equal`.
+//
+// Parameters:
+//   - `slice1`: The first input slice.
+//   - `slice2`: The second input slice.
+//   - `equal`: Reports whether two elements are considered the same.
+//
+// Returns:
+//   - A new slice with one element per equivalence class, in order of first appearance.
+//
+// Example:
+//
+//	result := UnionFunc([]string{"Go"}, []string{"go", "Rust"}, strings.EqualFold)
+//	// result will be []string{"Go", "Rust"}
+func UnionFunc[T any](slice1, slice2 []T, equal func(a, b T) bool) []T {
+	return UniqueFunc(append(append(make([]T, 0, len(slice1)+len(slice2)), slice1...), slice2...), equal)
+}
+
+// SymmetricDifference returns the elements present in exactly one of `slice1` and `slice2`.
+//
+// The elements unique to `slice1` come first, in `slice1` order, followed by the elements unique
+// to `slice2`, in `slice2` order. Each value is reported once.
+//
+// Parameters:
+//   - `slice1`: The first input slice.
+//   - `slice2`: The second input slice.
+//
+// Returns:
+//   - A new slice with the elements found in only one of the slices.
+//
+// Example:
+//
+//	result := SymmetricDifference([]int{1, 2, 3, 4}, []int{3, 4, 5, 6})
+//	// result will be []int{1, 2, 5, 6}
+func SymmetricDifference[T comparable](slice1, slice2 []T) []T {
+	identity := func(item T) T { return item }
+	return SymmetricDifferenceBy(slice1, slice2, identity)
+}
+
+// SymmetricDifferenceBy returns the elements whose key appears in exactly one of `slice1` and `slice2`.
+// Ordering and deduplication follow `SymmetricDifference`.
+//
+// Parameters:
+//   - `slice1`: The first input slice.
+//   - `slice2`: The second input slice.
+//   - `key`: Returns the identity of an element.
+//
+// Returns:
+//   - A new slice with the elements whose key is found in only one of the slices.
+//
+// Example:
+//
+//	changed := SymmetricDifferenceBy(before, after, func(u User) int { return u.ID })
+//	// changed contains users that were either removed or added
+func SymmetricDifferenceBy[T any, K comparable](slice1, slice2 []T, key func(T) K) []T {
+	return append(DifferenceBy(slice1, slice2, key), DifferenceBy(slice2, slice1, key)...)
+}
+
+// CountBy groups the elements of `slice` by the key computed with `key` and counts the
+// elements in each group.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `key`: Returns the grouping key of an element.
+//
+// Returns:
+//   - A map from each key to the number of elements sharing it.
+//
+// Example:
+//
+//	words := []string{"go", "rust", "java", "c"}
+//	result := CountBy(words, func(s string) int { return len(s) })
+//	// result will be map[int]int{2: 1, 4: 2, 1: 1}
+func CountBy[T any, K comparable](slice []T, key func(T) K) map[K]int {
+	result := make(map[K]int)
+	for _, item := range slice {
+		result[key(item)]++
+	}
+	return result
+}
+
+// Frequencies counts the occurrences of each distinct element of `slice`.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//
+// Returns:
+//   - A map from each element to the number of times it appears.
+//
+// Example:
+//
+//	result := Frequencies([]string{"a", "b", "a"})
+//	// result will be map[string]int{"a": 2, "b": 1}
+func Frequencies[T comparable](slice []T) map[T]int {
+	result := make(map[T]int, len(slice))
+	for _, item := range slice {
+		result[item]++
+	}
+	return result
+}
+
+// MultisetIntersect returns the intersection of `slice1` and `slice2` treated as multisets:
+// an element occurring `m` times in `slice1` and `n` times in `slice2` appears `min(m, n)` times.
+// Elements are returned in `slice1` order.
+//
+// Parameters:
+//   - `slice1`: The slice whose elements are returned.
+//   - `slice2`: The slice providing the available occurrences.
+//
+// Returns:
+//   - A new slice with the common occurrences, duplicates preserved.
+//
+// Example:
+//
+//	result := MultisetIntersect([]int{1, 1, 2, 3, 1}, []int{1, 1, 3, 3})
+//	// result will be []int{1, 1, 3}
+func MultisetIntersect[T comparable](slice1, slice2 []T) []T {
+	available := Frequencies(slice2)
+	result := make([]T, 0)
+	for _, item := range slice1 {
+		if available[item] > 0 {
+			available[item]--
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// MultisetDifference returns the difference of `slice1` and `slice2` treated as multisets:
+// each occurrence of an element in `slice2` cancels one occurrence of the same element in `slice1`,
+// starting from the front. The remaining elements are returned in `slice1` order.
+//
+// Parameters:
+//   - `slice1`: The slice whose elements are returned.
+//   - `slice2`: The slice providing the occurrences to remove.
+//
+// Returns:
+//   - A new slice with the remaining occurrences, duplicates preserved.
+//
+// Example:
+//
+//	result := MultisetDifference([]int{1, 1, 2, 3, 1}, []int{1, 3})
+//	// result will be []int{1, 2, 1}
+func MultisetDifference[T comparable](slice1, slice2 []T) []T {
+	remove := Frequencies(slice2)
+	result := make([]T, 0)
+	for _, item := range slice1 {
+		if remove[item] > 0 {
+			remove[item]--
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// containsFunc reports whether `slice` contains an element equal to `target` according to `equal`.
+func containsFunc[T any](slice []T, target T, equal func(a, b T) bool) bool {
+	for _, item := range slice {
+		if equal(item, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Window returns the windows of `size` consecutive elements of `slice`, starting a new window every
+// `step` elements. Only complete windows are returned.
+//
+// Unlike `Chunk`, windows may overlap (when `step` < `size`) or skip elements (when `step` > `size`).
+// Each window shares the backing array of `slice`, but its capacity is limited to its length so that
+// appending to a window never overwrites its neighbours.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `size`: The number of elements in each window.
+//   - `step`: The distance between the starts of two consecutive windows.
+//
+// Returns:
+//   - A slice of windows, or nil if `size` or `step` is not positive or `slice` has fewer than
+//     `size` elements.
+//
+// Example:
+//
+//	numbers := []int{1, 2, 3, 4, 5}
+//	windows := Window(numbers, 3, 1)
+//	// windows will be [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}
+//	windows = Window(numbers, 2, 2)
+//	// windows will be [][]int{{1, 2}, {3, 4}}
+func Window[T any](slice []T, size, step int) [][]T {
+	if size <= 0 || step <= 0 {
+		return nil
+	}
+	var windows [][]T
+	for i := 0; i+size <= len(slice); i += step {
+		windows = append(windows, slice[i:i+size:i+size])
+	}
+	return windows
+}
+
+// Pairwise returns every pair of adjacent elements of `slice`.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//
+// Returns:
+//   - A slice of `len(slice)-1` pairs, or an empty slice if `slice` has fewer than two elements.
+//
+// Example:
+//
+//	readings := []int{10, 12, 15}
+//	pairs := Pairwise(readings)
+//	// pairs will be []Pair[int, int]{{10, 12}, {12, 15}}
+func Pairwise[T any](slice []T) []Pair[T, T] {
+	if len(slice) < 2 {
+		return []Pair[T, T]{}
+	}
+	pairs := make([]Pair[T, T], len(slice)-1)
+	for i := range pairs {
+		pairs[i] = Pair[T, T]{First: slice[i], Second: slice[i+1]}
+	}
+	return pairs
+}
+
+// ChunkBy splits `slice` into runs of consecutive elements sharing the same key, starting a new
+// chunk every time the value returned by `key` changes. A predicate (`func(T) bool`) can be used
+// as the key to split whenever its result flips.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `key`: Returns the value compared between neighbouring elements.
+//
+// Returns:
+//   - A slice of non-empty chunks, in order; nil if `slice` is empty.
+//
+// Example:
+//
+//	numbers := []int{1, 3, 2, 4, 5}
+//	chunks := ChunkBy(numbers, func(n int) bool { return n%2 == 0 })
+//	// chunks will be [][]int{{1, 3}, {2, 4}, {5}}
+func ChunkBy[T any, K comparable](slice []T, key func(T) K) [][]T {
+	var chunks [][]T
+	start := 0
+	for i := 1; i <= len(slice); i++ {
+		if i == len(slice) || key(slice[i]) != key(slice[i-1]) {
+			chunks = append(chunks, slice[start:i:i])
+			start = i
+		}
+	}
+	return chunks
+}
+
+// SplitAt divides `slice` into two new slices: the elements before `index` and the elements from `index` on.
+// `index` is clamped to the bounds of the slice.
+//
+// Parameters:
+//   - `slice`: The input slice.
+//   - `index`: The position at which to split.
+//
+// Returns:
+//   - A copy of `slice[:index]`.
+//   - A copy of `slice[index:]`.
+//
+// Example:
+//
+//	head, tail := SplitAt([]int{1, 2, 3, 4}, 1)
+//	// head will be []int{1}, tail will be []int{2, 3, 4}
+func SplitAt[T any](slice []T, index int) ([]T, []T) {
+	index = max(0, min(index, len(slice)))
+	return append([]T{}, slice[:index]...), append([]T{}, slice[index:]...)
+}
+
+// Interleave merges `slices` by taking one element from each in turn. Once a slice is exhausted
+// it is skipped, so the remaining elements of longer slices are appended in round-robin order.
+//
+// Parameters:
+//   - `slices`: The slices to interleave.
+//
+// Returns:
+//   - A new slice containing every element of `slices`.
+//
+// Example:
+//
+//	result := Interleave([]int{1, 2, 3}, []int{10, 20}, []int{100})
+//	// result will be []int{1, 10, 100, 2, 20, 3}
+func Interleave[T any](slices ...[]T) []T {
+	total, longest := 0, 0
+	for _, s := range slices {
+		total += len(s)
+		longest = max(longest, len(s))
+	}
+	result := make([]T, 0, total)
+	for i := 0; i < longest; i++ {
+		for _, s := range slices {
+			if i < len(s) {
+				result = append(result, s[i])
+			}
+		}
+	}
+	return result
+}
+
+// Transpose swaps the rows and columns of `matrix`, so that `result[j][i] == matrix[i][j]`.
+//
+// Ragged input is supported: column j of the result contains, in row order, the j-th element of
+// every row long enough to have one.
+//
+// Parameters:
+//   - `matrix`: The rows to transpose.
+//
+// Returns:
+//   - A new matrix with as many rows as the longest row of `matrix`.
+//
+// Example:
+//
+//	matrix := [][]int{{1, 2, 3}, {4, 5, 6}}
+//	result := Transpose(matrix)
+//	// result will be [][]int{{1, 4}, {2, 5}, {3, 6}}
+func Transpose[T any](matrix [][]T) [][]T {
+	columns := 0
+	for _, row := range matrix {
+		columns = max(columns, len(row))
+	}
+	result := make([][]T, columns)
+	for j := range result {
+		result[j] = make([]T, 0, len(matrix))
+		for _, row := range matrix {
+			if j < len(row) {
+				result[j] = append(result[j], row[j])
+			}
+		}
+	}
+	return result
+}
+
+// Fill sets every element of `slice` to `value`, in place.
+//
+// Parameters:
+//   - `slice`: The slice to fill.
+//   - `value`: The value assigned to every element.
+//
+// Returns:
+//   - The same slice, for chaining.
+//
+// Example:
+//
+//	buffer := Fill(make([]string, 3), "-")
+//	// buffer will be []string{"-", "-", "-"}
+func Fill[T any](slice []T, value T) []T {
+	for i := range slice {
+		slice[i] = value
+	}
+	return slice
+}
+
+// Range returns the numbers from `start` (inclusive) to `end` (exclusive), incrementing by one.
+//
+// Parameters:
+//   - `start`: The first value.
+//   - `end`: The exclusive upper bound.
+//
+// Returns:
+//   - A new slice of numbers; empty if `start` >= `end`.
+//
+// Example:
+//
+//	numbers := Range(0, 5)
+//	// numbers will be []int{0, 1, 2, 3, 4}
+func Range[T Number](start, end T) []T {
+	return RangeStep(start, end, 1)
+}
+
+// RangeStep returns the numbers from `start` (inclusive) towards `end` (exclusive), separated by `step`.
+// A negative `step` produces a descending sequence; a zero `step` produces an empty slice.
+//
+// Parameters:
+//   - `start`: The first value.
+//   - `end`: The exclusive bound.
+//   - `step`: The increment between consecutive values.
+//
+// Returns:
+//   - A new slice of numbers.
+//
+// Example:
+//
+//	evens := RangeStep(0, 10, 2)
+//	// evens will be []int{0, 2, 4, 6, 8}
+//	countdown := RangeStep(3, 0, -1)
+//	// countdown will be []int{3, 2, 1}
+func RangeStep[T Number](start, end, step T) []T {
+	result := make([]T, 0)
+	var zero T
+	switch {
+	case step > zero:
+		for v := start; v < end; v += step {
+			result = append(result, v)
+			if v+step <= v {
+				break // overflow
+			}
+		}
+	case step < zero:
+		for v := start; v > end; v += step {
+			result = append(result, v)
+			if v+step >= v {
+				break // overflow
+			}
+		}
+	}
+	return result
+}
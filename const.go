@@ -20,6 +20,26 @@ var (
 	// be trimmed or removed.
 	RegexpDupSpaces = regexp.MustCompile(`\s+`)
 
+	// RegexpEmail is a precompiled regular expression that matches a practical subset of e-mail
+	// addresses: a local part of letters, digits and common punctuation, an '@', and a domain made
+	// of dot-separated labels ending with an alphabetic top-level domain. It is used by the `email`
+	// validation rule.
+	RegexpEmail = regexp.MustCompile(`^[a-zA-Z0-9.!#$%&'*+/=?^_{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*\.[a-zA-Z]{2,}$`)
+
+	// RegexpUUID is a precompiled regular expression that matches a UUID in its canonical textual
+	// form (8-4-4-4-12 hexadecimal digits), regardless of letter case. It is used by the `uuid`
+	// validation rule.
+	RegexpUUID = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+	// regexpBlank, regexpNonDigits, regexpSurroundingSpaces, regexpTrailingSpaces and
+	// regexpLeadingSpaces are the precompiled expressions of `IsBlank`, `OnlyDigits`, `Strip`,
+	// `StripEnd` and `StripStart`.
+	regexpBlank             = regexp.MustCompile(`^\s+$`)
+	regexpNonDigits         = regexp.MustCompile(`[\D]`)
+	regexpSurroundingSpaces = regexp.MustCompile(`^\s+|\s+$`)
+	regexpTrailingSpaces    = regexp.MustCompile(`\s+$`)
+	regexpLeadingSpaces     = regexp.MustCompile(`^\s+`)
+
 	// MaxRuneBytes represents the maximum valid UTF-8 encoding of a Unicode code point.
 	// It is a byte slice containing the specific byte values [244, 143, 191, 191].
 	MaxRuneBytes = [...]byte{244, 143, 191, 191}
@@ -44,3 +64,75 @@ const (
 	jsonTrue                   // Represents a JSON true boolean
 	jsonJson                   // Represents a JSON object or array
 )
+
+const (
+	// PercentileLinear interpolates linearly between the two closest ranks.
+	PercentileLinear PercentileMethod = iota
+	// PercentileLower takes the closest data point below the rank.
+	PercentileLower
+	// PercentileHigher takes the closest data point above the rank.
+	PercentileHigher
+	// PercentileNearest takes the data point whose rank is closest, rounding halves away from zero.
+	PercentileNearest
+	// PercentileMidpoint takes the average of the two closest data points.
+	PercentileMidpoint
+)
+
+const (
+	// MergeArrayReplace replaces the target array with the source array.
+	MergeArrayReplace MergeArrayStrategy = iota
+	// MergeArrayAppend appends the elements of the source array to the target array.
+	MergeArrayAppend
+	// MergeArrayDedupe appends the elements of the source array to the target array, then removes
+	// duplicate elements (compared with reflect.DeepEqual), keeping the first occurrence.
+	MergeArrayDedupe
+)
+
+const (
+	// TableFormatASCII renders a table with +, - and | borders.
+	TableFormatASCII TableFormat = iota
+	// TableFormatUnicode renders a table with Unicode box-drawing borders.
+	TableFormatUnicode
+	// TableFormatMarkdown renders a GitHub Flavored Markdown table.
+	TableFormatMarkdown
+	// TableFormatCSV renders the table as RFC 4180 comma-separated values.
+	TableFormatCSV
+	// TableFormatHTML renders the table as an HTML <table> element.
+	TableFormatHTML
+)
+
+const (
+	// TableAlignLeft aligns cells to the left (default).
+	TableAlignLeft TableAlign = iota
+	// TableAlignRight aligns cells to the right.
+	TableAlignRight
+	// TableAlignCenter centers cells.
+	TableAlignCenter
+)
+
+const (
+	// TableOverflowTruncate cuts cells that are too wide and ends them with "..." (default).
+	TableOverflowTruncate TableOverflow = iota
+	// TableOverflowWrap wraps cells that are too wide onto several lines.
+	TableOverflowWrap
+)
+
+const (
+	// TemplateEscapeNone inserts values as they are (default).
+	TemplateEscapeNone TemplateEscape = iota
+	// TemplateEscapeHTML escapes the HTML special characters <, >, &, ' and ".
+	TemplateEscapeHTML
+	// TemplateEscapeShell quotes values as single POSIX shell words.
+	TemplateEscapeShell
+	// TemplateEscapeSQLIdentifier quotes values as SQL identifiers, such as table or column names.
+	TemplateEscapeSQLIdentifier
+)
+
+const (
+	// DiffEqual marks text present in both inputs of a diff.
+	DiffEqual DiffOperation = iota
+	// DiffDelete marks text present only in the first input.
+	DiffDelete
+	// DiffInsert marks text present only in the second input.
+	DiffInsert
+)
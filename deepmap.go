@@ -0,0 +1,576 @@
+package unify4g
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pathSegment is one step of a path such as `a.b[2].c`: either a map key or an array index.
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// unflattenNode is the intermediate node built by `UnflattenMap`; it is distinguished from
+// user-supplied map values so that only generated nodes are turned into arrays.
+type unflattenNode map[string]interface{}
+
+// FlattenMap converts a nested map into a single-level map whose keys are the paths to each leaf,
+// joined with `sep`. Nested `map[string]interface{}` values are flattened by key and `[]interface{}`
+// values by index, so `{"a": {"b": [{"c": 1}]}}` becomes `{"a.b.0.c": 1}`.
+//
+// Empty maps and arrays are kept as leaf values so that `UnflattenMap` can restore them.
+//
+// Parameters:
+//   - `m`: The map to flatten.
+//   - `sep`: The separator placed between path segments. An empty separator defaults to ".".
+//
+// Returns:
+//   - A new, flat map.
+//
+// Example:
+//
+//	config := map[string]interface{}{
+//		"db":    map[string]interface{}{"host": "localhost", "port": 5432},
+//		"hosts": []interface{}{"a", "b"},
+//	}
+//	flat := FlattenMap(config, ".")
+//	// flat will be map[string]interface{}{"db.host": "localhost", "db.port": 5432, "hosts.0": "a", "hosts.1": "b"}
+func FlattenMap(m map[string]interface{}, sep string) map[string]interface{} {
+	if sep == "" {
+		sep = "."
+	}
+	result := make(map[string]interface{})
+	for key, value := range m {
+		flattenInto(result, key, value, sep)
+	}
+	return result
+}
+
+// UnflattenMap is the inverse of `FlattenMap`: it splits every key of `flat` on `sep` and rebuilds
+// the nested structure. Levels whose keys are exactly "0" to "n-1" become `[]interface{}` arrays;
+// every other level becomes a `map[string]interface{}`.
+//
+// Parameters:
+//   - `flat`: The flat map to expand.
+//   - `sep`: The separator between path segments. An empty separator defaults to ".".
+//
+// Returns:
+//   - A new, nested map.
+//   - An error if two keys conflict, such as "a" and "a.b" both holding a value.
+//
+// Example:
+//
+//	nested, err := UnflattenMap(map[string]interface{}{"db.host": "localhost", "hosts.0": "a"}, ".")
+//	// nested will be map[string]interface{}{
+//	//	"db":    map[string]interface{}{"host": "localhost"},
+//	//	"hosts": []interface{}{"a"},
+//	// }
+func UnflattenMap(flat map[string]interface{}, sep string) (map[string]interface{}, error) {
+	if sep == "" {
+		sep = "."
+	}
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	root := make(unflattenNode)
+	for _, key := range keys {
+		parts := strings.Split(key, sep)
+		node := root
+		for i, part := range parts[:len(parts)-1] {
+			child, exists := node[part]
+			if !exists {
+				child = make(unflattenNode)
+				node[part] = child
+			}
+			next, ok := child.(unflattenNode)
+			if !ok {
+				return nil, fmt.Errorf("unflatten conflict: key '%s' holds a value, cannot expand '%s'", strings.Join(parts[:i+1], sep), key)
+			}
+			node = next
+		}
+		last := parts[len(parts)-1]
+		if _, exists := node[last]; exists {
+			return nil, fmt.Errorf("unflatten conflict: key '%s' is both a value and a parent", key)
+		}
+		node[last] = flat[key]
+	}
+	return buildUnflattened(root).(map[string]interface{}), nil
+}
+
+// GetIn returns the value found at `path` inside a nested structure of maps and arrays.
+//
+// A path is a sequence of keys separated by dots, where array elements are addressed either with
+// brackets or with a numeric key: `a.b[2].c` and `a.b.2.c` are equivalent.
+//
+// Parameters:
+//   - `m`: The root map.
+//   - `path`: The path to the value.
+//
+// Returns:
+//   - The value, and `true`; or nil and `false` if the path is invalid or does not exist.
+//
+// Example:
+//
+//	config := map[string]interface{}{"servers": []interface{}{map[string]interface{}{"port": 80}}}
+//	port, ok := GetIn(config, "servers[0].port")
+//	// port will be 80, ok will be true
+func GetIn(m map[string]interface{}, path string) (interface{}, bool) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, false
+	}
+	var node interface{} = m
+	for _, segment := range segments {
+		switch current := node.(type) {
+		case map[string]interface{}:
+			value, exists := current[segment.mapKey()]
+			if !exists {
+				return nil, false
+			}
+			node = value
+		case []interface{}:
+			index, ok := segment.position()
+			if !ok || index >= len(current) {
+				return nil, false
+			}
+			node = current[index]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+// SetIn stores `value` at `path` inside a nested structure of maps and arrays, using the path
+// syntax of `GetIn`. Missing intermediate levels are created: a map for a key segment and an
+// array for a bracketed index. An index equal to the length of an array appends an element; larger
+// indexes are out of range.
+//
+// Parameters:
+//   - `m`: The root map, modified in place.
+//   - `path`: The path at which to store the value.
+//   - `value`: The value to store.
+//
+// Returns:
+//   - An error if the path is invalid, an index is out of range, or the path crosses a value that is
+//     neither a map nor an array.
+//
+// Example:
+//
+//	config := map[string]interface{}{}
+//	err := SetIn(config, "servers[0].port", 8080)
+//	// config will be map[string]interface{}{
+//	//	"servers": []interface{}{map[string]interface{}{"port": 8080}},
+//	// }
+func SetIn(m map[string]interface{}, path string, value interface{}) error {
+	segments, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	_, err = setNode(m, segments, value, path)
+	return err
+}
+
+// DeleteIn removes the value at `path` inside a nested structure of maps and arrays, using the path
+// syntax of `GetIn`. Map entries are deleted; array elements are removed and later elements shifted.
+//
+// Parameters:
+//   - `m`: The root map, modified in place.
+//   - `path`: The path of the value to remove.
+//
+// Returns:
+//   - `true` if a value was removed, `false` if the path is invalid or does not exist.
+//
+// Example:
+//
+//	config := map[string]interface{}{"db": map[string]interface{}{"password": "secret", "host": "localhost"}}
+//	removed := DeleteIn(config, "db.password")
+//	// removed will be true, config["db"] will be map[string]interface{}{"host": "localhost"}
+func DeleteIn(m map[string]interface{}, path string) bool {
+	segments, err := parsePath(path)
+	if err != nil {
+		return false
+	}
+	_, removed := deleteNode(m, segments)
+	return removed
+}
+
+// PickKeys returns a copy of `m` containing only the entries matched by at least one of `patterns`.
+//
+// Each pattern is a dot-separated path whose segments are matched against the keys of successive
+// map levels with `Match`, so they may contain the '*' and '?' wildcards: "db.*" keeps every entry
+// under "db", and "*.host" keeps the "host" entry of every top-level map. Only nested
+// `map[string]interface{}` values are traversed.
+//
+// Parameters:
+//   - `m`: The source map; it is not modified.
+//   - `patterns`: The paths to keep.
+//
+// Returns:
+//   - A new map with the selected entries. Parent maps left empty by the selection are omitted.
+//
+// Example:
+//
+//	config := map[string]interface{}{
+//		"db":  map[string]interface{}{"host": "localhost", "password": "secret"},
+//		"log": "debug",
+//	}
+//	result := PickKeys(config, "db.host", "l*")
+//	// result will be map[string]interface{}{"db": map[string]interface{}{"host": "localhost"}, "log": "debug"}
+func PickKeys(m map[string]interface{}, patterns ...string) map[string]interface{} {
+	return pickMap(m, splitPatterns(patterns))
+}
+
+// OmitKeys returns a copy of `m` without the entries matched by any of `patterns`, using the
+// pattern syntax of `PickKeys`.
+//
+// Parameters:
+//   - `m`: The source map; it is not modified.
+//   - `patterns`: The paths to remove.
+//
+// Returns:
+//   - A new map without the matched entries.
+//
+// Example:
+//
+//	config := map[string]interface{}{
+//		"db":    map[string]interface{}{"host": "localhost", "password": "secret"},
+//		"cache": map[string]interface{}{"password": "secret"},
+//	}
+//	result := OmitKeys(config, "*.password")
+//	// result will be map[string]interface{}{"db": map[string]interface{}{"host": "localhost"}, "cache": map[string]interface{}{}}
+func OmitKeys(m map[string]interface{}, patterns ...string) map[string]interface{} {
+	return omitMap(m, splitPatterns(patterns))
+}
+
+// DeepMergeMapWith merges `source` into `target` like `DeepMergeMap`, with configurable behaviour.
+//
+// Nested maps present on both sides are merged recursively. Arrays ([]interface{}) present on both
+// sides are combined according to `options.ArrayStrategy`. Any other value from `source` overwrites
+// the one in `target`, unless `options.StrictTypes` is set and the two values have different types,
+// in which case an error naming the conflicting path is returned. Keys are processed in sorted order,
+// and `target` may be partially merged when an error is returned.
+//
+// Parameters:
+//   - `target`: The map that will be updated with values from the `source`. It is modified in place.
+//   - `source`: The map whose values will be merged into the `target`.
+//   - `options`: The merge configuration. If nil, `DefaultMergeOptionsConfig` is used.
+//
+// Returns:
+//   - An error if a type conflict is found in strict mode.
+//
+// Example:
+//
+//	target := map[string]interface{}{"tags": []interface{}{"a", "b"}, "port": 80}
+//	source := map[string]interface{}{"tags": []interface{}{"b", "c"}, "port": "80"}
+//	err := DeepMergeMapWith(target, source, &MergeOptionsConfig{ArrayStrategy: MergeArrayDedupe})
+//	// target will be map[string]interface{}{"tags": []interface{}{"a", "b", "c"}, "port": "80"}
+//
+//	err = DeepMergeMapWith(target, map[string]interface{}{"port": 8080}, &MergeOptionsConfig{StrictTypes: true})
+//	// err will be "merge conflict at 'port': cannot merge int into string"
+func DeepMergeMapWith(target, source map[string]interface{}, options *MergeOptionsConfig) error {
+	if options == nil {
+		options = DefaultMergeOptionsConfig
+	}
+	return mergeMaps(target, source, options, "")
+}
+
+// mapKey returns the key used to address the segment inside a map.
+func (segment pathSegment) mapKey() string {
+	if segment.isIndex {
+		return strconv.Itoa(segment.index)
+	}
+	return segment.key
+}
+
+// position returns the array index addressed by the segment, if it is a bracketed or numeric key.
+func (segment pathSegment) position() (int, bool) {
+	if segment.isIndex {
+		return segment.index, true
+	}
+	index, err := strconv.Atoi(segment.key)
+	if err != nil || index < 0 || segment.key[0] == '+' {
+		return 0, false
+	}
+	return index, true
+}
+
+// parsePath splits a path such as `a.b[2].c` into its segments.
+func parsePath(path string) ([]pathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("invalid path: path is empty")
+	}
+	var segments []pathSegment
+	i := 0
+	for i < len(path) {
+		if path[i] == '[' {
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path '%s': missing ']'", path)
+			}
+			index, err := strconv.Atoi(path[i+1 : i+end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid path '%s': bad array index '%s'", path, path[i+1:i+end])
+			}
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+			i += end + 1
+		} else {
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path '%s': empty key at offset %d", path, i)
+			}
+			segments = append(segments, pathSegment{key: path[i : i+end]})
+			i += end
+		}
+		if i < len(path) && path[i] == '.' {
+			i++
+			if i == len(path) {
+				return nil, fmt.Errorf("invalid path '%s': trailing '.'", path)
+			}
+		} else if i < len(path) && path[i] != '[' {
+			return nil, fmt.Errorf("invalid path '%s': unexpected '%c' at offset %d", path, path[i], i)
+		}
+	}
+	return segments, nil
+}
+
+// setNode stores `value` at `segments` below `node` and returns the possibly reallocated node.
+func setNode(node interface{}, segments []pathSegment, value interface{}, path string) (interface{}, error) {
+	segment, rest := segments[0], segments[1:]
+	child := func(current interface{}) (interface{}, error) {
+		if len(rest) == 0 {
+			return value, nil
+		}
+		if current == nil {
+			if rest[0].isIndex {
+				current = []interface{}{}
+			} else {
+				current = make(map[string]interface{})
+			}
+		}
+		return setNode(current, rest, value, path)
+	}
+	switch current := node.(type) {
+	case map[string]interface{}:
+		updated, err := child(current[segment.mapKey()])
+		if err != nil {
+			return nil, err
+		}
+		current[segment.mapKey()] = updated
+		return current, nil
+	case []interface{}:
+		index, ok := segment.position()
+		if !ok {
+			return nil, fmt.Errorf("cannot set '%s': key '%s' used on an array", path, segment.key)
+		}
+		if index > len(current) {
+			return nil, fmt.Errorf("cannot set '%s': index %d out of range for length %d", path, index, len(current))
+		}
+		if index == len(current) {
+			current = append(current, nil)
+		}
+		updated, err := child(current[index])
+		if err != nil {
+			return nil, err
+		}
+		current[index] = updated
+		return current, nil
+	default:
+		return nil, fmt.Errorf("cannot set '%s': segment '%s' crosses a %T", path, segment.mapKey(), node)
+	}
+}
+
+// deleteNode removes the value at `segments` below `node` and returns the possibly reallocated node.
+func deleteNode(node interface{}, segments []pathSegment) (interface{}, bool) {
+	segment, rest := segments[0], segments[1:]
+	switch current := node.(type) {
+	case map[string]interface{}:
+		value, exists := current[segment.mapKey()]
+		if !exists {
+			return current, false
+		}
+		if len(rest) == 0 {
+			delete(current, segment.mapKey())
+			return current, true
+		}
+		updated, removed := deleteNode(value, rest)
+		current[segment.mapKey()] = updated
+		return current, removed
+	case []interface{}:
+		index, ok := segment.position()
+		if !ok || index >= len(current) {
+			return current, false
+		}
+		if len(rest) == 0 {
+			return append(current[:index:index], current[index+1:]...), true
+		}
+		updated, removed := deleteNode(current[index], rest)
+		current[index] = updated
+		return current, removed
+	default:
+		return node, false
+	}
+}
+
+// flattenInto writes the leaves of `value` into `result`, prefixing their paths with `prefix`.
+func flattenInto(result map[string]interface{}, prefix string, value interface{}, sep string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) > 0 {
+			for key, child := range v {
+				flattenInto(result, prefix+sep+key, child, sep)
+			}
+			return
+		}
+	case []interface{}:
+		if len(v) > 0 {
+			for i, child := range v {
+				flattenInto(result, prefix+sep+strconv.Itoa(i), child, sep)
+			}
+			return
+		}
+	}
+	result[prefix] = value
+}
+
+// buildUnflattened converts the intermediate nodes of `UnflattenMap` into maps and arrays.
+func buildUnflattened(value interface{}) interface{} {
+	node, ok := value.(unflattenNode)
+	if !ok {
+		return value
+	}
+	isArray := len(node) > 0
+	for i := 0; i < len(node) && isArray; i++ {
+		_, isArray = node[strconv.Itoa(i)]
+	}
+	if isArray {
+		array := make([]interface{}, len(node))
+		for i := range array {
+			array[i] = buildUnflattened(node[strconv.Itoa(i)])
+		}
+		return array
+	}
+	result := make(map[string]interface{}, len(node))
+	for key, child := range node {
+		result[key] = buildUnflattened(child)
+	}
+	return result
+}
+
+// splitPatterns splits each dotted pattern into its per-level segments.
+func splitPatterns(patterns []string) [][]string {
+	return Map(patterns, func(pattern string) []string { return strings.Split(pattern, ".") })
+}
+
+// matchPatterns checks `key` against the first segment of each pattern. It reports whether a
+// single-segment pattern matched, and otherwise returns the remaining segments of the matches.
+func matchPatterns(key string, patterns [][]string) (bool, [][]string) {
+	var nested [][]string
+	for _, pattern := range patterns {
+		if !Match(key, pattern[0]) {
+			continue
+		}
+		if len(pattern) == 1 {
+			return true, nil
+		}
+		nested = append(nested, pattern[1:])
+	}
+	return false, nested
+}
+
+// pickMap keeps the entries of `m` selected by `patterns`.
+func pickMap(m map[string]interface{}, patterns [][]string) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range m {
+		whole, nested := matchPatterns(key, patterns)
+		if whole {
+			result[key] = value
+			continue
+		}
+		if child, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			if picked := pickMap(child, nested); len(picked) > 0 {
+				result[key] = picked
+			}
+		}
+	}
+	return result
+}
+
+// omitMap drops the entries of `m` selected by `patterns`.
+func omitMap(m map[string]interface{}, patterns [][]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		whole, nested := matchPatterns(key, patterns)
+		if whole {
+			continue
+		}
+		if child, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			value = omitMap(child, nested)
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// mergeMaps merges `source` into `target`; `prefix` is the path of `target`, used in error messages.
+func mergeMaps(target, source map[string]interface{}, options *MergeOptionsConfig, prefix string) error {
+	keys := make([]string, 0, len(source))
+	for key := range source {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		sourceValue := source[key]
+		targetValue, exists := target[key]
+		if !exists {
+			target[key] = sourceValue
+			continue
+		}
+		switch s := sourceValue.(type) {
+		case map[string]interface{}:
+			if t, ok := targetValue.(map[string]interface{}); ok {
+				if err := mergeMaps(t, s, options, path); err != nil {
+					return err
+				}
+				continue
+			}
+		case []interface{}:
+			if t, ok := targetValue.([]interface{}); ok {
+				target[key] = mergeArrays(t, s, options.ArrayStrategy)
+				continue
+			}
+		}
+		if options.StrictTypes && targetValue != nil && sourceValue != nil &&
+			reflect.TypeOf(targetValue) != reflect.TypeOf(sourceValue) {
+			return fmt.Errorf("merge conflict at '%s': cannot merge %T into %T", path, sourceValue, targetValue)
+		}
+		target[key] = sourceValue
+	}
+	return nil
+}
+
+// mergeArrays combines two arrays according to `strategy`.
+func mergeArrays(target, source []interface{}, strategy MergeArrayStrategy) []interface{} {
+	switch strategy {
+	case MergeArrayAppend:
+		return append(append([]interface{}{}, target...), source...)
+	case MergeArrayDedupe:
+		return UniqueFunc(append(append([]interface{}{}, target...), source...), reflect.DeepEqual)
+	default:
+		return source
+	}
+}
@@ -0,0 +1,354 @@
+package unify4g
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ApplyDefaults assigns the values of `default` tags to the zero-valued fields of the struct pointed
+// to by `ptr`. Nested structs and non-nil pointers to structs are processed recursively, so that a
+// configuration tree can declare its defaults next to its fields.
+//
+// Tag values are converted to the field type:
+//   - strings, booleans, integers, unsigned integers and floats use the `strconv` syntax;
+//   - `time.Duration` uses `time.ParseDuration` (e.g. "1m30s"), `time.Time` uses RFC 3339;
+//   - slices are comma-separated lists (e.g. "a,b,c"), whose elements are converted in turn;
+//   - maps are comma-separated `key:value` pairs (e.g. "a:1,b:2");
+//   - pointers are allocated and their element converted;
+//   - types implementing `encoding.TextUnmarshaler` use their own parsing.
+//
+// Fields that already hold a non-zero value are left untouched, and a pointer reached again through
+// a cycle is not processed twice.
+//
+// Parameters:
+//   - `ptr`: A non-nil pointer to a struct.
+//
+// Returns:
+//   - An error naming the offending field if a tag value cannot be converted, or if `ptr` is not a pointer to a struct.
+//
+// Example:
+//
+//	type Config struct {
+//		Port    int            `default:"8080"`
+//		Timeout time.Duration  `default:"30s"`
+//		Hosts   []string       `default:"a.local,b.local"`
+//		Weights map[string]int `default:"a:1,b:2"`
+//	}
+//	var cfg Config
+//	err := ApplyDefaults(&cfg)
+//	// cfg.Port will be 8080, cfg.Timeout will be 30 * time.Second
+func ApplyDefaults(ptr interface{}) error {
+	value := reflect.ValueOf(ptr)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("apply defaults: expected a non-nil pointer to a struct, got %T", ptr)
+	}
+	visited := map[copyKey]bool{{pointer: value.Pointer(), typ: value.Type()}: true}
+	return applyDefaults(value.Elem(), "", visited)
+}
+
+// BindEnv populates the struct pointed to by `ptr` from environment variables declared with `env`
+// tags, and reports which variables were used.
+//
+// The variable read for a field is `prefix` and the tag name joined with an underscore, e.g. the
+// field tagged `env:"PORT"` is read from "APP_PORT" when `prefix` is "APP". Struct fields tagged
+// with `env` extend the prefix for their own fields (`env:"DB"` reads "APP_DB_HOST"); untagged struct
+// fields are bound with the current prefix. Nil pointers to structs are only allocated when one of
+// their variables is set, except those to a struct type already being bound, which would nest
+// forever. Values are converted like the tags of `ApplyDefaults`.
+//
+// Variables marked `env:"NAME,required"` must be set: BindEnv binds every other field, then returns
+// an error listing all the missing required variables. Unset optional variables leave their field
+// untouched, so BindEnv is usually called after `ApplyDefaults`.
+//
+// Parameters:
+//   - `ptr`: A non-nil pointer to a struct.
+//   - `prefix`: The prefix of every variable name; may be empty.
+//
+// Returns:
+//   - A report of the variables used and missing.
+//   - An error if a required variable is missing, a value cannot be converted, or `ptr` is not a pointer to a struct.
+//
+// Example:
+//
+//	type Config struct {
+//		Port int `env:"PORT" default:"8080"`
+//		DB   struct {
+//			URL string `env:"URL,required"`
+//		} `env:"DB"`
+//	}
+//	var cfg Config
+//	_ = ApplyDefaults(&cfg)
+//	report, err := BindEnv(&cfg, "APP") // reads APP_PORT and APP_DB_URL
+func BindEnv(ptr interface{}, prefix string) (*EnvBindingReport, error) {
+	value := reflect.ValueOf(ptr)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("bind env: expected a non-nil pointer to a struct, got %T", ptr)
+	}
+	report := &EnvBindingReport{}
+	binding := &envBinding{
+		report:  report,
+		types:   make(map[reflect.Type]bool),
+		visited: map[copyKey]bool{{pointer: value.Pointer(), typ: value.Type()}: true},
+	}
+	if err := binding.bind(value.Elem(), strings.TrimSuffix(prefix, "_")); err != nil {
+		return report, err
+	}
+	if len(binding.required) > 0 {
+		return report, fmt.Errorf("bind env: missing required environment variables: %s", strings.Join(binding.required, ", "))
+	}
+	return report, nil
+}
+
+// envBinding carries the state of a single `BindEnv` call: the report, the missing required
+// variables, and the struct types and pointers on the current path, so that self-referencing
+// configuration types are bound only once.
+type envBinding struct {
+	report   *EnvBindingReport
+	required []string
+	types    map[reflect.Type]bool
+	visited  map[copyKey]bool
+}
+
+// applyDefaults assigns the `default` tags of the struct `value`, whose path is `path`. Pointers in
+// `visited` have already been processed and are skipped, so that cyclic values terminate.
+func applyDefaults(value reflect.Value, path string, visited map[copyKey]bool) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		fieldValue := value.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		if raw, ok := field.Tag.Lookup("default"); ok {
+			if !fieldValue.CanSet() || !fieldValue.IsZero() {
+				continue
+			}
+			if err := setFromString(fieldValue, raw); err != nil {
+				return fmt.Errorf("apply defaults: field '%s': %v", fieldPath, err)
+			}
+			continue
+		}
+		if nested, ok := nestedStruct(fieldValue); ok {
+			if fieldValue.Kind() == reflect.Ptr {
+				key := copyKey{pointer: fieldValue.Pointer(), typ: fieldValue.Type()}
+				if visited[key] {
+					continue
+				}
+				visited[key] = true
+			}
+			if err := applyDefaults(nested, fieldPath, visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bind binds the `env` tags of the struct `value`, recording used and missing variables.
+func (binding *envBinding) bind(value reflect.Value, prefix string) error {
+	binding.types[value.Type()] = true
+	defer delete(binding.types, value.Type())
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		fieldValue := value.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		tag, tagged := field.Tag.Lookup("env")
+		name, flags, _ := strings.Cut(tag, ",")
+		if isBindableStruct(field.Type) {
+			nestedPrefix := prefix
+			if tagged && name != "" {
+				nestedPrefix = joinEnvKey(prefix, name)
+			}
+			if err := binding.bindStruct(fieldValue, nestedPrefix); err != nil {
+				return err
+			}
+			continue
+		}
+		if !tagged || name == "" || !fieldValue.CanSet() {
+			continue
+		}
+		key := joinEnvKey(prefix, name)
+		raw, ok := os.LookupEnv(key)
+		if !ok {
+			binding.report.Missing = append(binding.report.Missing, key)
+			if strings.Contains(","+flags+",", ",required,") {
+				binding.required = append(binding.required, key)
+			}
+			continue
+		}
+		if err := setFromString(fieldValue, raw); err != nil {
+			return fmt.Errorf("bind env: variable '%s': %v", key, err)
+		}
+		binding.report.Used = append(binding.report.Used, key)
+	}
+	return nil
+}
+
+// bindStruct binds a nested struct or pointer to struct. A nil pointer is bound into a fresh
+// struct, which is only assigned when at least one of its variables was used. Nil pointers to a
+// struct type already being bound, and pointers already on the path, are skipped.
+func (binding *envBinding) bindStruct(value reflect.Value, prefix string) error {
+	if value.Kind() != reflect.Ptr {
+		return binding.bind(value, prefix)
+	}
+	if !value.IsNil() {
+		key := copyKey{pointer: value.Pointer(), typ: value.Type()}
+		if binding.visited[key] {
+			return nil
+		}
+		binding.visited[key] = true
+		defer delete(binding.visited, key)
+		return binding.bind(value.Elem(), prefix)
+	}
+	if binding.types[value.Type().Elem()] {
+		return nil
+	}
+	used := len(binding.report.Used)
+	fresh := reflect.New(value.Type().Elem())
+	if err := binding.bind(fresh.Elem(), prefix); err != nil {
+		return err
+	}
+	if len(binding.report.Used) > used && value.CanSet() {
+		value.Set(fresh)
+	}
+	return nil
+}
+
+// setFromString converts `raw` to the type of `dst` and stores it, as described for `ApplyDefaults`.
+func setFromString(dst reflect.Value, raw string) error {
+	if dst.Kind() == reflect.Ptr {
+		element := reflect.New(dst.Type().Elem())
+		if err := setFromString(element.Elem(), raw); err != nil {
+			return err
+		}
+		dst.Set(element)
+		return nil
+	}
+	if dst.CanAddr() {
+		if unmarshaler, ok := dst.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return unmarshaler.UnmarshalText([]byte(raw))
+		}
+	}
+	if dst.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		dst.SetInt(int64(d))
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(raw, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+	case reflect.Slice:
+		items := splitList(raw)
+		slice := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setFromString(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+	case reflect.Map:
+		items := splitList(raw)
+		m := reflect.MakeMapWithSize(dst.Type(), len(items))
+		for _, item := range items {
+			k, v, found := strings.Cut(item, ":")
+			if !found {
+				return fmt.Errorf("invalid map entry '%s', expected key:value", item)
+			}
+			key := reflect.New(dst.Type().Key()).Elem()
+			if err := setFromString(key, strings.TrimSpace(k)); err != nil {
+				return err
+			}
+			element := reflect.New(dst.Type().Elem()).Elem()
+			if err := setFromString(element, strings.TrimSpace(v)); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, element)
+		}
+		dst.Set(m)
+	default:
+		return fmt.Errorf("unsupported type %s", dst.Type())
+	}
+	return nil
+}
+
+// nestedStruct returns the struct held by `value` when it is a struct or a non-nil pointer to a
+// struct that `ApplyDefaults` should descend into.
+func nestedStruct(value reflect.Value) (reflect.Value, bool) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return value, false
+		}
+		value = value.Elem()
+	}
+	return value, isBindableStruct(value.Type())
+}
+
+// isBindableStruct reports whether `t` is a struct, or a pointer to a struct, whose fields are bound
+// individually rather than parsed from a single string.
+func isBindableStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	return !reflect.PointerTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem())
+}
+
+// joinEnvKey joins an environment variable prefix and name with an underscore.
+func joinEnvKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
+
+// splitList splits a comma-separated list, trimming the spaces around items.
+// An empty string yields an empty list.
+func splitList(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return []string{}
+	}
+	items := strings.Split(raw, ",")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+	return items
+}
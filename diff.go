@@ -0,0 +1,545 @@
+package unify4g
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// diffHunkHeader matches the header of a unified diff hunk, such as "@@ -12,7 +12,8 @@".
+var diffHunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// ANSI colors of the diff output.
+const (
+	diffColorHeader = "\x1B[1m"
+	diffColorHunk   = "\x1B[36m"
+	diffColorDelete = "\x1B[31m"
+	diffColorInsert = "\x1B[32m"
+)
+
+// diffToken is one token of a diff with its operation and position in both inputs.
+type diffToken struct {
+	operation DiffOperation
+	text      string
+	from      int // index of the token in the first input, or of the next one for insertions
+	to        int // index of the token in the second input, or of the next one for deletions
+}
+
+// DiffLines compares `a` and `b` line by line with the Myers algorithm and returns the shortest edit
+// script turning `a` into `b`. Consecutive lines with the same operation are grouped into a single
+// edit whose text keeps the line breaks.
+//
+// Parameters:
+//   - `a`: The original text.
+//   - `b`: The modified text.
+//
+// Returns:
+//   - The edit script; concatenating the texts of the edits that are not insertions gives `a`, and of
+//     those that are not deletions gives `b`.
+//
+// Example:
+//
+//	edits := DiffLines("a\nb\nc\n", "a\nx\nc\n")
+//	// []DiffEdit{{DiffEqual, "a\n"}, {DiffDelete, "b\n"}, {DiffInsert, "x\n"}, {DiffEqual, "c\n"}}
+func DiffLines(a, b string) []DiffEdit {
+	return mergeDiffTokens(myersDiff(splitDiffLines(a), splitDiffLines(b)))
+}
+
+// DiffWords compares `a` and `b` word by word, like `DiffLines`. Words are runs of letters, digits and
+// underscores; runs of whitespace and single punctuation characters are compared as tokens of their own.
+//
+// Parameters:
+//   - `a`: The original text.
+//   - `b`: The modified text.
+//
+// Returns:
+//   - The edit script.
+//
+// Example:
+//
+//	edits := DiffWords("the quick fox", "the slow fox")
+//	// []DiffEdit{{DiffEqual, "the "}, {DiffDelete, "quick"}, {DiffInsert, "slow"}, {DiffEqual, " fox"}}
+func DiffWords(a, b string) []DiffEdit {
+	return mergeDiffTokens(myersDiff(splitDiffWords(a), splitDiffWords(b)))
+}
+
+// DiffChars compares `a` and `b` rune by rune, like `DiffLines`.
+//
+// Parameters:
+//   - `a`: The original text.
+//   - `b`: The modified text.
+//
+// Returns:
+//   - The edit script.
+//
+// Example:
+//
+//	edits := DiffChars("kitten", "sitting")
+//	// []DiffEdit{{DiffDelete, "k"}, {DiffInsert, "s"}, {DiffEqual, "itt"}, {DiffDelete, "e"}, {DiffInsert, "i"}, {DiffEqual, "n"}, {DiffInsert, "g"}}
+func DiffChars(a, b string) []DiffEdit {
+	return mergeDiffTokens(myersDiff(splitDiffChars(a), splitDiffChars(b)))
+}
+
+// FormatDiff renders an edit script inline, for word and character diffs. With `color`, deletions are
+// shown in red and insertions in green; otherwise they are marked as "[-deleted-]" and "{+inserted+}",
+// like `git diff --word-diff`.
+//
+// Parameters:
+//   - `edits`: The edit script to render.
+//   - `color`: Whether to use ANSI colors.
+//
+// Returns:
+//   - The rendered edit script.
+//
+// Example:
+//
+//	FormatDiff(DiffWords("the quick fox", "the slow fox"), false) // "the [-quick-]{+slow+} fox"
+func FormatDiff(edits []DiffEdit, color bool) string {
+	var builder strings.Builder
+	for _, edit := range edits {
+		switch {
+		case edit.Operation == DiffEqual:
+			builder.WriteString(edit.Text)
+		case color && edit.Operation == DiffDelete:
+			builder.WriteString(diffColorDelete + edit.Text + ansiReset)
+		case color:
+			builder.WriteString(diffColorInsert + edit.Text + ansiReset)
+		case edit.Operation == DiffDelete:
+			builder.WriteString("[-" + edit.Text + "-]")
+		default:
+			builder.WriteString("{+" + edit.Text + "+}")
+		}
+	}
+	return builder.String()
+}
+
+// UnifiedDiff compares `a` and `b` line by line and returns their differences in the unified format
+// of `diff -u` and `git diff`: a "---"/"+++" header followed by hunks of changed lines surrounded by
+// context lines. Lines missing a final line break are followed by "\ No newline at end of file".
+// The result can be applied to `a` with `ApplyPatch`.
+//
+// Parameters:
+//   - `a`: The original text.
+//   - `b`: The modified text.
+//   - `options`: The output configuration; nil uses `DefaultDiffOptionsConfig`.
+//
+// Returns:
+//   - The unified diff, or an empty string if the texts are equal.
+//
+// Example:
+//
+//	patch := UnifiedDiff("a\nb\nc\n", "a\nx\nc\n", nil)
+//	// --- a
+//	// +++ b
+//	// @@ -1,3 +1,3 @@
+//	//  a
+//	// -b
+//	// +x
+//	//  c
+func UnifiedDiff(a, b string, options *DiffOptionsConfig) string {
+	if options == nil {
+		options = DefaultDiffOptionsConfig
+	}
+	tokens := myersDiff(splitDiffLines(a), splitDiffLines(b))
+	hunks := diffHunks(tokens, max(options.Context, 0))
+	if len(hunks) == 0 {
+		return ""
+	}
+	paint := func(color, text string) string {
+		if !options.Color {
+			return text
+		}
+		return color + text + ansiReset
+	}
+	var builder strings.Builder
+	builder.WriteString(paint(diffColorHeader, "--- "+options.FromFile) + "\n")
+	builder.WriteString(paint(diffColorHeader, "+++ "+options.ToFile) + "\n")
+	for _, hunk := range hunks {
+		lines := tokens[hunk[0]:hunk[1]]
+		oldCount := len(lines) - CountIf(lines, func(t diffToken) bool { return t.operation == DiffInsert })
+		newCount := len(lines) - CountIf(lines, func(t diffToken) bool { return t.operation == DiffDelete })
+		header := fmt.Sprintf("@@ -%s +%s @@", diffRange(lines[0].from, oldCount), diffRange(lines[0].to, newCount))
+		builder.WriteString(paint(diffColorHunk, header) + "\n")
+		for _, line := range lines {
+			text := strings.TrimSuffix(line.text, "\n")
+			switch line.operation {
+			case DiffEqual:
+				builder.WriteString(" " + text + "\n")
+			case DiffDelete:
+				builder.WriteString(paint(diffColorDelete, "-"+text) + "\n")
+			default:
+				builder.WriteString(paint(diffColorInsert, "+"+text) + "\n")
+			}
+			if !strings.HasSuffix(line.text, "\n") {
+				builder.WriteString("\\ No newline at end of file\n")
+			}
+		}
+	}
+	return builder.String()
+}
+
+// ApplyPatch applies a unified diff, as produced by `UnifiedDiff` or `diff -u`, to `original`.
+//
+// Hunks are applied in order. A hunk is expected at the line stated in its header, but is also
+// searched for nearby, so that a patch still applies when unrelated lines were added or removed above
+// it; its context and deleted lines must match exactly. Lines before the first hunk header, such as
+// the "---" and "+++" headers, are ignored.
+//
+// Parameters:
+//   - `original`: The text to patch.
+//   - `patch`: The unified diff.
+//
+// Returns:
+//   - The patched text.
+//   - An error if the patch is malformed or a hunk does not match `original`.
+//
+// Example:
+//
+//	patched, err := ApplyPatch("a\nb\nc\n", UnifiedDiff("a\nb\nc\n", "a\nx\nc\n", nil))
+//	// patched will be "a\nx\nc\n"
+func ApplyPatch(original, patch string) (string, error) {
+	source := splitDiffLines(original)
+	lines := splitDiffLines(patch)
+	var result strings.Builder
+	position, offset, hunk := 0, 0, 0
+	for i := 0; i < len(lines); {
+		match := diffHunkHeader.FindStringSubmatch(lines[i])
+		i++
+		if match == nil {
+			continue
+		}
+		hunk++
+		oldStart, oldCount := parseHunkRange(match[1], match[2])
+		_, newCount := parseHunkRange(match[3], match[4])
+		var oldLines, newLines []string
+		var last byte
+		for i < len(lines) && (len(oldLines) < oldCount || len(newLines) < newCount || strings.HasPrefix(lines[i], "\\")) {
+			line := lines[i]
+			i++
+			if line == "\n" {
+				line = " \n"
+			}
+			switch line[0] {
+			case ' ':
+				oldLines = append(oldLines, line[1:])
+				newLines = append(newLines, line[1:])
+			case '-':
+				oldLines = append(oldLines, line[1:])
+			case '+':
+				newLines = append(newLines, line[1:])
+			case '\\':
+				if last == ' ' || last == '-' {
+					oldLines[len(oldLines)-1] = strings.TrimSuffix(oldLines[len(oldLines)-1], "\n")
+				}
+				if last == ' ' || last == '+' {
+					newLines[len(newLines)-1] = strings.TrimSuffix(newLines[len(newLines)-1], "\n")
+				}
+				continue
+			default:
+				return "", fmt.Errorf("apply patch: hunk %d: unexpected line %q", hunk, strings.TrimSuffix(line, "\n"))
+			}
+			last = line[0]
+		}
+		if len(oldLines) != oldCount || len(newLines) != newCount {
+			return "", fmt.Errorf("apply patch: hunk %d: expected %d old and %d new lines, got %d and %d", hunk, oldCount, newCount, len(oldLines), len(newLines))
+		}
+		expected := oldStart - 1 + offset
+		if oldCount == 0 {
+			expected = oldStart + offset
+		}
+		at, ok := locateHunk(source, oldLines, position, expected)
+		if !ok {
+			return "", fmt.Errorf("apply patch: hunk %d does not match the original text at line %d", hunk, oldStart)
+		}
+		for _, line := range source[position:at] {
+			result.WriteString(line)
+		}
+		for _, line := range newLines {
+			result.WriteString(line)
+		}
+		offset += at - expected
+		position = at + len(oldLines)
+	}
+	for _, line := range source[position:] {
+		result.WriteString(line)
+	}
+	return result.String(), nil
+}
+
+// myersDiff computes the edit script between the token lists `a` and `b` with the linear-space
+// variant of the O((N+M)D) algorithm of Eugene W. Myers.
+// Within each run of changes, deletions are placed before insertions.
+func myersDiff(a, b []string) []diffToken {
+	tokens := myersCompare(a, b, 0, 0, make([]diffToken, 0, len(a)+len(b)))
+	for start := 0; start < len(tokens); {
+		if tokens[start].operation == DiffEqual {
+			start++
+			continue
+		}
+		end := start
+		for end < len(tokens) && tokens[end].operation != DiffEqual {
+			end++
+		}
+		run := tokens[start:end]
+		from, to := run[0].from, run[0].to
+		deletions := Filter(run, func(t diffToken) bool { return t.operation == DiffDelete })
+		insertions := Filter(run, func(t diffToken) bool { return t.operation == DiffInsert })
+		for i, token := range deletions {
+			run[i] = diffToken{operation: DiffDelete, text: token.text, from: from + i, to: to}
+		}
+		for j, token := range insertions {
+			run[len(deletions)+j] = diffToken{operation: DiffInsert, text: token.text, from: from + len(deletions), to: to + j}
+		}
+		start = end
+	}
+	return tokens
+}
+
+// myersCompare appends the edit script of `a` and `b` to `tokens`, `from` and `to` being the positions
+// of `a` and `b` in the compared inputs. After trimming their common prefix and suffix, the middle
+// snake of the shortest edit path splits the problem in two halves, which are compared recursively.
+func myersCompare(a, b []string, from, to int, tokens []diffToken) []diffToken {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		tokens = append(tokens, diffToken{operation: DiffEqual, text: a[prefix], from: from + prefix, to: to + prefix})
+		prefix++
+	}
+	a, b, from, to = a[prefix:], b[prefix:], from+prefix, to+prefix
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	middleA, middleB := a[:len(a)-suffix], b[:len(b)-suffix]
+	x, y := len(middleA), 0
+	if len(middleA) > 0 && len(middleB) > 0 {
+		x, y = myersMiddleSnake(middleA, middleB)
+	}
+	if (x > 0 || y > 0) && (x < len(middleA) || y < len(middleB)) {
+		tokens = myersCompare(middleA[:x], middleB[:y], from, to, tokens)
+		tokens = myersCompare(middleA[x:], middleB[y:], from+x, to+y, tokens)
+	} else {
+		for i, token := range middleA {
+			tokens = append(tokens, diffToken{operation: DiffDelete, text: token, from: from + i, to: to})
+		}
+		for j, token := range middleB {
+			tokens = append(tokens, diffToken{operation: DiffInsert, text: token, from: from + len(middleA), to: to + j})
+		}
+	}
+	for i := len(a) - suffix; i < len(a); i++ {
+		j := i - len(a) + len(b)
+		tokens = append(tokens, diffToken{operation: DiffEqual, text: a[i], from: from + i, to: to + j})
+	}
+	return tokens
+}
+
+// myersMiddleSnake searches the shortest edit path of `a` and `b` from both ends at once, keeping
+// only the furthest reaching paths of the current edit distance, and returns the point where the
+// two searches meet. It returns (len(a), 0), splitting the inputs into a deletion and an insertion,
+// if they have nothing in common.
+func myersMiddleSnake(a, b []string) (int, int) {
+	n, m := len(a), len(b)
+	limit := (n + m + 1) / 2
+	offset, size := limit, 2*limit+2
+	forward, backward := make([]int, size), make([]int, size)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+	delta := n - m
+	odd := delta%2 != 0
+	forwardStart, forwardEnd, backwardStart, backwardEnd := 0, 0, 0, 0
+	for d := 0; d < limit; d++ {
+		for k := -d + forwardStart; k <= d-forwardEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+			switch {
+			case x > n:
+				forwardEnd += 2
+			case y > m:
+				forwardStart += 2
+			case odd:
+				if other := offset + delta - k; other >= 0 && other < size && backward[other] != -1 && x >= n-backward[other] {
+					return x, y
+				}
+			}
+		}
+		for k := -d + backwardStart; k <= d-backwardEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-x-1] == b[m-y-1] {
+				x++
+				y++
+			}
+			backward[offset+k] = x
+			switch {
+			case x > n:
+				backwardEnd += 2
+			case y > m:
+				backwardStart += 2
+			case !odd:
+				if other := offset + delta - k; other >= 0 && other < size && forward[other] != -1 {
+					if forwardX := forward[other]; forwardX >= n-x {
+						return forwardX, offset + forwardX - other
+					}
+				}
+			}
+		}
+	}
+	return n, 0
+}
+
+// mergeDiffTokens groups consecutive tokens with the same operation into edits.
+func mergeDiffTokens(tokens []diffToken) []DiffEdit {
+	var edits []DiffEdit
+	for start := 0; start < len(tokens); {
+		end := start + 1
+		for end < len(tokens) && tokens[end].operation == tokens[start].operation {
+			end++
+		}
+		var text strings.Builder
+		for _, token := range tokens[start:end] {
+			text.WriteString(token.text)
+		}
+		edits = append(edits, DiffEdit{Operation: tokens[start].operation, Text: text.String()})
+		start = end
+	}
+	return edits
+}
+
+// diffHunks returns the ranges [start, end) of `tokens` forming the hunks of a unified diff with
+// `context` unchanged lines around changes. Changes separated by at most twice the context share a hunk.
+func diffHunks(tokens []diffToken, context int) [][2]int {
+	var hunks [][2]int
+	for i := 0; i < len(tokens); {
+		if tokens[i].operation == DiffEqual {
+			i++
+			continue
+		}
+		start, end := max(0, i-context), i
+		for {
+			for end < len(tokens) && tokens[end].operation != DiffEqual {
+				end++
+			}
+			run := 0
+			for end+run < len(tokens) && tokens[end+run].operation == DiffEqual {
+				run++
+			}
+			if end+run < len(tokens) && run <= 2*context {
+				end += run
+				continue
+			}
+			end += min(run, context)
+			break
+		}
+		hunks = append(hunks, [2]int{start, end})
+		i = end
+	}
+	return hunks
+}
+
+// diffRange formats the range of a hunk header from the 0-based index of its first line and its
+// line count, following GNU diff: the count is omitted when it is 1, and an empty range refers to
+// the line before it.
+func diffRange(index, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", index)
+	case 1:
+		return strconv.Itoa(index + 1)
+	default:
+		return fmt.Sprintf("%d,%d", index+1, count)
+	}
+}
+
+// parseHunkRange parses the start and optional count of a hunk header range.
+func parseHunkRange(start, count string) (int, int) {
+	s, _ := strconv.Atoi(start)
+	if count == "" {
+		return s, 1
+	}
+	c, _ := strconv.Atoi(count)
+	return s, c
+}
+
+// locateHunk finds where `lines` occur in `source`, at or after `minimum`, starting from `expected`
+// and moving away from it in both directions.
+func locateHunk(source, lines []string, minimum, expected int) (int, bool) {
+	matches := func(at int) bool {
+		if at < minimum || at+len(lines) > len(source) {
+			return false
+		}
+		for i, line := range lines {
+			if source[at+i] != line {
+				return false
+			}
+		}
+		return true
+	}
+	for delta := 0; expected-delta >= minimum || expected+delta <= len(source); delta++ {
+		if matches(expected - delta) {
+			return expected - delta, true
+		}
+		if matches(expected + delta) {
+			return expected + delta, true
+		}
+	}
+	return 0, false
+}
+
+// splitDiffLines splits `str` into lines, keeping their line breaks.
+func splitDiffLines(str string) []string {
+	lines := strings.SplitAfter(str, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// splitDiffWords splits `str` into words, whitespace runs and punctuation characters.
+func splitDiffWords(str string) []string {
+	var tokens []string
+	runes := []rune(str)
+	isWord := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' }
+	for i := 0; i < len(runes); {
+		j := i + 1
+		switch {
+		case isWord(runes[i]):
+			for j < len(runes) && isWord(runes[j]) {
+				j++
+			}
+		case unicode.IsSpace(runes[i]):
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, string(runes[i:j]))
+		i = j
+	}
+	return tokens
+}
+
+// splitDiffChars splits `str` into runes.
+func splitDiffChars(str string) []string {
+	tokens := make([]string, 0, len(str))
+	for _, r := range str {
+		tokens = append(tokens, string(r))
+	}
+	return tokens
+}
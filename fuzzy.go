@@ -0,0 +1,511 @@
+package unify4g
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Scores used by `FuzzyFind`, modelled on the fzf matching algorithm: every matched character earns
+// fuzzyScoreMatch, gaps between matched characters are penalised, and characters starting a word
+// earn a bonus so that "gc" ranks "git commit" above "magic".
+const (
+	fuzzyScoreMatch          = 16
+	fuzzyScoreGapStart       = -3
+	fuzzyScoreGapExtension   = -1
+	fuzzyBonusWhitespace     = 10
+	fuzzyBonusDelimiter      = 9
+	fuzzyBonusBoundary       = 8
+	fuzzyBonusCamel          = 7
+	fuzzyBonusConsecutive    = 4
+	fuzzyBonusFirstCharScale = 2
+)
+
+// fuzzyCharClass classifies characters to compute word boundary bonuses.
+type fuzzyCharClass int
+
+const (
+	fuzzyClassWhitespace fuzzyCharClass = iota
+	fuzzyClassDelimiter
+	fuzzyClassPunctuation
+	fuzzyClassLower
+	fuzzyClassUpper
+	fuzzyClassLetter
+	fuzzyClassDigit
+)
+
+// Levenshtein returns the edit distance between `a` and `b`: the minimum number of single-rune
+// insertions, deletions and substitutions needed to turn one string into the other.
+//
+// Parameters:
+//   - `a`: The first string.
+//   - `b`: The second string.
+//
+// Returns:
+//   - The edit distance, between 0 and the rune length of the longer string.
+//
+// Example:
+//
+//	distance := Levenshtein("kitten", "sitting") // 3
+func Levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	if len(source) < len(target) {
+		source, target = target, source
+	}
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
+
+// DamerauLevenshtein returns the Damerau-Levenshtein distance between `a` and `b`: like `Levenshtein`,
+// but the transposition of two adjacent runes counts as a single edit. Unlike the restricted "optimal
+// string alignment" variant, a transposed pair may be edited again, so the result is a true metric.
+//
+// Parameters:
+//   - `a`: The first string.
+//   - `b`: The second string.
+//
+// Returns:
+//   - The edit distance.
+//
+// Example:
+//
+//	DamerauLevenshtein("ca", "ac")  // 1 (Levenshtein gives 2)
+//	DamerauLevenshtein("ca", "abc") // 2
+func DamerauLevenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	n, m := len(source), len(target)
+	infinity := n + m
+	distances := make([][]int, n+2)
+	for i := range distances {
+		distances[i] = make([]int, m+2)
+	}
+	distances[0][0] = infinity
+	for i := 0; i <= n; i++ {
+		distances[i+1][0] = infinity
+		distances[i+1][1] = i
+	}
+	for j := 0; j <= m; j++ {
+		distances[0][j+1] = infinity
+		distances[1][j+1] = j
+	}
+	lastRow := make(map[rune]int)
+	for i := 1; i <= n; i++ {
+		lastColumn := 0
+		for j := 1; j <= m; j++ {
+			k := lastRow[target[j-1]]
+			l := lastColumn
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+				lastColumn = j
+			}
+			distances[i+1][j+1] = min(
+				distances[i][j]+cost,
+				distances[i+1][j]+1,
+				distances[i][j+1]+1,
+				distances[k][l]+(i-k-1)+1+(j-l-1),
+			)
+		}
+		lastRow[source[i-1]] = i
+	}
+	return distances[n+1][m+1]
+}
+
+// Hamming returns the number of positions at which the runes of `a` and `b` differ.
+// The distance is only defined for strings with the same number of runes.
+//
+// Parameters:
+//   - `a`: The first string.
+//   - `b`: The second string.
+//
+// Returns:
+//   - The number of differing positions.
+//   - A boolean indicating whether the strings have the same rune length.
+//
+// Example:
+//
+//	distance, ok := Hamming("karolin", "kathrin") // 3, true
+//	_, ok = Hamming("go", "rust")                 // false
+func Hamming(a, b string) (int, bool) {
+	source, target := []rune(a), []rune(b)
+	if len(source) != len(target) {
+		return 0, false
+	}
+	distance := 0
+	for i := range source {
+		if source[i] != target[i] {
+			distance++
+		}
+	}
+	return distance, true
+}
+
+// Jaro returns the Jaro similarity of `a` and `b`, a score between 0 (nothing in common) and 1
+// (identical) based on the number of matching runes and transpositions. Two empty strings are identical.
+//
+// Parameters:
+//   - `a`: The first string.
+//   - `b`: The second string.
+//
+// Returns:
+//   - The similarity, between 0 and 1.
+//
+// Example:
+//
+//	score := Jaro("MARTHA", "MARHTA") // 0.944...
+func Jaro(a, b string) float64 {
+	source, target := []rune(a), []rune(b)
+	if len(source) == 0 && len(target) == 0 {
+		return 1
+	}
+	if len(source) == 0 || len(target) == 0 {
+		return 0
+	}
+	window := max(len(source), len(target))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	sourceMatched := make([]bool, len(source))
+	targetMatched := make([]bool, len(target))
+	matches := 0
+	for i := range source {
+		for j := max(0, i-window); j < min(len(target), i+window+1); j++ {
+			if !targetMatched[j] && source[i] == target[j] {
+				sourceMatched[i], targetMatched[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	transpositions, j := 0, 0
+	for i := range source {
+		if !sourceMatched[i] {
+			continue
+		}
+		for !targetMatched[j] {
+			j++
+		}
+		if source[i] != target[j] {
+			transpositions++
+		}
+		j++
+	}
+	m := float64(matches)
+	return (m/float64(len(source)) + m/float64(len(target)) + (m-float64(transpositions/2))/m) / 3
+}
+
+// JaroWinkler returns the Jaro-Winkler similarity of `a` and `b`: the `Jaro` similarity boosted for
+// strings sharing a common prefix of up to four runes, which suits short strings such as names and
+// commands where typos tend to occur late.
+//
+// Parameters:
+//   - `a`: The first string.
+//   - `b`: The second string.
+//
+// Returns:
+//   - The similarity, between 0 and 1.
+//
+// Example:
+//
+//	score := JaroWinkler("MARTHA", "MARHTA") // 0.961...
+func JaroWinkler(a, b string) float64 {
+	similarity := Jaro(a, b)
+	source, target := []rune(a), []rune(b)
+	prefix := 0
+	for prefix < min(4, len(source), len(target)) && source[prefix] == target[prefix] {
+		prefix++
+	}
+	return similarity + float64(prefix)*0.1*(1-similarity)
+}
+
+// LongestCommonSubsequence returns the longest sequence of runes appearing in both `a` and `b` in the
+// same order, though not necessarily contiguously. When several exist, the one found first is returned.
+//
+// Parameters:
+//   - `a`: The first string.
+//   - `b`: The second string.
+//
+// Returns:
+//   - The longest common subsequence; its rune length measures how much the strings share.
+//
+// Example:
+//
+//	lcs := LongestCommonSubsequence("ABCBDAB", "BDCABA") // "BDAB"
+func LongestCommonSubsequence(a, b string) string {
+	source, target := []rune(a), []rune(b)
+	lengths := make([][]int, len(source)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(target)+1)
+	}
+	for i := len(source) - 1; i >= 0; i-- {
+		for j := len(target) - 1; j >= 0; j-- {
+			if source[i] == target[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+	var builder strings.Builder
+	for i, j := 0, 0; i < len(source) && j < len(target); {
+		switch {
+		case source[i] == target[j]:
+			builder.WriteRune(source[i])
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return builder.String()
+}
+
+// Similarity returns a normalized similarity score between `a` and `b`, computed from their
+// `Levenshtein` distance as 1 - distance / length of the longer string. Identical strings score 1
+// and strings with nothing in common score 0.
+//
+// Parameters:
+//   - `a`: The first string.
+//   - `b`: The second string.
+//
+// Returns:
+//   - The similarity, between 0 and 1.
+//
+// Example:
+//
+//	score := Similarity("kitten", "sitting") // 0.571... (1 - 3/7)
+func Similarity(a, b string) float64 {
+	longest := max(len([]rune(a)), len([]rune(b)))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(Levenshtein(a, b))/float64(longest)
+}
+
+// FuzzyFind returns the candidates matching `query` as a subsequence, ranked from best to worst,
+// ignoring case. It is the engine behind "did you mean" suggestions and interactive search: the
+// characters of the query must appear in the candidate in order, and matches are scored like fzf,
+// preferring consecutive characters and characters at the start of words.
+//
+// Ties are broken by the shorter candidate, then by the position in `candidates`. An empty query
+// matches every candidate with a score of 0.
+//
+// Parameters:
+//   - `query`: The text typed by the user.
+//   - `candidates`: The strings to search.
+//   - `limit`: The maximum number of matches to return; 0 or less returns every match.
+//
+// Returns:
+//   - The matches, best first, with their score and the rune positions of the matched characters.
+//
+// Example:
+//
+//	matches := FuzzyFind("gco", []string{"git checkout", "git commit", "go vet"}, 0)
+//	// matches[0].Value will be "git commit" with Positions []int{0, 4, 5},
+//	// matches[1].Value will be "git checkout" with Positions []int{0, 4, 9}
+func FuzzyFind(query string, candidates []string, limit int) []FuzzyMatch {
+	return FuzzyFindWith(query, candidates, limit, DefaultFuzzyOptionsConfig)
+}
+
+// FuzzyFindWith returns the candidates matching `query` like `FuzzyFind`, using the given options
+// to control case and accent sensitivity.
+//
+// Parameters:
+//   - `query`: The text typed by the user.
+//   - `candidates`: The strings to search.
+//   - `limit`: The maximum number of matches to return; 0 or less returns every match.
+//   - `options`: The matching configuration; nil uses `DefaultFuzzyOptionsConfig`.
+//
+// Returns:
+//   - The matches, best first.
+//
+// Example:
+//
+//	matches := FuzzyFindWith("cafe", []string{"Café Noir", "Cafeteria"}, 0, &FuzzyOptionsConfig{IgnoreAccents: true})
+//	// both candidates match
+func FuzzyFindWith(query string, candidates []string, limit int, options *FuzzyOptionsConfig) []FuzzyMatch {
+	if options == nil {
+		options = DefaultFuzzyOptionsConfig
+	}
+	pattern, _ := fuzzyNormalize(query, options.IgnoreAccents)
+	if !options.CaseSensitive {
+		for i, r := range pattern {
+			pattern[i] = unicode.ToLower(r)
+		}
+	}
+	var matches []FuzzyMatch
+	for index, candidate := range candidates {
+		text, origins := fuzzyNormalize(candidate, options.IgnoreAccents)
+		score, positions, ok := fuzzyScore(pattern, text, options.CaseSensitive)
+		if !ok {
+			continue
+		}
+		original := make([]int, 0, len(positions))
+		for _, position := range positions {
+			if len(original) == 0 || original[len(original)-1] != origins[position] {
+				original = append(original, origins[position])
+			}
+		}
+		matches = append(matches, FuzzyMatch{Value: candidate, Index: index, Score: score, Positions: original})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return len([]rune(matches[i].Value)) < len([]rune(matches[j].Value))
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// fuzzyNormalize returns the runes of `str` prepared for matching, along with the position in `str`
+// of the rune each one comes from (removing accents may expand a rune, e.g. "æ" into "ae").
+// Case is kept so that camel case boundaries can be detected; `fuzzyScore` folds it as needed.
+func fuzzyNormalize(str string, ignoreAccents bool) ([]rune, []int) {
+	runes := make([]rune, 0, len(str))
+	origins := make([]int, 0, len(str))
+	position := 0
+	for _, r := range str {
+		replacement := []rune{r}
+		if ignoreAccents {
+			replacement = []rune(RemoveAccents(string(r)))
+		}
+		for _, c := range replacement {
+			runes = append(runes, c)
+			origins = append(origins, position)
+		}
+		position++
+	}
+	return runes, origins
+}
+
+// fuzzyScore scores the best occurrence of `pattern` in `text` found by the fzf "v1" strategy: the
+// first occurrence is located by a forward scan, then shortened by a backward scan from its end.
+// Unless `caseSensitive` is set, `pattern` must already be lowercase.
+func fuzzyScore(pattern, text []rune, caseSensitive bool) (int, []int, bool) {
+	if len(pattern) == 0 {
+		return 0, []int{}, true
+	}
+	equal := func(p, t rune) bool {
+		return p == t || !caseSensitive && unicode.ToLower(t) == p
+	}
+	end, matched := -1, 0
+	for i := 0; i < len(text) && end < 0; i++ {
+		if equal(pattern[matched], text[i]) {
+			matched++
+			if matched == len(pattern) {
+				end = i + 1
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+	start := 0
+	for i, p := end-1, len(pattern)-1; i >= 0; i-- {
+		if equal(pattern[p], text[i]) {
+			p--
+			if p < 0 {
+				start = i
+				break
+			}
+		}
+	}
+	score, consecutive, firstBonus, inGap := 0, 0, 0, false
+	positions := make([]int, 0, len(pattern))
+	p := 0
+	for i := start; i < end; i++ {
+		if p < len(pattern) && equal(pattern[p], text[i]) {
+			previous := fuzzyClassWhitespace
+			if i > 0 {
+				previous = fuzzyClass(text[i-1])
+			}
+			bonus := fuzzyBonus(previous, fuzzyClass(text[i]))
+			if consecutive == 0 {
+				firstBonus = bonus
+			} else {
+				if bonus >= fuzzyBonusBoundary && bonus > firstBonus {
+					firstBonus = bonus
+				}
+				bonus = max(bonus, firstBonus, fuzzyBonusConsecutive)
+			}
+			if p == 0 {
+				bonus *= fuzzyBonusFirstCharScale
+			}
+			score += fuzzyScoreMatch + bonus
+			positions = append(positions, i)
+			consecutive++
+			inGap = false
+			p++
+			continue
+		}
+		if inGap {
+			score += fuzzyScoreGapExtension
+		} else {
+			score += fuzzyScoreGapStart
+		}
+		inGap, consecutive, firstBonus = true, 0, 0
+	}
+	return score, positions, true
+}
+
+// fuzzyClass returns the character class of `r`.
+func fuzzyClass(r rune) fuzzyCharClass {
+	switch {
+	case unicode.IsLower(r):
+		return fuzzyClassLower
+	case unicode.IsUpper(r):
+		return fuzzyClassUpper
+	case unicode.IsDigit(r):
+		return fuzzyClassDigit
+	case unicode.IsLetter(r):
+		return fuzzyClassLetter
+	case unicode.IsSpace(r):
+		return fuzzyClassWhitespace
+	case strings.ContainsRune("/,:;|-_.\\", r):
+		return fuzzyClassDelimiter
+	default:
+		return fuzzyClassPunctuation
+	}
+}
+
+// fuzzyBonus returns the bonus earned by a character of class `current` following one of class `previous`.
+func fuzzyBonus(previous, current fuzzyCharClass) int {
+	if current <= fuzzyClassPunctuation {
+		return 0
+	}
+	switch previous {
+	case fuzzyClassWhitespace:
+		return fuzzyBonusWhitespace
+	case fuzzyClassDelimiter:
+		return fuzzyBonusDelimiter
+	case fuzzyClassPunctuation:
+		return fuzzyBonusBoundary
+	}
+	if previous == fuzzyClassLower && current == fuzzyClassUpper || previous != fuzzyClassDigit && current == fuzzyClassDigit {
+		return fuzzyBonusCamel
+	}
+	return 0
+}
@@ -1,5 +1,7 @@
 package unify4g
 
+import "iter"
+
 // HashMap is a generic hash map data structure that maps keys of type `K` to values of type `V`.
 // `K` and `V` must be comparable types, meaning that they support comparison operators (like == and !=).
 // The `items` field stores the actual map, which is used to store key-value pairs.
@@ -123,3 +125,23 @@ func (hash *HashMap[K, V]) KeySet() []K {
 	}
 	return keys
 }
+
+// All returns a lazy sequence over the key-value pairs of the HashMap.
+// As with any Go map iteration, the order is not specified.
+// Returns:
+//   - An `iter.Seq2[K, V]` yielding each key with its value.
+//
+// Example:
+//
+//	for key, value := range hashMap.All() {
+//		fmt.Println(key, value)
+//	}
+func (hash *HashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range hash.items {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
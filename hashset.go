@@ -1,6 +1,9 @@
 package unify4g
 
-import "fmt"
+import (
+	"fmt"
+	"iter"
+)
 
 // itemExists is an empty struct used as a placeholder for values in the `HashSet` map.
 // Using an empty struct saves memory, as it does not allocate any space in Go.
@@ -232,3 +235,22 @@ func (hash *HashSet[T]) String() string {
 	}
 	return s
 }
+
+// All returns a lazy sequence over the elements of the HashSet. The order is not specified.
+// Returns:
+//   - An `iter.Seq[T]` yielding each element of the set.
+//
+// Example usage:
+//
+//	for item := range hashSet.All() {
+//		fmt.Println(item)
+//	}
+func (hash *HashSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range hash.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
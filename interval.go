@@ -0,0 +1,691 @@
+package unify4g
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Interval is a half-open range [Start, End) over an ordered type `T`.
+// A point `p` belongs to the interval when Start <= p < End, so two intervals
+// sharing only an endpoint (e.g. [1, 5) and [5, 9)) do not overlap but are adjacent.
+type Interval[T cmp.Ordered] struct {
+	Start T `json:"start"`
+	End   T `json:"end"`
+}
+
+// IntervalEntry is an interval stored in an `IntervalTree` together with its associated value.
+type IntervalEntry[T cmp.Ordered, V any] struct {
+	Interval[T]
+	Value V `json:"value"`
+}
+
+// IntervalTree is a generic augmented AVL tree storing half-open intervals of type `T` with values of type `V`.
+// Every node keeps the maximum end point of its subtree, which allows stabbing and overlap queries to
+// skip whole subtrees and run in O(log n + k), where k is the number of reported intervals.
+// Intervals are ordered by start, then by end; identical intervals may be stored several times.
+type IntervalTree[T cmp.Ordered, V any] struct {
+	root *intervalNode[T, V]
+	size int
+}
+
+// intervalNode is a single node of an `IntervalTree`.
+type intervalNode[T cmp.Ordered, V any] struct {
+	entry       IntervalEntry[T, V]
+	maxEnd      T
+	height      int
+	left, right *intervalNode[T, V]
+}
+
+// RangeSet is a set of values of an ordered type `T` represented as a sorted list of disjoint,
+// non-adjacent half-open intervals. Overlapping or adjacent intervals are merged on insertion,
+// so [1, 5) and [5, 9) are stored as the single interval [1, 9).
+type RangeSet[T cmp.Ordered] struct {
+	ranges []Interval[T]
+}
+
+// NewInterval creates a new half-open `Interval` [start, end).
+//
+// Parameters:
+//   - `start`: The inclusive lower bound.
+//   - `end`: The exclusive upper bound.
+//
+// Returns:
+//   - The resulting `Interval`.
+//
+// Example usage:
+//
+//	window := NewInterval(9, 17) // Covers 9 through 16.
+func NewInterval[T cmp.Ordered](start, end T) Interval[T] {
+	return Interval[T]{Start: start, End: end}
+}
+
+// IsEmpty reports whether the interval contains no point, i.e. End <= Start.
+//
+// Returns:
+//   - `true` if the interval is empty, `false` otherwise.
+//
+// Example usage:
+//
+//	empty := NewInterval(5, 5).IsEmpty() // empty will be true
+func (interval Interval[T]) IsEmpty() bool {
+	return interval.End <= interval.Start
+}
+
+// Contains reports whether `point` lies within the interval.
+//
+// Parameters:
+//   - `point`: The point to check.
+//
+// Returns:
+//   - `true` if Start <= point < End, `false` otherwise.
+//
+// Example usage:
+//
+//	inside := NewInterval(1, 5).Contains(4) // inside will be true
+func (interval Interval[T]) Contains(point T) bool {
+	return interval.Start <= point && point < interval.End
+}
+
+// Overlaps reports whether the interval shares at least one point with `another`.
+//
+// Parameters:
+//   - `another`: The interval to compare with.
+//
+// Returns:
+//   - `true` if the intervals overlap, `false` otherwise (adjacent intervals do not overlap).
+//
+// Example usage:
+//
+//	overlaps := NewInterval(1, 5).Overlaps(NewInterval(4, 8)) // overlaps will be true
+func (interval Interval[T]) Overlaps(another Interval[T]) bool {
+	return interval.Start < another.End && another.Start < interval.End
+}
+
+// String returns the interval formatted as "[start, end)".
+//
+// Returns:
+//   - A string representation of the interval.
+//
+// Example usage:
+//
+//	str := NewInterval(1, 5).String() // str will be "[1, 5)"
+func (interval Interval[T]) String() string {
+	return fmt.Sprintf("[%v, %v)", interval.Start, interval.End)
+}
+
+// NewIntervalTree creates a new, empty `IntervalTree`.
+//
+// Returns:
+//   - A pointer to an empty `IntervalTree`.
+//
+// Example usage:
+//
+//	tree := NewIntervalTree[int, string]()
+func NewIntervalTree[T cmp.Ordered, V any]() *IntervalTree[T, V] {
+	return &IntervalTree[T, V]{}
+}
+
+// Insert adds the interval [start, end) with its associated value to the tree.
+// Empty intervals (end <= start) are ignored because they can never match a query.
+//
+// Parameters:
+//   - `start`: The inclusive lower bound of the interval.
+//   - `end`: The exclusive upper bound of the interval.
+//   - `value`: The value associated with the interval.
+//
+// Returns:
+//   - `true` if the interval was inserted, `false` if it was empty.
+//
+// Example usage:
+//
+//	tree.Insert(9, 17, "office hours")
+func (tree *IntervalTree[T, V]) Insert(start, end T, value V) bool {
+	if end <= start {
+		return false
+	}
+	entry := IntervalEntry[T, V]{Interval: Interval[T]{Start: start, End: end}, Value: value}
+	tree.root = tree.root.insert(entry)
+	tree.size++
+	return true
+}
+
+// Delete removes one interval equal to [start, end) from the tree.
+// When several identical intervals are stored, only one of them is removed.
+//
+// Parameters:
+//   - `start`: The inclusive lower bound of the interval.
+//   - `end`: The exclusive upper bound of the interval.
+//
+// Returns:
+//   - `true` if an interval was removed, `false` if no such interval exists.
+//
+// Example usage:
+//
+//	removed := tree.Delete(9, 17)
+func (tree *IntervalTree[T, V]) Delete(start, end T) bool {
+	var removed bool
+	tree.root, removed = tree.root.delete(Interval[T]{Start: start, End: end})
+	if removed {
+		tree.size--
+	}
+	return removed
+}
+
+// Stab returns every stored interval containing `point`, ordered by start then end.
+//
+// Parameters:
+//   - `point`: The point to query.
+//
+// Returns:
+//   - A slice of entries whose interval satisfies start <= point < end.
+//
+// Example usage:
+//
+//	active := tree.Stab(12) // All intervals covering 12.
+func (tree *IntervalTree[T, V]) Stab(point T) []IntervalEntry[T, V] {
+	result := make([]IntervalEntry[T, V], 0)
+	tree.root.stab(point, &result)
+	return result
+}
+
+// Overlapping returns every stored interval sharing at least one point with [start, end),
+// ordered by start then end.
+//
+// Parameters:
+//   - `start`: The inclusive lower bound of the query range.
+//   - `end`: The exclusive upper bound of the query range.
+//
+// Returns:
+//   - A slice of entries overlapping the query range. Adjacent intervals are not reported.
+//
+// Example usage:
+//
+//	conflicts := tree.Overlapping(10, 11)
+func (tree *IntervalTree[T, V]) Overlapping(start, end T) []IntervalEntry[T, V] {
+	result := make([]IntervalEntry[T, V], 0)
+	if end <= start {
+		return result
+	}
+	tree.root.overlapping(Interval[T]{Start: start, End: end}, &result)
+	return result
+}
+
+// AnyOverlap reports whether at least one stored interval overlaps [start, end).
+//
+// Parameters:
+//   - `start`: The inclusive lower bound of the query range.
+//   - `end`: The exclusive upper bound of the query range.
+//
+// Returns:
+//   - `true` if an overlapping interval exists, `false` otherwise.
+//
+// Example usage:
+//
+//	busy := tree.AnyOverlap(10, 11)
+func (tree *IntervalTree[T, V]) AnyOverlap(start, end T) bool {
+	query := Interval[T]{Start: start, End: end}
+	if query.IsEmpty() {
+		return false
+	}
+	n := tree.root
+	for n != nil {
+		if n.entry.Overlaps(query) {
+			return true
+		}
+		if n.left != nil && n.left.maxEnd > query.Start {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return false
+}
+
+// Entries returns all stored intervals ordered by start then end.
+//
+// Returns:
+//   - A slice containing every entry of the tree.
+//
+// Example usage:
+//
+//	all := tree.Entries()
+func (tree *IntervalTree[T, V]) Entries() []IntervalEntry[T, V] {
+	result := make([]IntervalEntry[T, V], 0, tree.size)
+	tree.root.inOrder(&result)
+	return result
+}
+
+// Size returns the number of intervals stored in the tree.
+//
+// Returns:
+//   - The number of intervals.
+//
+// Example usage:
+//
+//	size := tree.Size()
+func (tree *IntervalTree[T, V]) Size() int {
+	return tree.size
+}
+
+// IsEmpty checks whether the tree contains no intervals.
+//
+// Returns:
+//   - `true` if the tree is empty, `false` otherwise.
+//
+// Example usage:
+//
+//	isEmpty := tree.IsEmpty()
+func (tree *IntervalTree[T, V]) IsEmpty() bool {
+	return tree.size == 0
+}
+
+// Clear removes all intervals from the tree.
+//
+// Example usage:
+//
+//	tree.Clear()
+func (tree *IntervalTree[T, V]) Clear() {
+	tree.root = nil
+	tree.size = 0
+}
+
+// NewRangeSet creates a new `RangeSet` and optionally adds the provided intervals.
+//
+// Parameters:
+//   - `intervals`: A variadic list of intervals to add initially (optional). Empty intervals are ignored.
+//
+// Returns:
+//   - A pointer to the newly created `RangeSet`.
+//
+// Example usage:
+//
+//	set := NewRangeSet(NewInterval(1, 5), NewInterval(5, 9)) // Stored as [1, 9).
+func NewRangeSet[T cmp.Ordered](intervals ...Interval[T]) *RangeSet[T] {
+	set := &RangeSet[T]{ranges: make([]Interval[T], 0)}
+	for _, interval := range intervals {
+		set.Add(interval.Start, interval.End)
+	}
+	return set
+}
+
+// Add inserts the range [start, end), merging it with any overlapping or adjacent ranges.
+//
+// Parameters:
+//   - `start`: The inclusive lower bound of the range.
+//   - `end`: The exclusive upper bound of the range. Empty ranges are ignored.
+//
+// Example usage:
+//
+//	set.Add(10, 20)
+func (set *RangeSet[T]) Add(start, end T) {
+	if end <= start {
+		return
+	}
+	i := sort.Search(len(set.ranges), func(k int) bool { return set.ranges[k].End >= start })
+	j := sort.Search(len(set.ranges), func(k int) bool { return set.ranges[k].Start > end })
+	merged := Interval[T]{Start: start, End: end}
+	if i < j {
+		merged.Start = min(start, set.ranges[i].Start)
+		merged.End = max(end, set.ranges[j-1].End)
+	}
+	ranges := make([]Interval[T], 0, len(set.ranges)-(j-i)+1)
+	ranges = append(ranges, set.ranges[:i]...)
+	ranges = append(ranges, merged)
+	ranges = append(ranges, set.ranges[j:]...)
+	set.ranges = ranges
+}
+
+// Remove deletes the range [start, end) from the set, splitting existing ranges where necessary.
+//
+// Parameters:
+//   - `start`: The inclusive lower bound of the range.
+//   - `end`: The exclusive upper bound of the range. Empty ranges are ignored.
+//
+// Example usage:
+//
+//	set.Remove(12, 14) // [10, 20) becomes [10, 12) and [14, 20).
+func (set *RangeSet[T]) Remove(start, end T) {
+	if end <= start {
+		return
+	}
+	ranges := make([]Interval[T], 0, len(set.ranges)+1)
+	for _, r := range set.ranges {
+		if r.End <= start || r.Start >= end {
+			ranges = append(ranges, r)
+			continue
+		}
+		if r.Start < start {
+			ranges = append(ranges, Interval[T]{Start: r.Start, End: start})
+		}
+		if r.End > end {
+			ranges = append(ranges, Interval[T]{Start: end, End: r.End})
+		}
+	}
+	set.ranges = ranges
+}
+
+// Contains reports whether `point` is covered by the set.
+//
+// Parameters:
+//   - `point`: The point to check.
+//
+// Returns:
+//   - `true` if a range of the set contains the point, `false` otherwise.
+//
+// Example usage:
+//
+//	covered := set.Contains(15)
+func (set *RangeSet[T]) Contains(point T) bool {
+	i := sort.Search(len(set.ranges), func(k int) bool { return set.ranges[k].End > point })
+	return i < len(set.ranges) && set.ranges[i].Start <= point
+}
+
+// ContainsRange reports whether the whole range [start, end) is covered by the set.
+//
+// Parameters:
+//   - `start`: The inclusive lower bound of the range.
+//   - `end`: The exclusive upper bound of the range.
+//
+// Returns:
+//   - `true` if a single range of the set encloses [start, end), `false` otherwise.
+//     An empty range is always considered covered.
+//
+// Example usage:
+//
+//	covered := set.ContainsRange(11, 13)
+func (set *RangeSet[T]) ContainsRange(start, end T) bool {
+	if end <= start {
+		return true
+	}
+	i := sort.Search(len(set.ranges), func(k int) bool { return set.ranges[k].End > start })
+	return i < len(set.ranges) && set.ranges[i].Start <= start && set.ranges[i].End >= end
+}
+
+// Union returns a new `RangeSet` covering every point covered by the current set or `another`.
+//
+// Parameters:
+//   - `another`: The other set to combine with.
+//
+// Returns:
+//   - A new `RangeSet` with the union of the two sets.
+//
+// Example usage:
+//
+//	result := a.Union(b)
+func (set *RangeSet[T]) Union(another *RangeSet[T]) *RangeSet[T] {
+	result := set.Clone()
+	for _, r := range another.ranges {
+		result.Add(r.Start, r.End)
+	}
+	return result
+}
+
+// Intersection returns a new `RangeSet` covering only the points covered by both the current set and `another`.
+//
+// Parameters:
+//   - `another`: The other set to intersect with.
+//
+// Returns:
+//   - A new `RangeSet` with the intersection of the two sets.
+//
+// Example usage:
+//
+//	result := a.Intersection(b)
+func (set *RangeSet[T]) Intersection(another *RangeSet[T]) *RangeSet[T] {
+	result := NewRangeSet[T]()
+	i, j := 0, 0
+	for i < len(set.ranges) && j < len(another.ranges) {
+		a, b := set.ranges[i], another.ranges[j]
+		start, end := max(a.Start, b.Start), min(a.End, b.End)
+		if start < end {
+			result.ranges = append(result.ranges, Interval[T]{Start: start, End: end})
+		}
+		if a.End < b.End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// Difference returns a new `RangeSet` covering the points covered by the current set but not by `another`.
+//
+// Parameters:
+//   - `another`: The set whose ranges are removed from the result.
+//
+// Returns:
+//   - A new `RangeSet` with the difference between the two sets.
+//
+// Example usage:
+//
+//	result := a.Difference(b)
+func (set *RangeSet[T]) Difference(another *RangeSet[T]) *RangeSet[T] {
+	result := set.Clone()
+	for _, r := range another.ranges {
+		result.Remove(r.Start, r.End)
+	}
+	return result
+}
+
+// Ranges returns a copy of the disjoint ranges of the set in ascending order.
+//
+// Returns:
+//   - A slice of the merged intervals.
+//
+// Example usage:
+//
+//	ranges := set.Ranges()
+func (set *RangeSet[T]) Ranges() []Interval[T] {
+	result := make([]Interval[T], len(set.ranges))
+	copy(result, set.ranges)
+	return result
+}
+
+// Size returns the number of disjoint ranges in the set.
+//
+// Returns:
+//   - The number of merged intervals.
+//
+// Example usage:
+//
+//	size := set.Size()
+func (set *RangeSet[T]) Size() int {
+	return len(set.ranges)
+}
+
+// IsEmpty checks whether the set covers no point.
+//
+// Returns:
+//   - `true` if the set is empty, `false` otherwise.
+//
+// Example usage:
+//
+//	isEmpty := set.IsEmpty()
+func (set *RangeSet[T]) IsEmpty() bool {
+	return len(set.ranges) == 0
+}
+
+// Clone returns a copy of the `RangeSet`.
+//
+// Returns:
+//   - A new `RangeSet` with the same ranges.
+//
+// Example usage:
+//
+//	copied := set.Clone()
+func (set *RangeSet[T]) Clone() *RangeSet[T] {
+	return &RangeSet[T]{ranges: set.Ranges()}
+}
+
+// String returns the ranges of the set separated by commas, e.g. "[1, 5),[7, 9)".
+//
+// Returns:
+//   - A string representation of the set.
+//
+// Example usage:
+//
+//	str := set.String()
+func (set *RangeSet[T]) String() string {
+	parts := make([]string, len(set.ranges))
+	for i, r := range set.ranges {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// compareIntervals orders intervals by start, then by end.
+func compareIntervals[T cmp.Ordered](a, b Interval[T]) int {
+	if c := cmp.Compare(a.Start, b.Start); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.End, b.End)
+}
+
+// insert adds `entry` below `n` and returns the rebalanced subtree root.
+func (n *intervalNode[T, V]) insert(entry IntervalEntry[T, V]) *intervalNode[T, V] {
+	if n == nil {
+		return &intervalNode[T, V]{entry: entry, maxEnd: entry.End, height: 1}
+	}
+	if compareIntervals(entry.Interval, n.entry.Interval) < 0 {
+		n.left = n.left.insert(entry)
+	} else {
+		n.right = n.right.insert(entry)
+	}
+	return n.rebalance()
+}
+
+// delete removes one node holding `interval` below `n` and returns the rebalanced subtree root.
+func (n *intervalNode[T, V]) delete(interval Interval[T]) (*intervalNode[T, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var removed bool
+	switch c := compareIntervals(interval, n.entry.Interval); {
+	case c < 0:
+		n.left, removed = n.left.delete(interval)
+	case c > 0:
+		n.right, removed = n.right.delete(interval)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		n.right, n.entry = n.right.deleteMin()
+		removed = true
+	}
+	return n.rebalance(), removed
+}
+
+// deleteMin removes the leftmost node below `n`, returning the rebalanced subtree root and the removed entry.
+func (n *intervalNode[T, V]) deleteMin() (*intervalNode[T, V], IntervalEntry[T, V]) {
+	if n.left == nil {
+		return n.right, n.entry
+	}
+	var entry IntervalEntry[T, V]
+	n.left, entry = n.left.deleteMin()
+	return n.rebalance(), entry
+}
+
+// stab collects the entries containing `point` in order.
+func (n *intervalNode[T, V]) stab(point T, result *[]IntervalEntry[T, V]) {
+	if n == nil || n.maxEnd <= point {
+		return
+	}
+	n.left.stab(point, result)
+	if n.entry.Start > point {
+		return
+	}
+	if n.entry.Contains(point) {
+		*result = append(*result, n.entry)
+	}
+	n.right.stab(point, result)
+}
+
+// overlapping collects the entries overlapping `query` in order.
+func (n *intervalNode[T, V]) overlapping(query Interval[T], result *[]IntervalEntry[T, V]) {
+	if n == nil || n.maxEnd <= query.Start {
+		return
+	}
+	n.left.overlapping(query, result)
+	if n.entry.Start >= query.End {
+		return
+	}
+	if n.entry.Overlaps(query) {
+		*result = append(*result, n.entry)
+	}
+	n.right.overlapping(query, result)
+}
+
+// inOrder collects every entry below `n` in order.
+func (n *intervalNode[T, V]) inOrder(result *[]IntervalEntry[T, V]) {
+	if n == nil {
+		return
+	}
+	n.left.inOrder(result)
+	*result = append(*result, n.entry)
+	n.right.inOrder(result)
+}
+
+// nodeHeight returns the height of `n`, treating nil as 0.
+func (n *intervalNode[T, V]) nodeHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// update recomputes the height and maximum end point of `n` from its children.
+func (n *intervalNode[T, V]) update() {
+	n.height = 1 + max(n.left.nodeHeight(), n.right.nodeHeight())
+	n.maxEnd = n.entry.End
+	if n.left != nil && n.left.maxEnd > n.maxEnd {
+		n.maxEnd = n.left.maxEnd
+	}
+	if n.right != nil && n.right.maxEnd > n.maxEnd {
+		n.maxEnd = n.right.maxEnd
+	}
+}
+
+// rebalance restores the AVL invariant at `n` and returns the new subtree root.
+func (n *intervalNode[T, V]) rebalance() *intervalNode[T, V] {
+	n.update()
+	balance := n.left.nodeHeight() - n.right.nodeHeight()
+	switch {
+	case balance > 1:
+		if n.left.left.nodeHeight() < n.left.right.nodeHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case balance < -1:
+		if n.right.right.nodeHeight() < n.right.left.nodeHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+// rotateLeft performs a left rotation around `n`.
+func (n *intervalNode[T, V]) rotateLeft() *intervalNode[T, V] {
+	pivot := n.right
+	n.right = pivot.left
+	pivot.left = n
+	n.update()
+	pivot.update()
+	return pivot
+}
+
+// rotateRight performs a right rotation around `n`.
+func (n *intervalNode[T, V]) rotateRight() *intervalNode[T, V] {
+	pivot := n.left
+	n.left = pivot.right
+	pivot.right = n
+	n.update()
+	pivot.update()
+	return pivot
+}
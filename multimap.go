@@ -0,0 +1,271 @@
+package unify4g
+
+// MultiMap is a generic map that associates each key of type `K` with a collection of values of type `V`.
+// Unlike `GroupBy`, which returns a snapshot, a `MultiMap` is a live structure that can be updated
+// incrementally. Depending on the constructor used, the values of a key follow list semantics
+// (duplicates allowed, insertion order kept) or set semantics (each value stored at most once per key,
+// insertion order kept).
+type MultiMap[K, V comparable] struct {
+	items  map[K][]V
+	index  map[K]map[V]struct{} // the values of each key, with set semantics only
+	unique bool
+	size   int
+}
+
+// NewMultiMap creates a new, empty `MultiMap` with list semantics: a key may hold the same value
+// several times, and values are returned in insertion order.
+//
+// Returns:
+//   - A pointer to an empty `MultiMap`.
+//
+// Example usage:
+//
+//	tags := NewMultiMap[string, string]()
+//	tags.Put("post-1", "go")
+func NewMultiMap[K, V comparable]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{items: make(map[K][]V)}
+}
+
+// NewSetMultiMap creates a new, empty `MultiMap` with set semantics: putting a value that is already
+// associated with a key has no effect. Each key keeps an index of its values, so that `Put` and
+// `ContainsEntry` take constant time however many values the key holds.
+//
+// Returns:
+//   - A pointer to an empty `MultiMap` that deduplicates values per key.
+//
+// Example usage:
+//
+//	index := NewSetMultiMap[string, int]()
+//	index.Put("go", 1)
+//	index.Put("go", 1) // ignored, the entry already exists
+func NewSetMultiMap[K, V comparable]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{items: make(map[K][]V), index: make(map[K]map[V]struct{}), unique: true}
+}
+
+// Put associates `value` with `key`.
+// With set semantics, the call has no effect when the entry already exists.
+//
+// Parameters:
+//   - `key`: The key to associate the value with.
+//   - `value`: The value to add.
+//
+// Returns:
+//   - `true` if the entry was added, `false` if it already existed (set semantics only).
+//
+// Example usage:
+//
+//	added := multi.Put("go", 1)
+func (multi *MultiMap[K, V]) Put(key K, value V) bool {
+	if multi.unique {
+		values, ok := multi.index[key]
+		if !ok {
+			values = make(map[V]struct{})
+			multi.index[key] = values
+		}
+		if _, exists := values[value]; exists {
+			return false
+		}
+		values[value] = struct{}{}
+	}
+	multi.items[key] = append(multi.items[key], value)
+	multi.size++
+	return true
+}
+
+// PutAll associates every value in `values` with `key`.
+//
+// Parameters:
+//   - `key`: The key to associate the values with.
+//   - `values`: A variadic list of values to add.
+//
+// Example usage:
+//
+//	multi.PutAll("go", 1, 2, 3)
+func (multi *MultiMap[K, V]) PutAll(key K, values ...V) {
+	for _, v := range values {
+		multi.Put(key, v)
+	}
+}
+
+// Get returns a copy of the values associated with `key`, in insertion order.
+//
+// Parameters:
+//   - `key`: The key to look up.
+//
+// Returns:
+//   - A new slice with the values of `key`, or an empty slice if the key is absent.
+//
+// Example usage:
+//
+//	values := multi.Get("go")
+func (multi *MultiMap[K, V]) Get(key K) []V {
+	values := multi.items[key]
+	result := make([]V, len(values))
+	copy(result, values)
+	return result
+}
+
+// Remove deletes a single occurrence of the entry (`key`, `value`).
+// When the last value of a key is removed, the key itself is removed.
+//
+// Parameters:
+//   - `key`: The key of the entry.
+//   - `value`: The value of the entry.
+//
+// Returns:
+//   - `true` if an entry was removed, `false` if it did not exist.
+//
+// Example usage:
+//
+//	removed := multi.Remove("go", 1)
+func (multi *MultiMap[K, V]) Remove(key K, value V) bool {
+	if multi.unique && !multi.ContainsEntry(key, value) {
+		return false
+	}
+	values := multi.items[key]
+	for i, v := range values {
+		if v == value {
+			values = append(values[:i], values[i+1:]...)
+			multi.size--
+			if multi.unique {
+				delete(multi.index[key], value)
+			}
+			if len(values) == 0 {
+				delete(multi.items, key)
+				delete(multi.index, key)
+			} else {
+				multi.items[key] = values
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveAll deletes `key` along with all of its values.
+//
+// Parameters:
+//   - `key`: The key to remove.
+//
+// Returns:
+//   - The values that were associated with `key`, or an empty slice if the key was absent.
+//
+// Example usage:
+//
+//	removed := multi.RemoveAll("go")
+func (multi *MultiMap[K, V]) RemoveAll(key K) []V {
+	values, ok := multi.items[key]
+	if !ok {
+		return []V{}
+	}
+	delete(multi.items, key)
+	delete(multi.index, key)
+	multi.size -= len(values)
+	return values
+}
+
+// ContainsKey checks whether `key` has at least one associated value.
+//
+// Parameters:
+//   - `key`: The key to check.
+//
+// Returns:
+//   - `true` if the key is present, `false` otherwise.
+//
+// Example usage:
+//
+//	exists := multi.ContainsKey("go")
+func (multi *MultiMap[K, V]) ContainsKey(key K) bool {
+	_, ok := multi.items[key]
+	return ok
+}
+
+// ContainsEntry checks whether `value` is associated with `key`.
+//
+// Parameters:
+//   - `key`: The key of the entry.
+//   - `value`: The value of the entry.
+//
+// Returns:
+//   - `true` if the entry exists, `false` otherwise.
+//
+// Example usage:
+//
+//	exists := multi.ContainsEntry("go", 1)
+func (multi *MultiMap[K, V]) ContainsEntry(key K, value V) bool {
+	if multi.unique {
+		_, ok := multi.index[key][value]
+		return ok
+	}
+	for _, v := range multi.items[key] {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyCount returns the number of distinct keys.
+//
+// Returns:
+//   - The number of keys with at least one value.
+//
+// Example usage:
+//
+//	keys := multi.KeyCount()
+func (multi *MultiMap[K, V]) KeyCount() int {
+	return len(multi.items)
+}
+
+// Size returns the total number of key-value entries across all keys.
+//
+// Returns:
+//   - The number of entries in the map.
+//
+// Example usage:
+//
+//	entries := multi.Size()
+func (multi *MultiMap[K, V]) Size() int {
+	return multi.size
+}
+
+// IsEmpty checks whether the map contains no entries.
+//
+// Returns:
+//   - `true` if the map is empty, `false` otherwise.
+//
+// Example usage:
+//
+//	isEmpty := multi.IsEmpty()
+func (multi *MultiMap[K, V]) IsEmpty() bool {
+	return multi.size == 0
+}
+
+// Keys returns all keys that have at least one value. The order is not specified.
+//
+// Returns:
+//   - A slice containing the keys of the map.
+//
+// Example usage:
+//
+//	keys := multi.Keys()
+func (multi *MultiMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(multi.items))
+	for key := range multi.items {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Clear removes all entries from the map.
+//
+// Example usage:
+//
+//	multi.Clear()
+func (multi *MultiMap[K, V]) Clear() {
+	multi.items = make(map[K][]V)
+	if multi.unique {
+		multi.index = make(map[K]map[V]struct{})
+	}
+	multi.size = 0
+}
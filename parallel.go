@@ -0,0 +1,242 @@
+package unify4g
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelMap applies `f` to every element of `items` using at most `workers` goroutines and
+// returns the results in the same order as the input.
+//
+// The first error returned by `f` cancels the context passed to the remaining calls and is
+// returned to the caller; elements not yet started are skipped. A panic inside `f` is recovered
+// and reported as an error. If `ctx` is cancelled before all elements are processed, `ctx.Err()`
+// is returned.
+//
+// Parameters:
+//   - `ctx`: The parent context controlling cancellation.
+//   - `items`: The input slice.
+//   - `workers`: The maximum number of concurrent goroutines. Values <= 0 default to `runtime.GOMAXPROCS(0)`.
+//   - `f`: The transformation applied to each element.
+//
+// Returns:
+//   - A slice with the transformed elements, in input order, or nil on error.
+//   - The first error encountered, if any.
+//
+// Example:
+//
+//	enriched, err := ParallelMap(ctx, records, 8, func(ctx context.Context, r Record) (Enriched, error) {
+//		return enrich(ctx, r)
+//	})
+func ParallelMap[T, U any](ctx context.Context, items []T, workers int, f func(context.Context, T) (U, error)) ([]U, error) {
+	result := make([]U, len(items))
+	err := parallelRun(ctx, len(items), workers, func(ctx context.Context, i int) error {
+		v, err := f(ctx, items[i])
+		if err != nil {
+			return err
+		}
+		result[i] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ParallelFilter evaluates `predicate` on every element of `items` using at most `workers` goroutines
+// and returns the elements for which it returned `true`, preserving their input order.
+// Errors, panics and cancellation are handled as in `ParallelMap`.
+//
+// Parameters:
+//   - `ctx`: The parent context controlling cancellation.
+//   - `items`: The input slice.
+//   - `workers`: The maximum number of concurrent goroutines. Values <= 0 default to `runtime.GOMAXPROCS(0)`.
+//   - `predicate`: The condition evaluated on each element.
+//
+// Returns:
+//   - A slice with the matching elements, in input order, or nil on error.
+//   - The first error encountered, if any.
+//
+// Example:
+//
+//	valid, err := ParallelFilter(ctx, urls, 16, func(ctx context.Context, u string) (bool, error) {
+//		return isReachable(ctx, u)
+//	})
+func ParallelFilter[T any](ctx context.Context, items []T, workers int, predicate func(context.Context, T) (bool, error)) ([]T, error) {
+	keep := make([]bool, len(items))
+	err := parallelRun(ctx, len(items), workers, func(ctx context.Context, i int) error {
+		ok, err := predicate(ctx, items[i])
+		if err != nil {
+			return err
+		}
+		keep[i] = ok
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]T, 0)
+	for i, item := range items {
+		if keep[i] {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// ParallelForEach calls `f` on every element of `items` using at most `workers` goroutines.
+// Errors, panics and cancellation are handled as in `ParallelMap`.
+//
+// Parameters:
+//   - `ctx`: The parent context controlling cancellation.
+//   - `items`: The input slice.
+//   - `workers`: The maximum number of concurrent goroutines. Values <= 0 default to `runtime.GOMAXPROCS(0)`.
+//   - `f`: The action executed for each element.
+//
+// Returns:
+//   - The first error encountered, if any.
+//
+// Example:
+//
+//	err := ParallelForEach(ctx, users, 4, func(ctx context.Context, u User) error {
+//		return notify(ctx, u)
+//	})
+func ParallelForEach[T any](ctx context.Context, items []T, workers int, f func(context.Context, T) error) error {
+	return parallelRun(ctx, len(items), workers, func(ctx context.Context, i int) error {
+		return f(ctx, items[i])
+	})
+}
+
+// ParallelReduce splits `items` into at most `workers` contiguous segments, folds each segment
+// concurrently with `accumulator` starting from `initialValue`, and then merges the partial results
+// from left to right with `combiner`.
+//
+// Because every segment starts from `initialValue`, it must be an identity element for `combiner`
+// (e.g. 0 for addition), and `combiner` must be associative for the result to match a sequential
+// `Reduce`. Errors, panics and cancellation are handled as in `ParallelMap`.
+//
+// Parameters:
+//   - `ctx`: The parent context controlling cancellation.
+//   - `items`: The input slice.
+//   - `workers`: The maximum number of concurrent goroutines. Values <= 0 default to `runtime.GOMAXPROCS(0)`.
+//   - `initialValue`: The identity value each segment starts from.
+//   - `accumulator`: Folds one element into a segment's running result.
+//   - `combiner`: Merges two partial results.
+//
+// Returns:
+//   - The combined result, or `initialValue` if `items` is empty.
+//   - The first error encountered, if any.
+//
+// Example:
+//
+//	total, err := ParallelReduce(ctx, orders, 8, 0.0,
+//		func(ctx context.Context, acc float64, o Order) (float64, error) { return acc + price(o), nil },
+//		func(a, b float64) float64 { return a + b })
+func ParallelReduce[T, U any](ctx context.Context, items []T, workers int, initialValue U, accumulator func(context.Context, U, T) (U, error), combiner func(U, U) U) (U, error) {
+	workers = parallelWorkers(workers, len(items))
+	if len(items) == 0 {
+		return initialValue, nil
+	}
+	size := (len(items) + workers - 1) / workers
+	segments := Chunk(items, size)
+	partials := make([]U, len(segments))
+	err := parallelRun(ctx, len(segments), workers, func(ctx context.Context, i int) (err error) {
+		acc, index := initialValue, 0
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic at index %d: %v", i*size+index, r)
+			}
+		}()
+		for j, item := range segments[i] {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			index = j
+			if acc, err = accumulator(ctx, acc, item); err != nil {
+				return err
+			}
+		}
+		partials[i] = acc
+		return nil
+	})
+	if err != nil {
+		return initialValue, err
+	}
+	result := partials[0]
+	for _, partial := range partials[1:] {
+		result = combiner(result, partial)
+	}
+	return result, nil
+}
+
+// parallelWorkers normalizes the requested worker count for `n` tasks.
+func parallelWorkers(workers, n int) int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+	return max(workers, 1)
+}
+
+// parallelRun executes `task` for every index in [0, n) on a bounded pool of goroutines.
+// The first failure cancels the shared context; panics are recovered into errors.
+func parallelRun(ctx context.Context, n int, workers int, task func(ctx context.Context, i int) error) error {
+	if n == 0 {
+		return ctx.Err()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		next     atomic.Int64
+		done     atomic.Int64
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	run := func(i int) {
+		defer func() {
+			if r := recover(); r != nil {
+				fail(fmt.Errorf("panic at index %d: %v", i, r))
+			}
+		}()
+		if err := task(ctx, i); err != nil {
+			fail(err)
+			return
+		}
+		done.Add(1)
+	}
+	workers = parallelWorkers(workers, n)
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= n || ctx.Err() != nil {
+					return
+				}
+				run(i)
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	if int(done.Load()) == n {
+		return nil
+	}
+	return ctx.Err()
+}
@@ -202,3 +202,48 @@ func NextBytes(count int) []byte {
 func nextInt() int {
 	return rand.Int()
 }
+
+// Sample returns a random element of `slice`, chosen with the package-level random generator.
+//
+// Parameters:
+//   - `slice`: The slice to pick from.
+//
+// Returns:
+//   - A random element, and `true`; or the zero value and `false` if `slice` is empty.
+//
+// Example:
+//
+//	color, ok := Sample([]string{"red", "green", "blue"})
+//	fmt.Println("Random color:", color)
+func Sample[T any](slice []T) (T, bool) {
+	if len(slice) == 0 {
+		var zero T
+		return zero, false
+	}
+	return slice[r.Intn(len(slice))], true
+}
+
+// SampleN returns `n` elements of `slice` chosen at random without replacement, so that no position
+// is picked twice, using the package-level random generator. The input slice is not modified.
+//
+// Parameters:
+//   - `slice`: The slice to pick from.
+//   - `n`: The number of elements to pick; clamped to the range [0, len(slice)].
+//
+// Returns:
+//   - A new slice of `n` elements in random order.
+//
+// Example:
+//
+//	winners := SampleN(participants, 3)
+//	fmt.Println("Winners:", winners)
+func SampleN[T any](slice []T, n int) []T {
+	n = max(0, min(n, len(slice)))
+	pool := append([]T{}, slice...)
+	// Partial Fisher-Yates: only the first n positions need to be shuffled.
+	for i := 0; i < n; i++ {
+		j := i + r.Intn(len(pool)-i)
+		pool[i], pool[j] = pool[j], pool[i]
+	}
+	return pool[:n:n]
+}
@@ -1,6 +1,14 @@
 package unify4g
 
-import "reflect"
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // IsPrimitive checks whether the given value is a primitive type in Go.
 //
@@ -44,3 +52,781 @@ func IsPrimitive(value interface{}) bool {
 		return false
 	}
 }
+
+// copyKey identifies a pointer, map or slice already copied by `DeepCopy`, so that shared
+// references and cycles are reproduced in the copy instead of being followed forever.
+type copyKey struct {
+	pointer uintptr
+	typ     reflect.Type
+	length  int
+}
+
+// deepCopier carries the state of a single `DeepCopy` call.
+type deepCopier struct {
+	visited        map[copyKey]reflect.Value
+	zeroUnexported bool
+}
+
+// jsonField describes how a struct field is named and serialized according to its `json` tag.
+type jsonField struct {
+	index     int
+	name      string
+	omitEmpty bool
+	inline    bool
+}
+
+// DeepCopy returns a deep copy of `v`: pointers, slices, maps, arrays, interfaces and structs are
+// duplicated recursively, so that modifying the copy never affects the original.
+//
+// The copy preserves the shape of the original graph: a value referenced twice is copied once and
+// referenced twice in the copy, and cyclic structures are supported. `time.Time` values are copied as
+// is, while channels and functions are shared. Unexported struct fields are copied shallowly; use
+// `DeepCopyWith` to reset them instead.
+//
+// Compared to a round trip through `MarshalN` and `UnmarshalN`, DeepCopy keeps the exact types of
+// interface values, copies unexported fields, and is considerably faster.
+//
+// Parameters:
+//   - `v`: The value to copy.
+//
+// Returns:
+//   - A deep copy of `v`.
+//
+// Example:
+//
+//	original := &Config{Hosts: []string{"a"}, Limits: map[string]int{"rps": 10}}
+//	clone := DeepCopy(original)
+//	clone.Hosts[0] = "b"
+//	// original.Hosts[0] is still "a"
+func DeepCopy[T any](v T) T {
+	return DeepCopyWith(v, nil)
+}
+
+// DeepCopyWith returns a deep copy of `v` like `DeepCopy`, using the given options.
+//
+// Parameters:
+//   - `v`: The value to copy.
+//   - `options`: The copy configuration; nil uses the defaults.
+//
+// Returns:
+//   - A deep copy of `v`.
+//
+// Example:
+//
+//	clone := DeepCopyWith(session, &CopyOptionsConfig{ZeroUnexported: true})
+func DeepCopyWith[T any](v T, options *CopyOptionsConfig) T {
+	copier := &deepCopier{visited: make(map[copyKey]reflect.Value)}
+	if options != nil {
+		copier.zeroUnexported = options.ZeroUnexported
+	}
+	var result T
+	reflect.ValueOf(&result).Elem().Set(copier.copy(reflect.ValueOf(&v).Elem()))
+	return result
+}
+
+// StructToMap converts a struct into a `map[string]interface{}` keyed by the names in its `json` tags.
+//
+// Fields follow the `encoding/json` conventions: the tag name replaces the field name, `json:"-"`
+// skips the field, `omitempty` omits it when empty, and embedded structs without a tag are inlined.
+// Nested structs become nested maps, and slices, arrays and maps containing structs are converted
+// element by element. `time.Time` values and types implementing `encoding.TextMarshaler` are kept as is.
+//
+// Parameters:
+//   - `v`: A struct or a pointer to a struct.
+//   - `options`: The conversion configuration; nil uses `DefaultStructMapOptionsConfig`.
+//
+// Returns:
+//   - The resulting map.
+//   - An error if `v` is not a struct.
+//
+// Example:
+//
+//	type User struct {
+//		Name    string `json:"name"`
+//		Email   string `json:"email,omitempty"`
+//		Address struct {
+//			City string `json:"city"`
+//		} `json:"address"`
+//	}
+//	m, err := StructToMap(User{Name: "Ann"}, nil)
+//	// m will be map[string]interface{}{"name": "Ann", "address": map[string]interface{}{"city": ""}}
+//	m, err = StructToMap(User{Name: "Ann"}, &StructMapOptionsConfig{Flatten: true})
+//	// m will be map[string]interface{}{"name": "Ann", "address.city": ""}
+func StructToMap(v interface{}, options *StructMapOptionsConfig) (map[string]interface{}, error) {
+	if options == nil {
+		options = DefaultStructMapOptionsConfig
+	}
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("struct to map: expected a struct or a pointer to a struct, got %T", v)
+	}
+	result := structToMap(value, options)
+	if options.Flatten {
+		return FlattenMap(result, options.Separator), nil
+	}
+	return result, nil
+}
+
+// MapToStruct fills the struct pointed to by `out` from the map `m`, matching keys with the names in
+// the `json` tags of its fields (or the field names when untagged, case-insensitively as a fallback).
+//
+// Values are converted when needed: numbers between numeric types when their value is kept (a float
+// with a fractional part or a number out of the range of the field is an error), nested maps into structs and maps,
+// `[]interface{}` into typed slices, RFC 3339 strings into `time.Time`, and strings into types
+// implementing `encoding.TextUnmarshaler`. Pointers are allocated as needed and unknown keys are ignored.
+// Flattened maps should be expanded with `UnflattenMap` first.
+//
+// Parameters:
+//   - `m`: The source map.
+//   - `out`: A non-nil pointer to the destination struct.
+//
+// Returns:
+//   - An error naming the offending field if a value cannot be converted, or if `out` is not a pointer to a struct.
+//
+// Example:
+//
+//	var user User
+//	err := MapToStruct(map[string]interface{}{"name": "Ann", "address": map[string]interface{}{"city": "Oslo"}}, &user)
+//	// user.Name will be "Ann", user.Address.City will be "Oslo"
+func MapToStruct(m map[string]interface{}, out interface{}) error {
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("map to struct: expected a non-nil pointer to a struct, got %T", out)
+	}
+	return assignValue(value.Elem(), reflect.ValueOf(m), "")
+}
+
+// FieldByPath returns the value found at `path` inside `v`, following struct fields, slice and array
+// indexes, map keys and pointers. The path uses the syntax of `GetIn`, e.g. "Orders[0].Items.2.Name";
+// struct fields may be referenced by their Go name or their `json` tag name.
+//
+// Parameters:
+//   - `v`: The root value.
+//   - `path`: The path to the value.
+//
+// Returns:
+//   - The value at `path`.
+//   - An error if the path is invalid, does not exist or crosses a nil pointer.
+//
+// Example:
+//
+//	city, err := FieldByPath(order, "Customer.Addresses[0].City")
+func FieldByPath(v interface{}, path string) (interface{}, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	value := reflect.ValueOf(v)
+	for _, segment := range segments {
+		if value, err = stepPath(value, segment, path); err != nil {
+			return nil, err
+		}
+	}
+	if !value.CanInterface() {
+		return nil, fmt.Errorf("field by path '%s': value is not accessible", path)
+	}
+	return value.Interface(), nil
+}
+
+// SetFieldByPath stores `value` at `path` inside the value pointed to by `v`, using the path syntax
+// of `FieldByPath`. Nil pointers along the path are allocated, map entries are created or replaced,
+// and `value` is converted to the destination type like in `MapToStruct`.
+//
+// Parameters:
+//   - `v`: A pointer to the root value.
+//   - `path`: The path of the value to set.
+//   - `value`: The new value.
+//
+// Returns:
+//   - An error if `v` is not a pointer, the path is invalid or the value cannot be converted.
+//
+// Example:
+//
+//	err := SetFieldByPath(&order, "Customer.Addresses[0].City", "Oslo")
+func SetFieldByPath(v interface{}, path string, value interface{}) error {
+	segments, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	root := reflect.ValueOf(v)
+	if root.Kind() != reflect.Ptr || root.IsNil() {
+		return fmt.Errorf("set field by path '%s': expected a non-nil pointer, got %T", path, v)
+	}
+	current := root.Elem()
+	for i, segment := range segments {
+		for current.Kind() == reflect.Ptr {
+			if current.IsNil() {
+				current.Set(reflect.New(current.Type().Elem()))
+			}
+			current = current.Elem()
+		}
+		last := i == len(segments)-1
+		if current.Kind() == reflect.Map {
+			if !last {
+				return fmt.Errorf("set field by path '%s': cannot set inside map entry '%s'", path, segment.mapKey())
+			}
+			key, err := mapKeyValue(current.Type().Key(), segment)
+			if err != nil {
+				return fmt.Errorf("set field by path '%s': %v", path, err)
+			}
+			if current.IsNil() {
+				current.Set(reflect.MakeMap(current.Type()))
+			}
+			element := reflect.New(current.Type().Elem()).Elem()
+			if err := assignValue(element, reflect.ValueOf(value), path); err != nil {
+				return err
+			}
+			current.SetMapIndex(key, element)
+			return nil
+		}
+		if current.Kind() == reflect.Struct && !segment.isIndex {
+			allocateEmbedded(current, segment.key)
+		}
+		if current, err = stepPath(current, segment, path); err != nil {
+			return err
+		}
+		if !current.CanSet() {
+			return fmt.Errorf("set field by path '%s': value is not settable", path)
+		}
+	}
+	return assignValue(current, reflect.ValueOf(value), path)
+}
+
+// Walk visits every value reachable from `v` in depth-first order: exported and embedded struct fields, slice
+// and array elements, and map entries (in natural key order), following pointers and interfaces.
+// `visit` receives the path of each value, in the syntax of `FieldByPath`, and returns whether Walk
+// should descend into it. Each pointer is followed only once, so cyclic structures terminate.
+//
+// Parameters:
+//   - `v`: The root value; it is not visited itself.
+//   - `visit`: Called for every value; returning false skips its children.
+//
+// Example:
+//
+//	Walk(config, func(path string, value reflect.Value) bool {
+//		if value.Kind() == reflect.String && strings.Contains(strings.ToLower(path), "password") {
+//			fmt.Println("secret at", path)
+//		}
+//		return true
+//	})
+func Walk(v interface{}, visit func(path string, value reflect.Value) bool) {
+	walkValue(reflect.ValueOf(v), "", visit, make(map[copyKey]bool))
+}
+
+// copy returns a deep copy of `src`.
+func (copier *deepCopier) copy(src reflect.Value) reflect.Value {
+	if !src.IsValid() {
+		return src
+	}
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		key := copyKey{pointer: src.Pointer(), typ: src.Type()}
+		if dst, seen := copier.visited[key]; seen {
+			return dst
+		}
+		dst := reflect.New(src.Type().Elem())
+		copier.visited[key] = dst
+		dst.Elem().Set(copier.copy(src.Elem()))
+		return dst
+	case reflect.Interface:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(copier.copy(src.Elem()))
+		return dst
+	case reflect.Struct:
+		if src.Type() == reflect.TypeOf(time.Time{}) {
+			return src
+		}
+		dst := reflect.New(src.Type()).Elem()
+		if !copier.zeroUnexported {
+			dst.Set(src)
+		}
+		for i := 0; i < src.NumField(); i++ {
+			if src.Type().Field(i).IsExported() {
+				dst.Field(i).Set(copier.copy(src.Field(i)))
+			}
+		}
+		return dst
+	case reflect.Slice:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		key := copyKey{pointer: src.Pointer(), typ: src.Type(), length: src.Len()}
+		if dst, seen := copier.visited[key]; seen {
+			return dst
+		}
+		dst := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		copier.visited[key] = dst
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(copier.copy(src.Index(i)))
+		}
+		return dst
+	case reflect.Array:
+		dst := reflect.New(src.Type()).Elem()
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(copier.copy(src.Index(i)))
+		}
+		return dst
+	case reflect.Map:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		key := copyKey{pointer: src.Pointer(), typ: src.Type()}
+		if dst, seen := copier.visited[key]; seen {
+			return dst
+		}
+		dst := reflect.MakeMapWithSize(src.Type(), src.Len())
+		copier.visited[key] = dst
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(copier.copy(iter.Key()), copier.copy(iter.Value()))
+		}
+		return dst
+	default:
+		return src
+	}
+}
+
+// jsonFields returns the serializable fields of the struct type `t`, following the `json` tag conventions.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup("json")
+		if tag == "-" {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && (!hasTag || name == "") && fieldType.Kind() == reflect.Struct {
+			fields = append(fields, jsonField{index: i, inline: true})
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, jsonField{index: i, name: name, omitEmpty: strings.Contains(","+flags+",", ",omitempty,")})
+	}
+	return fields
+}
+
+// structToMap converts the struct `value` into a map.
+func structToMap(value reflect.Value, options *StructMapOptionsConfig) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, field := range jsonFields(value.Type()) {
+		fieldValue := value.Field(field.index)
+		if field.inline {
+			for fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					break
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			if fieldValue.Kind() == reflect.Struct {
+				for key, v := range structToMap(fieldValue, options) {
+					if _, exists := result[key]; !exists {
+						result[key] = v
+					}
+				}
+			}
+			continue
+		}
+		if (field.omitEmpty || options.OmitEmpty) && isEmptyValue(fieldValue) {
+			continue
+		}
+		result[field.name] = toMapValue(fieldValue, options)
+	}
+	return result
+}
+
+// toMapValue converts a field value for `StructToMap`, turning structs into maps recursively.
+func toMapValue(value reflect.Value, options *StructMapOptionsConfig) interface{} {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Type() == reflect.TypeOf(time.Time{}) || value.Type().Implements(reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()) {
+		return value.Interface()
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		return structToMap(value, options)
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return value.Interface()
+		}
+		if !holdsStructs(value.Type().Elem()) {
+			return value.Interface()
+		}
+		items := make([]interface{}, value.Len())
+		for i := range items {
+			items[i] = toMapValue(value.Index(i), options)
+		}
+		return items
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String || !holdsStructs(value.Type().Elem()) || value.IsNil() {
+			return value.Interface()
+		}
+		items := make(map[string]interface{}, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			items[iter.Key().String()] = toMapValue(iter.Value(), options)
+		}
+		return items
+	default:
+		return value.Interface()
+	}
+}
+
+// assignValue stores `src` into the settable `dst`, converting it as described for `MapToStruct`.
+func assignValue(dst, src reflect.Value, path string) error {
+	for src.IsValid() && src.Kind() == reflect.Interface {
+		src = src.Elem()
+	}
+	if !src.IsValid() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	failWith := func(reason string) error {
+		if path == "" {
+			return fmt.Errorf("map to struct: cannot assign %s to %s%s", src.Type(), dst.Type(), reason)
+		}
+		return fmt.Errorf("map to struct: field '%s': cannot assign %s to %s%s", path, src.Type(), dst.Type(), reason)
+	}
+	fail := func() error { return failWith("") }
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+	if dst.Kind() == reflect.Ptr {
+		element := reflect.New(dst.Type().Elem())
+		if err := assignValue(element.Elem(), src, path); err != nil {
+			return err
+		}
+		dst.Set(element)
+		return nil
+	}
+	if src.Kind() == reflect.String {
+		if dst.Type() == reflect.TypeOf(time.Time{}) {
+			t, err := time.Parse(time.RFC3339Nano, src.String())
+			if err != nil {
+				return fmt.Errorf("map to struct: field '%s': %v", path, err)
+			}
+			dst.Set(reflect.ValueOf(t))
+			return nil
+		}
+		if dst.CanAddr() {
+			if unmarshaler, ok := dst.Addr().Interface().(encoding.TextUnmarshaler); ok {
+				return unmarshaler.UnmarshalText([]byte(src.String()))
+			}
+		}
+	}
+	switch dst.Kind() {
+	case reflect.Struct:
+		m, ok := src.Interface().(map[string]interface{})
+		if !ok {
+			return fail()
+		}
+		return assignStruct(dst, m, path)
+	case reflect.Slice, reflect.Array:
+		if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+			return fail()
+		}
+		length := src.Len()
+		if dst.Kind() == reflect.Slice {
+			dst.Set(reflect.MakeSlice(dst.Type(), length, length))
+		} else if length > dst.Len() {
+			return fail()
+		}
+		for i := 0; i < length; i++ {
+			if err := assignValue(dst.Index(i), src.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if src.Kind() != reflect.Map {
+			return fail()
+		}
+		result := reflect.MakeMapWithSize(dst.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			key := reflect.New(dst.Type().Key()).Elem()
+			if err := assignValue(key, iter.Key(), path); err != nil {
+				return err
+			}
+			element := reflect.New(dst.Type().Elem()).Elem()
+			if err := assignValue(element, iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key().Interface())); err != nil {
+				return err
+			}
+			result.SetMapIndex(key, element)
+		}
+		dst.Set(result)
+		return nil
+	}
+	if isNumberKind(src.Kind()) && isNumberKind(dst.Kind()) {
+		if reason := numberConversionLoss(dst, src); reason != "" {
+			return failWith(": " + reason)
+		}
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	}
+	if src.Kind() == dst.Kind() && src.Type().ConvertibleTo(dst.Type()) {
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	}
+	return fail()
+}
+
+// numberConversionLoss reports why converting the number `src` to the type of `dst` would change
+// its value, or returns an empty string if the conversion is exact. Floats may lose precision.
+func numberConversionLoss(dst, src reflect.Value) string {
+	switch {
+	case src.CanInt():
+		n := src.Int()
+		if (dst.CanInt() && dst.OverflowInt(n)) || (dst.CanUint() && (n < 0 || dst.OverflowUint(uint64(n)))) {
+			return fmt.Sprintf("%d out of range", n)
+		}
+	case src.CanUint():
+		n := src.Uint()
+		if (dst.CanInt() && (n > math.MaxInt64 || dst.OverflowInt(int64(n)))) || (dst.CanUint() && dst.OverflowUint(n)) {
+			return fmt.Sprintf("%d out of range", n)
+		}
+	case src.CanFloat():
+		f := src.Float()
+		switch {
+		case dst.CanFloat():
+			if !math.IsInf(f, 0) && dst.OverflowFloat(f) {
+				return fmt.Sprintf("%v out of range", f)
+			}
+		case f != math.Trunc(f) || math.IsNaN(f):
+			return fmt.Sprintf("%v has a fractional part", f)
+		case dst.CanInt() && (f < math.MinInt64 || f >= math.MaxInt64 || dst.OverflowInt(int64(f))):
+			return fmt.Sprintf("%v out of range", f)
+		case dst.CanUint() && (f < 0 || f >= math.MaxUint64 || dst.OverflowUint(uint64(f))):
+			return fmt.Sprintf("%v out of range", f)
+		}
+	}
+	return ""
+}
+
+// assignStruct fills the struct `dst` from the map `m`.
+func assignStruct(dst reflect.Value, m map[string]interface{}, prefix string) error {
+	for _, field := range jsonFields(dst.Type()) {
+		fieldValue := dst.Field(field.index)
+		if field.inline {
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					if !fieldValue.CanSet() {
+						continue
+					}
+					fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			if err := assignStruct(fieldValue, m, prefix); err != nil {
+				return err
+			}
+			continue
+		}
+		value, exists := m[field.name]
+		if !exists {
+			for key, v := range m {
+				if strings.EqualFold(key, field.name) {
+					value, exists = v, true
+					break
+				}
+			}
+		}
+		if !exists {
+			continue
+		}
+		path := field.name
+		if prefix != "" {
+			path = prefix + "." + field.name
+		}
+		if err := assignValue(fieldValue, reflect.ValueOf(value), path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stepPath follows one path segment from `value`, dereferencing pointers and interfaces first.
+func stepPath(value reflect.Value, segment pathSegment, path string) (reflect.Value, error) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}, fmt.Errorf("field by path '%s': nil value before '%s'", path, segment.mapKey())
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return reflect.Value{}, fmt.Errorf("field by path '%s': nil value before '%s'", path, segment.mapKey())
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		if !segment.isIndex {
+			if field, ok := value.Type().FieldByName(segment.key); ok && field.IsExported() {
+				found, err := value.FieldByIndexErr(field.Index)
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("field by path '%s': nil embedded struct before '%s'", path, segment.mapKey())
+				}
+				return found, nil
+			}
+			if field, ok := fieldByJSONName(value, segment.key); ok {
+				return field, nil
+			}
+		}
+		return reflect.Value{}, fmt.Errorf("field by path '%s': no field '%s' in %s", path, segment.mapKey(), value.Type())
+	case reflect.Slice, reflect.Array:
+		index, ok := segment.position()
+		if !ok || index >= value.Len() {
+			return reflect.Value{}, fmt.Errorf("field by path '%s': index '%s' out of range", path, segment.mapKey())
+		}
+		return value.Index(index), nil
+	case reflect.Map:
+		key, err := mapKeyValue(value.Type().Key(), segment)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("field by path '%s': %v", path, err)
+		}
+		element := value.MapIndex(key)
+		if !element.IsValid() {
+			return reflect.Value{}, fmt.Errorf("field by path '%s': key '%s' not found", path, segment.mapKey())
+		}
+		return element, nil
+	default:
+		return reflect.Value{}, fmt.Errorf("field by path '%s': cannot select '%s' in %s", path, segment.mapKey(), value.Type())
+	}
+}
+
+// allocateEmbedded allocates the nil embedded struct pointers through which the field `name` of the
+// struct `value` is promoted, so that it can be set.
+func allocateEmbedded(value reflect.Value, name string) {
+	field, ok := value.Type().FieldByName(name)
+	if !ok {
+		return
+	}
+	for _, index := range field.Index[:len(field.Index)-1] {
+		value = value.Field(index)
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				if !value.CanSet() {
+					return
+				}
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+	}
+}
+
+// fieldByJSONName returns the field of the struct `value` whose `json` name is `name`, searching
+// inlined embedded structs as well.
+func fieldByJSONName(value reflect.Value, name string) (reflect.Value, bool) {
+	for _, field := range jsonFields(value.Type()) {
+		fieldValue := value.Field(field.index)
+		if !field.inline {
+			if field.name == name {
+				return fieldValue, true
+			}
+			continue
+		}
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if found, ok := fieldByJSONName(fieldValue, name); ok {
+			return found, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// mapKeyValue converts a path segment into a key of type `keyType`.
+func mapKeyValue(keyType reflect.Type, segment pathSegment) (reflect.Value, error) {
+	key := reflect.New(keyType).Elem()
+	switch {
+	case keyType.Kind() == reflect.String:
+		key.SetString(segment.mapKey())
+	case keyType.Kind() >= reflect.Int && keyType.Kind() <= reflect.Int64:
+		n, err := strconv.ParseInt(segment.mapKey(), 10, 64)
+		if err != nil {
+			return key, fmt.Errorf("invalid map key '%s'", segment.mapKey())
+		}
+		key.SetInt(n)
+	case keyType.Kind() >= reflect.Uint && keyType.Kind() <= reflect.Uintptr:
+		n, err := strconv.ParseUint(segment.mapKey(), 10, 64)
+		if err != nil {
+			return key, fmt.Errorf("invalid map key '%s'", segment.mapKey())
+		}
+		key.SetUint(n)
+	default:
+		return key, fmt.Errorf("unsupported map key type %s", keyType)
+	}
+	return key, nil
+}
+
+// walkValue visits the children of `value`, whose path is `path`.
+func walkValue(value reflect.Value, path string, visit func(string, reflect.Value) bool, seen map[copyKey]bool) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		if value.Kind() == reflect.Ptr {
+			key := copyKey{pointer: value.Pointer(), typ: value.Type()}
+			if seen[key] {
+				return
+			}
+			seen[key] = true
+		}
+		value = value.Elem()
+	}
+	child := func(childPath string, childValue reflect.Value) {
+		if visit(childPath, childValue) {
+			walkValue(childValue, childPath, visit, seen)
+		}
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		if value.Type() == reflect.TypeOf(time.Time{}) {
+			return
+		}
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() && !field.Anonymous {
+				continue
+			}
+			childPath := field.Name
+			if path != "" {
+				childPath = path + "." + field.Name
+			}
+			child(childPath, value.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			child(fmt.Sprintf("%s[%d]", path, i), value.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range sortedMapKeys(value) {
+			child(fmt.Sprintf("%s[%v]", path, key.Interface()), value.MapIndex(key))
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package unify4g
+
+import (
+	"container/list"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RegexpCache is a concurrency-safe cache of compiled regular expressions, keyed by pattern.
+// When it is full, the least recently used expression is evicted. Patterns that fail to compile
+// are not cached.
+//
+// Compiled expressions are themselves safe for concurrent use, so a cached *regexp.Regexp can be
+// shared by all the goroutines using the same pattern.
+type RegexpCache struct {
+	mutex    sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // of *regexpCacheEntry, most recently used first
+}
+
+// regexpCacheEntry is an expression stored in a RegexpCache.
+type regexpCacheEntry struct {
+	pattern string
+	regexp  *regexp.Regexp
+}
+
+// defaultRegexpCacheSize is the capacity of the cache used by the package-level regular expression helpers.
+const defaultRegexpCacheSize = 512
+
+// defaultRegexpCache is the RegexpCache used by `CompileCached` and the helpers taking a pattern.
+var defaultRegexpCache = NewRegexpCache(defaultRegexpCacheSize)
+
+// NewRegexpCache creates a RegexpCache holding at most `capacity` expressions.
+//
+// Parameters:
+//   - `capacity`: The maximum number of cached expressions; values under 1 are treated as 1.
+//
+// Returns:
+//   - A pointer to a new, empty RegexpCache.
+//
+// Example:
+//
+//	cache := NewRegexpCache(128)
+//	re, err := cache.Compile(`^v(\d+)$`)
+func NewRegexpCache(capacity int) *RegexpCache {
+	return &RegexpCache{
+		capacity: max(capacity, 1),
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Compile returns the compiled expression of `pattern`, compiling and caching it on first use.
+//
+// Parameters:
+//   - `pattern`: The regular expression, in the syntax of the regexp package.
+//
+// Returns:
+//   - The compiled expression.
+//   - An error if the pattern is invalid.
+//
+// Example:
+//
+//	re, err := cache.Compile(`\d+`)
+//	if err != nil {
+//		return err
+//	}
+//	re.FindString("abc123") // "123"
+func (cache *RegexpCache) Compile(pattern string) (*regexp.Regexp, error) {
+	cache.mutex.Lock()
+	if element, ok := cache.entries[pattern]; ok {
+		cache.order.MoveToFront(element)
+		cache.mutex.Unlock()
+		return element.Value.(*regexpCacheEntry).regexp, nil
+	}
+	cache.mutex.Unlock()
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, ok := cache.entries[pattern]; ok {
+		cache.order.MoveToFront(element)
+		return element.Value.(*regexpCacheEntry).regexp, nil
+	}
+	cache.entries[pattern] = cache.order.PushFront(&regexpCacheEntry{pattern: pattern, regexp: compiled})
+	for cache.order.Len() > cache.capacity {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*regexpCacheEntry).pattern)
+	}
+	return compiled, nil
+}
+
+// MustCompile is like `Compile` but panics if the pattern is invalid, like `regexp.MustCompile`.
+//
+// Parameters:
+//   - `pattern`: The regular expression.
+//
+// Returns:
+//   - The compiled expression.
+//
+// Example:
+//
+//	cache.MustCompile(`\s+`).ReplaceAllString("a  b", " ") // "a b"
+func (cache *RegexpCache) MustCompile(pattern string) *regexp.Regexp {
+	compiled, err := cache.Compile(pattern)
+	if err != nil {
+		panic(`regexp: Compile(` + strconv.Quote(pattern) + `): ` + err.Error())
+	}
+	return compiled
+}
+
+// Len returns the number of expressions in the cache.
+func (cache *RegexpCache) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.order.Len()
+}
+
+// Clear removes all the expressions from the cache.
+func (cache *RegexpCache) Clear() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.entries = make(map[string]*list.Element)
+	cache.order.Init()
+}
+
+// CompileCached compiles `pattern` through a package-wide RegexpCache of 512 expressions, so that
+// functions receiving patterns at run time do not recompile them on every call. Patterns known in
+// advance are better compiled once into a package variable, like `RegexpDupSpaces`.
+//
+// Parameters:
+//   - `pattern`: The regular expression.
+//
+// Returns:
+//   - The compiled expression.
+//   - An error if the pattern is invalid.
+//
+// Example:
+//
+//	re, err := CompileCached(`^(\w+)@example\.com$`)
+func CompileCached(pattern string) (*regexp.Regexp, error) {
+	return defaultRegexpCache.Compile(pattern)
+}
+
+// MustCompileCached is like `CompileCached` but panics if the pattern is invalid.
+//
+// Parameters:
+//   - `pattern`: The regular expression.
+//
+// Returns:
+//   - The compiled expression.
+//
+// Example:
+//
+//	MustCompileCached(`\d+`).MatchString("a1") // true
+func MustCompileCached(pattern string) *regexp.Regexp {
+	return defaultRegexpCache.MustCompile(pattern)
+}
+
+// CompileAll compiles a set of patterns through the package-wide cache, reporting every invalid
+// pattern at once.
+//
+// Parameters:
+//   - `patterns`: The regular expressions.
+//
+// Returns:
+//   - The compiled expressions, in the order of `patterns`.
+//   - An error listing the invalid patterns, if any.
+//
+// Example:
+//
+//	rules, err := CompileAll(`^\d{4}-\d{2}-\d{2}$`, `^\d{2}/\d{2}/\d{4}$`)
+func CompileAll(patterns ...string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, len(patterns))
+	var invalid []string
+	for i, pattern := range patterns {
+		re, err := CompileCached(pattern)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("pattern %d: %v", i, err))
+			continue
+		}
+		compiled[i] = re
+	}
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("compile all: %s", strings.Join(invalid, "; "))
+	}
+	return compiled, nil
+}
+
+// MustCompileAll is like `CompileAll` but panics if any pattern is invalid. It is meant for
+// package-level pattern sets initialized at startup.
+//
+// Parameters:
+//   - `patterns`: The regular expressions.
+//
+// Returns:
+//   - The compiled expressions, in the order of `patterns`.
+//
+// Example:
+//
+//	var dateFormats = MustCompileAll(`^\d{4}-\d{2}-\d{2}$`, `^\d{2}/\d{2}/\d{4}$`)
+func MustCompileAll(patterns ...string) []*regexp.Regexp {
+	compiled, err := CompileAll(patterns...)
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
+
+// ReplaceAllFunc replaces the matches of `pattern` in `str` with the result of `replace`, which
+// receives the groups of each match: named groups by name, and all groups by their index as a
+// string, "0" being the whole match. Groups that did not participate in the match are empty.
+// The pattern is compiled through the package-wide cache and must be valid; it panics otherwise.
+//
+// Parameters:
+//   - `str`: The input string.
+//   - `pattern`: The regular expression.
+//   - `replace`: The function returning the replacement of a match from its groups.
+//
+// Returns:
+//   - The string with the matches replaced.
+//
+// Example:
+//
+//	ReplaceAllFunc("2024-05-01", `(?P<y>\d{4})-(?P<m>\d{2})-(?P<d>\d{2})`, func(groups map[string]string) string {
+//		return groups["d"] + "/" + groups["m"] + "/" + groups["y"]
+//	}) // "01/05/2024"
+func ReplaceAllFunc(str, pattern string, replace func(groups map[string]string) string) string {
+	re := MustCompileCached(pattern)
+	var builder strings.Builder
+	last := 0
+	for _, indexes := range re.FindAllStringSubmatchIndex(str, -1) {
+		builder.WriteString(str[last:indexes[0]])
+		builder.WriteString(replace(regexpGroups(re, indexes, str)))
+		last = indexes[1]
+	}
+	builder.WriteString(str[last:])
+	return builder.String()
+}
+
+// ExtractNamed returns the values of the named groups of the first match of `pattern` in `str`.
+// The pattern is compiled through the package-wide cache and must be valid; it panics otherwise.
+//
+// Parameters:
+//   - `str`: The input string.
+//   - `pattern`: The regular expression, with named groups such as `(?P<name>...)`.
+//
+// Returns:
+//   - A map from group names to their values, empty for groups that did not participate,
+//     or nil if the pattern does not match.
+//
+// Example:
+//
+//	ExtractNamed("user=alice id=42", `user=(?P<user>\w+) id=(?P<id>\d+)`)
+//	// map[string]string{"user": "alice", "id": "42"}
+func ExtractNamed(str, pattern string) map[string]string {
+	re := MustCompileCached(pattern)
+	indexes := re.FindStringSubmatchIndex(str)
+	if indexes == nil {
+		return nil
+	}
+	named := make(map[string]string)
+	for i, name := range re.SubexpNames() {
+		if name == "" {
+			continue
+		}
+		named[name] = ""
+		if indexes[2*i] >= 0 {
+			named[name] = str[indexes[2*i]:indexes[2*i+1]]
+		}
+	}
+	return named
+}
+
+// FindAllStringsLimit returns at most `limit` successive matches of `pattern` in `str`, or all of
+// them if `limit` is negative. The pattern is compiled through the package-wide cache and must be
+// valid; it panics otherwise.
+//
+// Parameters:
+//   - `str`: The input string.
+//   - `pattern`: The regular expression.
+//   - `limit`: The maximum number of matches; negative for no limit.
+//
+// Returns:
+//   - The matches, or nil if there are none.
+//
+// Example:
+//
+//	FindAllStringsLimit("a1 b22 c333", `\d+`, 2) // []string{"1", "22"}
+func FindAllStringsLimit(str, pattern string, limit int) []string {
+	return MustCompileCached(pattern).FindAllString(str, limit)
+}
+
+// regexpGroups maps the groups of `re` found at `indexes` in `str` by name and by index.
+func regexpGroups(re *regexp.Regexp, indexes []int, str string) map[string]string {
+	groups := make(map[string]string, len(indexes))
+	for i, name := range re.SubexpNames() {
+		value := ""
+		if indexes[2*i] >= 0 {
+			value = str[indexes[2*i]:indexes[2*i+1]]
+		}
+		groups[strconv.Itoa(i)] = value
+		if name != "" {
+			groups[name] = value
+		}
+	}
+	return groups
+}
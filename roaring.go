@@ -0,0 +1,647 @@
+package unify4g
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// roaringArrayMax is the cardinality above which a roaring container switches
+// from a sorted array to a bitmap representation.
+const roaringArrayMax = 4096
+
+// roaringContainer stores the low 16 bits of the values sharing the same high 16 bits.
+// Sparse containers keep a sorted `array`; dense containers keep a 65536-bit `bitmap`.
+type roaringContainer struct {
+	array  []uint16
+	bitmap []uint64
+	card   int
+}
+
+// RoaringBitmap is a compressed bitmap of `uint32` values, following the roaring bitmap layout.
+// Values are partitioned by their high 16 bits into containers; each container is stored
+// either as a sorted array (when sparse) or as a plain bitmap (when dense). This keeps memory
+// usage proportional to the data for sparse sets spread over large ranges, where a `BitSet`
+// would have to allocate every intermediate word.
+type RoaringBitmap struct {
+	keys       []uint16
+	containers []*roaringContainer
+}
+
+// NewRoaringBitmap is a constructor function that creates a new `RoaringBitmap` and optionally
+// adds any provided values.
+//
+// Parameters:
+//   - `values`: A variadic list of values to add initially (optional).
+//
+// Returns:
+//   - A pointer to the newly created `RoaringBitmap`.
+//
+// Example usage:
+//
+//	rb := NewRoaringBitmap(1, 100000, 4000000000)
+func NewRoaringBitmap(values ...uint32) *RoaringBitmap {
+	rb := &RoaringBitmap{}
+	for _, v := range values {
+		rb.Add(v)
+	}
+	return rb
+}
+
+// NewRoaringBitmapFromBitSet creates a new `RoaringBitmap` containing every bit set in `bitset`.
+// Bits beyond the `uint32` range are ignored.
+//
+// Parameters:
+//   - `bitset`: The source `BitSet`.
+//
+// Returns:
+//   - A pointer to a new `RoaringBitmap` with the same members.
+//
+// Example usage:
+//
+//	rb := NewRoaringBitmapFromBitSet(NewBitSet(1, 2, 3))
+func NewRoaringBitmapFromBitSet(bitset *BitSet) *RoaringBitmap {
+	rb := NewRoaringBitmap()
+	for i, ok := bitset.NextSet(0); ok && uint64(i) <= 1<<32-1; i, ok = bitset.NextSet(i + 1) {
+		rb.Add(uint32(i))
+	}
+	return rb
+}
+
+// NewRoaringBitmapFromHashSet creates a new `RoaringBitmap` containing every element of `hash`.
+//
+// Parameters:
+//   - `hash`: The source `HashSet[uint32]`.
+//
+// Returns:
+//   - A pointer to a new `RoaringBitmap` with the same members.
+//
+// Example usage:
+//
+//	rb := NewRoaringBitmapFromHashSet(NewHashSet[uint32](1, 2))
+func NewRoaringBitmapFromHashSet(hash *HashSet[uint32]) *RoaringBitmap {
+	rb := NewRoaringBitmap()
+	if hash == nil {
+		return rb
+	}
+	for item := range hash.items {
+		rb.Add(item)
+	}
+	return rb
+}
+
+// Add inserts `value` into the bitmap. If the value already exists, no action is taken.
+//
+// Parameters:
+//   - `value`: The value to add.
+//
+// Example usage:
+//
+//	rb.Add(42)
+func (rb *RoaringBitmap) Add(value uint32) {
+	rb.getOrCreate(uint16(value >> 16)).add(uint16(value))
+}
+
+// AddRange inserts every value in the half-open range [from, to).
+//
+// Parameters:
+//   - `from`: The first value to add (inclusive).
+//   - `to`: The value at which to stop (exclusive). Values above 1<<32 are clamped.
+//
+// Example usage:
+//
+//	rb.AddRange(0, 1_000_000) // Adds 0 through 999999.
+func (rb *RoaringBitmap) AddRange(from, to uint64) {
+	if to > 1<<32 {
+		to = 1 << 32
+	}
+	for from < to {
+		key := uint16(from >> 16)
+		end := min((from|0xFFFF)+1, to)
+		rb.getOrCreate(key).addRange(int(from&0xFFFF), int(end-(from&^0xFFFF)))
+		from = end
+	}
+}
+
+// Remove deletes `value` from the bitmap. If the value does not exist, no action is taken.
+//
+// Parameters:
+//   - `value`: The value to remove.
+//
+// Example usage:
+//
+//	rb.Remove(42)
+func (rb *RoaringBitmap) Remove(value uint32) {
+	idx, ok := rb.find(uint16(value >> 16))
+	if !ok {
+		return
+	}
+	c := rb.containers[idx]
+	c.remove(uint16(value))
+	if c.card == 0 {
+		rb.keys = append(rb.keys[:idx], rb.keys[idx+1:]...)
+		rb.containers = append(rb.containers[:idx], rb.containers[idx+1:]...)
+	}
+}
+
+// Contains checks whether `value` exists in the bitmap.
+//
+// Parameters:
+//   - `value`: The value to look up.
+//
+// Returns:
+//   - `true` if the value is present, `false` otherwise.
+//
+// Example usage:
+//
+//	exists := rb.Contains(42)
+func (rb *RoaringBitmap) Contains(value uint32) bool {
+	idx, ok := rb.find(uint16(value >> 16))
+	return ok && rb.containers[idx].contains(uint16(value))
+}
+
+// Cardinality returns the number of values stored in the bitmap.
+//
+// Returns:
+//   - The number of values in the bitmap.
+//
+// Example usage:
+//
+//	count := rb.Cardinality()
+func (rb *RoaringBitmap) Cardinality() int {
+	count := 0
+	for _, c := range rb.containers {
+		count += c.card
+	}
+	return count
+}
+
+// IsEmpty checks whether the bitmap contains no values.
+//
+// Returns:
+//   - `true` if the bitmap is empty, `false` otherwise.
+//
+// Example usage:
+//
+//	isEmpty := rb.IsEmpty()
+func (rb *RoaringBitmap) IsEmpty() bool {
+	return len(rb.containers) == 0
+}
+
+// Iterate calls `fn` for every value in ascending order until `fn` returns `false`.
+//
+// Parameters:
+//   - `fn`: The callback invoked with each value. Returning `false` stops the iteration.
+//
+// Example usage:
+//
+//	rb.Iterate(func(v uint32) bool {
+//		fmt.Println(v)
+//		return true
+//	})
+func (rb *RoaringBitmap) Iterate(fn func(value uint32) bool) {
+	for i, c := range rb.containers {
+		high := uint32(rb.keys[i]) << 16
+		if c.bitmap == nil {
+			for _, low := range c.array {
+				if !fn(high | uint32(low)) {
+					return
+				}
+			}
+			continue
+		}
+		for wi, w := range c.bitmap {
+			for w != 0 {
+				t := bits.TrailingZeros64(w)
+				if !fn(high | uint32(wi*wordSize+t)) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// Slice returns every value in the bitmap in ascending order.
+//
+// Returns:
+//   - A sorted slice of all values.
+//
+// Example usage:
+//
+//	values := rb.Slice()
+func (rb *RoaringBitmap) Slice() []uint32 {
+	result := make([]uint32, 0, rb.Cardinality())
+	rb.Iterate(func(v uint32) bool {
+		result = append(result, v)
+		return true
+	})
+	return result
+}
+
+// And returns a new `RoaringBitmap` holding the values present in both bitmaps.
+//
+// Parameters:
+//   - `another`: The other bitmap to intersect with.
+//
+// Returns:
+//   - A new bitmap with the intersection.
+//
+// Example usage:
+//
+//	result := a.And(b)
+func (rb *RoaringBitmap) And(another *RoaringBitmap) *RoaringBitmap {
+	return rb.combine(another, func(a, b uint64) uint64 { return a & b }, false, false)
+}
+
+// Or returns a new `RoaringBitmap` holding the values present in either bitmap.
+//
+// Parameters:
+//   - `another`: The other bitmap to combine with.
+//
+// Returns:
+//   - A new bitmap with the union.
+//
+// Example usage:
+//
+//	result := a.Or(b)
+func (rb *RoaringBitmap) Or(another *RoaringBitmap) *RoaringBitmap {
+	return rb.combine(another, func(a, b uint64) uint64 { return a | b }, true, true)
+}
+
+// Xor returns a new `RoaringBitmap` holding the values present in exactly one of the bitmaps.
+//
+// Parameters:
+//   - `another`: The other bitmap to compare with.
+//
+// Returns:
+//   - A new bitmap with the symmetric difference.
+//
+// Example usage:
+//
+//	result := a.Xor(b)
+func (rb *RoaringBitmap) Xor(another *RoaringBitmap) *RoaringBitmap {
+	return rb.combine(another, func(a, b uint64) uint64 { return a ^ b }, true, true)
+}
+
+// AndNot returns a new `RoaringBitmap` holding the values present in the current bitmap but not in `another`.
+//
+// Parameters:
+//   - `another`: The bitmap whose values are removed from the result.
+//
+// Returns:
+//   - A new bitmap with the difference.
+//
+// Example usage:
+//
+//	result := a.AndNot(b)
+func (rb *RoaringBitmap) AndNot(another *RoaringBitmap) *RoaringBitmap {
+	return rb.combine(another, func(a, b uint64) uint64 { return a &^ b }, true, false)
+}
+
+// BitSet converts the bitmap into a dense `BitSet`.
+// Beware that the resulting set allocates one bit per value up to the maximum value stored.
+//
+// Returns:
+//   - A new `BitSet` with the same members.
+//
+// Example usage:
+//
+//	bitset := rb.BitSet()
+func (rb *RoaringBitmap) BitSet() *BitSet {
+	bitset := NewBitSet()
+	rb.Iterate(func(v uint32) bool {
+		bitset.Set(int(v))
+		return true
+	})
+	return bitset
+}
+
+// HashSet converts the bitmap into a `HashSet[uint32]`.
+//
+// Returns:
+//   - A new `HashSet[uint32]` with the same members.
+//
+// Example usage:
+//
+//	hash := rb.HashSet()
+func (rb *RoaringBitmap) HashSet() *HashSet[uint32] {
+	return NewHashSet(rb.Slice()...)
+}
+
+// MarshalBinary encodes the bitmap into a compact binary form. It implements `encoding.BinaryMarshaler`.
+//
+// The layout is a little-endian container count followed, for each container, by its key,
+// its cardinality and either the sorted 16-bit values (sparse) or the 1024 bitmap words (dense).
+//
+// Returns:
+//   - The binary encoding of the bitmap.
+//   - A nil error; the signature satisfies `encoding.BinaryMarshaler`.
+//
+// Example usage:
+//
+//	data, _ := rb.MarshalBinary()
+func (rb *RoaringBitmap) MarshalBinary() ([]byte, error) {
+	data := binary.LittleEndian.AppendUint32(nil, uint32(len(rb.keys)))
+	for i, c := range rb.containers {
+		data = binary.LittleEndian.AppendUint16(data, rb.keys[i])
+		data = binary.LittleEndian.AppendUint32(data, uint32(c.card))
+		if c.bitmap == nil {
+			for _, v := range c.array {
+				data = binary.LittleEndian.AppendUint16(data, v)
+			}
+			continue
+		}
+		for _, w := range c.bitmap {
+			data = binary.LittleEndian.AppendUint64(data, w)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes data produced by `MarshalBinary`, replacing the current contents.
+// It implements `encoding.BinaryUnmarshaler`.
+//
+// Parameters:
+//   - `data`: The binary encoding to decode.
+//
+// Returns:
+//   - An error if `data` is truncated or malformed, e.g. with unsorted keys or values, or a
+//     cardinality that does not match the container.
+//
+// Example usage:
+//
+//	err := rb.UnmarshalBinary(data)
+func (rb *RoaringBitmap) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return fmt.Errorf("invalid roaring bitmap encoding length:[%d]", len(data))
+	}
+	n := int(binary.LittleEndian.Uint32(data))
+	data = data[4:]
+	if n > len(data)/6 {
+		return fmt.Errorf("invalid roaring bitmap container count:[%d]", n)
+	}
+	keys := make([]uint16, 0, n)
+	containers := make([]*roaringContainer, 0, n)
+	for i := 0; i < n; i++ {
+		if len(data) < 6 {
+			return fmt.Errorf("truncated roaring bitmap container:[%d]", i)
+		}
+		key := binary.LittleEndian.Uint16(data)
+		card := int(binary.LittleEndian.Uint32(data[2:]))
+		data = data[6:]
+		if i > 0 && key <= keys[i-1] {
+			return fmt.Errorf("unsorted roaring bitmap container key:[%d]", key)
+		}
+		if card == 0 || card > 1<<16 {
+			return fmt.Errorf("invalid roaring bitmap container cardinality:[%d]", card)
+		}
+		c := &roaringContainer{card: card}
+		if card <= roaringArrayMax {
+			if len(data) < card*2 {
+				return fmt.Errorf("truncated roaring bitmap container:[%d]", i)
+			}
+			c.array = make([]uint16, card)
+			for j := range c.array {
+				c.array[j] = binary.LittleEndian.Uint16(data[j*2:])
+				if j > 0 && c.array[j] <= c.array[j-1] {
+					return fmt.Errorf("unsorted roaring bitmap container:[%d]", i)
+				}
+			}
+			data = data[card*2:]
+		} else {
+			if len(data) < 1024*8 {
+				return fmt.Errorf("truncated roaring bitmap container:[%d]", i)
+			}
+			c.bitmap = make([]uint64, 1024)
+			count := 0
+			for j := range c.bitmap {
+				c.bitmap[j] = binary.LittleEndian.Uint64(data[j*8:])
+				count += bits.OnesCount64(c.bitmap[j])
+			}
+			if count != card {
+				return fmt.Errorf("invalid roaring bitmap container cardinality:[%d]", card)
+			}
+			data = data[1024*8:]
+		}
+		keys = append(keys, key)
+		containers = append(containers, c)
+	}
+	rb.keys, rb.containers = keys, containers
+	return nil
+}
+
+// find locates the container for `key`, returning its position and whether it exists.
+func (rb *RoaringBitmap) find(key uint16) (int, bool) {
+	idx := sort.Search(len(rb.keys), func(i int) bool { return rb.keys[i] >= key })
+	return idx, idx < len(rb.keys) && rb.keys[idx] == key
+}
+
+// getOrCreate returns the container for `key`, inserting an empty one if necessary.
+func (rb *RoaringBitmap) getOrCreate(key uint16) *roaringContainer {
+	idx, ok := rb.find(key)
+	if ok {
+		return rb.containers[idx]
+	}
+	c := &roaringContainer{}
+	rb.keys = append(rb.keys, 0)
+	rb.containers = append(rb.containers, nil)
+	copy(rb.keys[idx+1:], rb.keys[idx:])
+	copy(rb.containers[idx+1:], rb.containers[idx:])
+	rb.keys[idx] = key
+	rb.containers[idx] = c
+	return c
+}
+
+// combine merges two bitmaps container by container using the word-level operation `op`.
+// `keepLeft` and `keepRight` indicate whether containers present on only one side survive.
+func (rb *RoaringBitmap) combine(another *RoaringBitmap, op func(a, b uint64) uint64, keepLeft, keepRight bool) *RoaringBitmap {
+	result := NewRoaringBitmap()
+	i, j := 0, 0
+	for i < len(rb.keys) || j < len(another.keys) {
+		switch {
+		case j >= len(another.keys) || (i < len(rb.keys) && rb.keys[i] < another.keys[j]):
+			if keepLeft {
+				result.keys = append(result.keys, rb.keys[i])
+				result.containers = append(result.containers, rb.containers[i].clone())
+			}
+			i++
+		case i >= len(rb.keys) || another.keys[j] < rb.keys[i]:
+			if keepRight {
+				result.keys = append(result.keys, another.keys[j])
+				result.containers = append(result.containers, another.containers[j].clone())
+			}
+			j++
+		default:
+			c := combineContainers(rb.containers[i], another.containers[j], op)
+			if c.card > 0 {
+				result.keys = append(result.keys, rb.keys[i])
+				result.containers = append(result.containers, c)
+			}
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// combineContainers applies `op` to two containers sharing the same key. Two arrays are merged
+// value by value, so the result only turns into a bitmap when it exceeds `roaringArrayMax` values;
+// otherwise both sides are expanded to bitmaps and combined word by word.
+func combineContainers(left, right *roaringContainer, op func(a, b uint64) uint64) *roaringContainer {
+	if left.bitmap == nil && right.bitmap == nil {
+		return combineArrays(left.array, right.array, op)
+	}
+	a, b := left.words(), right.words()
+	c := &roaringContainer{bitmap: make([]uint64, 1024)}
+	for k := range c.bitmap {
+		c.bitmap[k] = op(a[k], b[k])
+		c.card += bits.OnesCount64(c.bitmap[k])
+	}
+	c.normalize()
+	return c
+}
+
+// combineArrays merges the sorted arrays `a` and `b`, keeping each value for which `op` sets the
+// low bit given its membership in `a` and `b` (1 when present, 0 when absent).
+func combineArrays(a, b []uint16, op func(a, b uint64) uint64) *roaringContainer {
+	c := &roaringContainer{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		var v uint16
+		var inA, inB uint64
+		switch {
+		case j >= len(b) || (i < len(a) && a[i] < b[j]):
+			v, inA = a[i], 1
+			i++
+		case i >= len(a) || b[j] < a[i]:
+			v, inB = b[j], 1
+			j++
+		default:
+			v, inA, inB = a[i], 1, 1
+			i++
+			j++
+		}
+		if op(inA, inB)&1 != 0 {
+			c.array = append(c.array, v)
+		}
+	}
+	c.card = len(c.array)
+	c.normalize()
+	return c
+}
+
+// add inserts the low 16 bits `v` into the container.
+func (c *roaringContainer) add(v uint16) {
+	if c.bitmap != nil {
+		w := &c.bitmap[v/wordSize]
+		if *w&(1<<(v%wordSize)) == 0 {
+			*w |= 1 << (v % wordSize)
+			c.card++
+		}
+		return
+	}
+	idx := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= v })
+	if idx < len(c.array) && c.array[idx] == v {
+		return
+	}
+	c.array = append(c.array, 0)
+	copy(c.array[idx+1:], c.array[idx:])
+	c.array[idx] = v
+	c.card++
+	c.normalize()
+}
+
+// addRange sets the low values in the half-open range [from, to), where to <= 65536.
+func (c *roaringContainer) addRange(from, to int) {
+	if c.bitmap == nil && c.card+(to-from) <= roaringArrayMax {
+		for v := from; v < to; v++ {
+			c.add(uint16(v))
+		}
+		return
+	}
+	c.bitmap = c.words()
+	c.array = nil
+	for v := from; v < to; v++ {
+		c.bitmap[v/wordSize] |= 1 << uint(v%wordSize)
+	}
+	c.card = 0
+	for _, w := range c.bitmap {
+		c.card += bits.OnesCount64(w)
+	}
+	c.normalize()
+}
+
+// remove deletes the low 16 bits `v` from the container.
+func (c *roaringContainer) remove(v uint16) {
+	if c.bitmap != nil {
+		w := &c.bitmap[v/wordSize]
+		if *w&(1<<(v%wordSize)) != 0 {
+			*w &^= 1 << (v % wordSize)
+			c.card--
+			c.normalize()
+		}
+		return
+	}
+	idx := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= v })
+	if idx < len(c.array) && c.array[idx] == v {
+		c.array = append(c.array[:idx], c.array[idx+1:]...)
+		c.card--
+	}
+}
+
+// contains reports whether the low 16 bits `v` are present in the container.
+func (c *roaringContainer) contains(v uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[v/wordSize]&(1<<(v%wordSize)) != 0
+	}
+	idx := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= v })
+	return idx < len(c.array) && c.array[idx] == v
+}
+
+// words returns the container contents as a freshly allocated 1024-word bitmap.
+func (c *roaringContainer) words() []uint64 {
+	result := make([]uint64, 1024)
+	if c.bitmap != nil {
+		copy(result, c.bitmap)
+		return result
+	}
+	for _, v := range c.array {
+		result[v/wordSize] |= 1 << (v % wordSize)
+	}
+	return result
+}
+
+// normalize switches the container representation according to its cardinality.
+func (c *roaringContainer) normalize() {
+	switch {
+	case c.bitmap == nil && c.card > roaringArrayMax:
+		c.bitmap = c.words()
+		c.array = nil
+	case c.bitmap != nil && c.card <= roaringArrayMax:
+		array := make([]uint16, 0, c.card)
+		for wi, w := range c.bitmap {
+			for w != 0 {
+				t := bits.TrailingZeros64(w)
+				array = append(array, uint16(wi*wordSize+t))
+				w &= w - 1
+			}
+		}
+		c.array = array
+		c.bitmap = nil
+	}
+}
+
+// clone returns a deep copy of the container.
+func (c *roaringContainer) clone() *roaringContainer {
+	result := &roaringContainer{card: c.card}
+	if c.bitmap != nil {
+		result.bitmap = make([]uint64, len(c.bitmap))
+		copy(result.bitmap, c.bitmap)
+		return result
+	}
+	result.array = make([]uint16, len(c.array))
+	copy(result.array, c.array)
+	return result
+}
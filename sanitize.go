@@ -0,0 +1,322 @@
+package unify4g
+
+import (
+	"html"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// windowsReservedNames lists the device names that Windows does not allow as file names, with or without extension.
+var windowsReservedNames = map[string]struct{}{
+	"con": {}, "prn": {}, "aux": {}, "nul": {},
+	"com1": {}, "com2": {}, "com3": {}, "com4": {}, "com5": {}, "com6": {}, "com7": {}, "com8": {}, "com9": {},
+	"lpt1": {}, "lpt2": {}, "lpt3": {}, "lpt4": {}, "lpt5": {}, "lpt6": {}, "lpt7": {}, "lpt8": {}, "lpt9": {},
+}
+
+// htmlCharacterReference matches a named or numeric HTML character reference, such as "&amp;" or "&#39;".
+var htmlCharacterReference = regexp.MustCompile(`^&(?:[a-zA-Z][a-zA-Z0-9]*|#[0-9]+|#[xX][0-9a-fA-F]+);`)
+
+// maxFilenameLength is the length in bytes to which `SanitizeFilename` shortens names, the limit of
+// most file systems.
+const maxFilenameLength = 255
+
+// EscapeHTML escapes the characters of `str` that are special in HTML text and attribute values:
+// <, >, &, ' and ".
+//
+// Parameters:
+//   - `str`: The text to escape.
+//
+// Returns:
+//   - The escaped text, safe to insert in an HTML document.
+//
+// Example:
+//
+//	EscapeHTML(`<a href="x">Tom & Jerry</a>`) // "&lt;a href=&#34;x&#34;&gt;Tom &amp; Jerry&lt;/a&gt;"
+func EscapeHTML(str string) string {
+	return html.EscapeString(str)
+}
+
+// StripTags removes the HTML tags and comments of `str`, keeping the tags listed in `allowedTags`.
+//
+// Kept tags are rewritten without their attributes, so that event handlers such as "onclick" and
+// "javascript:" links cannot get through. The "script" and "style" elements are always removed
+// together with their content, and an unterminated tag removes the rest of the input. In the text
+// between tags, '<', '>' and '&' are escaped, except for '&' starting a character reference such
+// as "&amp;", so that removing a tag cannot join its surroundings into new markup
+// ("<<b>script>" gives "&lt;script&gt;").
+//
+// Parameters:
+//   - `str`: The HTML to clean.
+//   - `allowedTags`: The names of the tags to keep, case-insensitively, e.g. "b", "i" or "br".
+//
+// Returns:
+//   - The text without the tags that are not allowed.
+//
+// Example:
+//
+//	StripTags(`<p onclick="steal()">Hello <b>world</b><script>alert(1)</script></p>`, "b")
+//	// "Hello <b>world</b>"
+func StripTags(str string, allowedTags ...string) string {
+	allowed := make(map[string]struct{}, len(allowedTags))
+	for _, tag := range allowedTags {
+		allowed[strings.ToLower(tag)] = struct{}{}
+	}
+	var builder strings.Builder
+	skipping := ""
+	for i := 0; i < len(str); {
+		if !isTagStart(str, i) {
+			if skipping == "" {
+				writeTagText(&builder, str, i)
+			}
+			i++
+			continue
+		}
+		end := tagEnd(str, i)
+		if !strings.HasSuffix(str[i:end], ">") {
+			break
+		}
+		name, closing := tagName(str[i:end])
+		_, keep := allowed[name]
+		switch {
+		case skipping != "":
+			if closing && name == skipping {
+				skipping = ""
+			}
+		case name == "script" || name == "style":
+			if !closing && !strings.HasSuffix(str[i:end], "/>") {
+				skipping = name
+			}
+		case keep && closing:
+			builder.WriteString("</" + name + ">")
+		case keep:
+			builder.WriteString("<" + name + ">")
+		}
+		i = end
+	}
+	return builder.String()
+}
+
+// QuoteShell quotes `str` as a single argument for POSIX shells, by enclosing it in single quotes,
+// inside which no character is special, and closing, escaping and reopening the quotes around its
+// own single quotes. The argument is always quoted, even when it would be safe without quotes.
+// NUL bytes, which cannot be passed to commands, are removed.
+//
+// Parameters:
+//   - `str`: The argument to quote.
+//
+// Returns:
+//   - The quoted argument.
+//
+// Example:
+//
+//	QuoteShell("it's; rm -rf /") // `'it'\''s; rm -rf /'`
+func QuoteShell(str string) string {
+	str = strings.ReplaceAll(str, "\x00", "")
+	return "'" + strings.ReplaceAll(str, "'", `'\''`) + "'"
+}
+
+// QuoteShellArgs quotes each of `args` with `QuoteShell` and joins them with spaces, to build a
+// command line for `sh -c`.
+//
+// Parameters:
+//   - `args`: The command and its arguments.
+//
+// Returns:
+//   - The command line.
+//
+// Example:
+//
+//	QuoteShellArgs("git", "commit", "-m", "fix: it's done") // `'git' 'commit' '-m' 'fix: it'\''s done'`
+func QuoteShellArgs(args ...string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = QuoteShell(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// QuoteSQLIdentifier quotes a table, column or schema name for SQL using standard double quotes,
+// as understood by PostgreSQL, SQLite, Oracle and SQL Server, and by MySQL in ANSI_QUOTES mode.
+// Double quotes inside a name are doubled and NUL bytes are removed. Several parts are quoted
+// separately and joined with '.', to form a qualified name.
+//
+// Identifiers cannot be passed as query parameters, so names coming from users must be quoted,
+// and should also be checked against the expected names when possible.
+//
+// Parameters:
+//   - `parts`: The identifier, or the parts of a qualified identifier.
+//
+// Returns:
+//   - The quoted identifier.
+//
+// Example:
+//
+//	QuoteSQLIdentifier("users")              // `"users"`
+//	QuoteSQLIdentifier("public", `my"table`) // `"public"."my""table"`
+func QuoteSQLIdentifier(parts ...string) string {
+	quoted := make([]string, len(parts))
+	for i, part := range parts {
+		part = strings.ReplaceAll(part, "\x00", "")
+		quoted[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+	}
+	return strings.Join(quoted, ".")
+}
+
+// SanitizeFilename turns `name` into a file name that is safe to create on Linux, macOS and Windows.
+//
+// Path separators and the characters forbidden on Windows (<>:"|?*) are replaced with '_', so that
+// the name cannot traverse directories; control and bidirectional formatting characters are
+// removed; leading spaces and trailing dots and spaces are trimmed; reserved Windows device names
+// such as "CON" or "lpt1.txt" are prefixed with '_'; and the name is shortened to 255 bytes,
+// keeping its extension. A name that ends up empty, "." or ".." becomes "_".
+//
+// Parameters:
+//   - `name`: The file name to sanitize, typically provided by a user.
+//
+// Returns:
+//   - The sanitized file name.
+//
+// Example:
+//
+//	SanitizeFilename("../../etc/passwd") // ".._.._etc_passwd"
+//	SanitizeFilename("CON.txt")          // "_CON.txt"
+//	SanitizeFilename("report: Q1?.pdf")  // "report_ Q1_.pdf"
+func SanitizeFilename(name string) string {
+	var builder strings.Builder
+	for _, r := range StripControlChars(name) {
+		if r == '/' || r == '\\' || unicode.IsControl(r) || strings.ContainsRune(`<>:"|?*`, r) {
+			r = '_'
+		}
+		builder.WriteRune(r)
+	}
+	result := strings.TrimRight(strings.TrimLeft(builder.String(), " "), ". ")
+	if result == "" {
+		return "_"
+	}
+	base, _, _ := strings.Cut(strings.ToLower(result), ".")
+	if _, ok := windowsReservedNames[strings.TrimRight(base, " ")]; ok {
+		result = "_" + result
+	}
+	if len(result) > maxFilenameLength {
+		extension := filepath.Ext(result)
+		if len(extension) > maxFilenameLength/2 {
+			extension = ""
+		}
+		stem := result[:maxFilenameLength-len(extension)]
+		for !utf8.ValidString(stem) {
+			stem = stem[:len(stem)-1]
+		}
+		result = stem + extension
+	}
+	return result
+}
+
+// StripControlChars removes the control characters of `str`, except tabs and line breaks, and the
+// Unicode bidirectional formatting characters, which can make text display differently from its
+// actual content ("Trojan Source", CVE-2021-42574).
+//
+// Parameters:
+//   - `str`: The text to clean.
+//
+// Returns:
+//   - The text without control and bidirectional formatting characters.
+//
+// Example:
+//
+//	StripControlChars("invoice\u202Efdp.exe\x00") // "invoicefdp.exe"
+func StripControlChars(str string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return r
+		}
+		if unicode.IsControl(r) || isBidiControl(r) {
+			return -1
+		}
+		return r
+	}, str)
+}
+
+// ContainsBidiControl reports whether `str` contains Unicode bidirectional formatting characters,
+// which can be used to disguise source code or file names ("Trojan Source", CVE-2021-42574).
+//
+// Parameters:
+//   - `str`: The text to check.
+//
+// Returns:
+//   - `true` if `str` contains a bidirectional formatting character, `false` otherwise.
+//
+// Example:
+//
+//	ContainsBidiControl("if isAdmin {\u202E } \u2066") // true
+func ContainsBidiControl(str string) bool {
+	return strings.IndexFunc(str, isBidiControl) >= 0
+}
+
+// isBidiControl reports whether `r` is a bidirectional formatting character: an embedding, override
+// or isolate, or an implicit directional mark.
+func isBidiControl(r rune) bool {
+	return r == '\u061C' || r == '\u200E' || r == '\u200F' || (r >= '\u202A' && r <= '\u202E') || (r >= '\u2066' && r <= '\u2069')
+}
+
+// isTagStart reports whether the '<' at `str[i]`, if any, starts an HTML tag, a comment or a declaration.
+func isTagStart(str string, i int) bool {
+	if str[i] != '<' || i+1 >= len(str) {
+		return false
+	}
+	next := str[i+1]
+	return next == '/' || next == '!' || next == '?' || (next >= 'a' && next <= 'z') || (next >= 'A' && next <= 'Z')
+}
+
+// tagEnd returns the index following the end of the tag or comment starting at `str[start]`, ignoring
+// '>' inside quoted attribute values, or the length of `str` if it is not terminated.
+func tagEnd(str string, start int) int {
+	if strings.HasPrefix(str[start:], "<!--") {
+		if end := strings.Index(str[start+4:], "-->"); end >= 0 {
+			return start + 4 + end + 3
+		}
+		return len(str)
+	}
+	var quote byte
+	for i := start + 1; i < len(str); i++ {
+		switch {
+		case quote != 0:
+			if str[i] == quote {
+				quote = 0
+			}
+		case str[i] == '"' || str[i] == '\'':
+			quote = str[i]
+		case str[i] == '>':
+			return i + 1
+		}
+	}
+	return len(str)
+}
+
+// writeTagText writes the text character `str[i]` kept by `StripTags`, escaping it if it could form markup.
+func writeTagText(builder *strings.Builder, str string, i int) {
+	switch {
+	case str[i] == '<':
+		builder.WriteString("&lt;")
+	case str[i] == '>':
+		builder.WriteString("&gt;")
+	case str[i] == '&' && !htmlCharacterReference.MatchString(str[i:]):
+		builder.WriteString("&amp;")
+	default:
+		builder.WriteByte(str[i])
+	}
+}
+
+// tagName returns the lowercase name of `tag`, empty for comments and declarations, and whether it is a closing tag.
+func tagName(tag string) (string, bool) {
+	name := strings.TrimPrefix(tag, "<")
+	closing := strings.HasPrefix(name, "/")
+	name = strings.TrimPrefix(name, "/")
+	end := strings.IndexFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' })
+	if end >= 0 {
+		name = name[:end]
+	}
+	return strings.ToLower(name), closing
+}
@@ -0,0 +1,549 @@
+package unify4g
+
+import "iter"
+
+// SeqFromSlice returns a lazy sequence yielding the elements of `slice` in order.
+//
+// Parameters:
+//   - `slice`: The slice to iterate over.
+//
+// Returns:
+//   - An `iter.Seq[T]` over the elements of `slice`.
+//
+// Example:
+//
+//	for v := range SeqFromSlice([]int{1, 2, 3}) {
+//		fmt.Println(v)
+//	}
+func SeqFromSlice[T any](slice []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range slice {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SeqFromMap returns a lazy sequence yielding the key-value pairs of `m`.
+// As with any Go map iteration, the order is not specified.
+//
+// Parameters:
+//   - `m`: The map to iterate over.
+//
+// Returns:
+//   - An `iter.Seq2[K, V]` over the entries of `m`.
+//
+// Example:
+//
+//	for k, v := range SeqFromMap(map[string]int{"a": 1}) {
+//		fmt.Println(k, v)
+//	}
+func SeqFromMap[K comparable, V any](m map[K]V) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range m {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// SeqFromChan returns a lazy sequence yielding the values received from `ch` until it is closed.
+// Stopping the iteration early leaves the channel untouched, so remaining values can still be received.
+//
+// Parameters:
+//   - `ch`: The channel to receive from.
+//
+// Returns:
+//   - An `iter.Seq[T]` over the received values.
+//
+// Example:
+//
+//	for v := range SeqFromChan(results) {
+//		fmt.Println(v)
+//	}
+func SeqFromChan[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SeqValues drops the keys of a two-valued sequence and yields only its values.
+//
+// Parameters:
+//   - `seq`: The source `iter.Seq2[K, V]`.
+//
+// Returns:
+//   - An `iter.Seq[V]` over the values of `seq`.
+//
+// Example:
+//
+//	values := SeqCollect(SeqValues(SeqFromMap(m)))
+func SeqValues[K, V any](seq iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SeqKeys drops the values of a two-valued sequence and yields only its keys.
+//
+// Parameters:
+//   - `seq`: The source `iter.Seq2[K, V]`.
+//
+// Returns:
+//   - An `iter.Seq[K]` over the keys of `seq`.
+//
+// Example:
+//
+//	keys := SeqCollect(SeqKeys(SeqFromMap(m)))
+func SeqKeys[K, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// SeqFilter returns a lazy sequence yielding only the elements of `seq` that satisfy `condition`.
+//
+// Parameters:
+//   - `seq`: The source sequence.
+//   - `condition`: The predicate deciding whether an element is kept.
+//
+// Returns:
+//   - An `iter.Seq[T]` over the matching elements.
+//
+// Example:
+//
+//	evens := SeqFilter(SeqFromSlice(numbers), func(n int) bool { return n%2 == 0 })
+func SeqFilter[T any](seq iter.Seq[T], condition func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if condition(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SeqMap returns a lazy sequence yielding the result of applying `f` to each element of `seq`.
+//
+// Parameters:
+//   - `seq`: The source sequence.
+//   - `f`: The transformation applied to each element.
+//
+// Returns:
+//   - An `iter.Seq[U]` over the transformed elements.
+//
+// Example:
+//
+//	squares := SeqMap(SeqFromSlice(numbers), func(n int) int { return n * n })
+func SeqMap[T, U any](seq iter.Seq[T], f func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// SeqFlatMap returns a lazy sequence that applies `f` to each element of `seq` and yields
+// every element of the resulting sub-sequences in order.
+//
+// Parameters:
+//   - `seq`: The source sequence.
+//   - `f`: A function returning a sub-sequence for each element.
+//
+// Returns:
+//   - An `iter.Seq[U]` over the concatenated sub-sequences.
+//
+// Example:
+//
+//	words := SeqFlatMap(SeqFromSlice(lines), func(line string) iter.Seq[string] {
+//		return SeqFromSlice(strings.Fields(line))
+//	})
+func SeqFlatMap[T, U any](seq iter.Seq[T], f func(T) iter.Seq[U]) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			for u := range f(v) {
+				if !yield(u) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SeqTake returns a lazy sequence yielding at most the first `n` elements of `seq`.
+// The source sequence is not advanced beyond the `n`-th element.
+//
+// Parameters:
+//   - `seq`: The source sequence.
+//   - `n`: The maximum number of elements to yield.
+//
+// Returns:
+//   - An `iter.Seq[T]` over the first `n` elements.
+//
+// Example:
+//
+//	firstThree := SeqCollect(SeqTake(SeqFromSlice(numbers), 3))
+func SeqTake[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		count := 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			count++
+			if count >= n {
+				return
+			}
+		}
+	}
+}
+
+// SeqSkip returns a lazy sequence that discards the first `n` elements of `seq` and yields the rest.
+//
+// Parameters:
+//   - `seq`: The source sequence.
+//   - `n`: The number of elements to discard.
+//
+// Returns:
+//   - An `iter.Seq[T]` over the remaining elements.
+//
+// Example:
+//
+//	rest := SeqCollect(SeqSkip(SeqFromSlice(numbers), 2))
+func SeqSkip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		count := 0
+		for v := range seq {
+			if count < n {
+				count++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SeqTakeWhile returns a lazy sequence yielding elements of `seq` as long as `condition` holds,
+// stopping at the first element that does not satisfy it.
+//
+// Parameters:
+//   - `seq`: The source sequence.
+//   - `condition`: The predicate evaluated on each element.
+//
+// Returns:
+//   - An `iter.Seq[T]` over the leading matching elements.
+//
+// Example:
+//
+//	small := SeqCollect(SeqTakeWhile(SeqFromSlice(numbers), func(n int) bool { return n < 10 }))
+func SeqTakeWhile[T any](seq iter.Seq[T], condition func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if !condition(v) || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SeqSkipWhile returns a lazy sequence that discards elements of `seq` while `condition` holds
+// and yields every element from the first one that does not satisfy it.
+//
+// Parameters:
+//   - `seq`: The source sequence.
+//   - `condition`: The predicate evaluated on the leading elements.
+//
+// Returns:
+//   - An `iter.Seq[T]` over the remaining elements.
+//
+// Example:
+//
+//	body := SeqSkipWhile(SeqFromSlice(lines), func(l string) bool { return l != "" })
+func SeqSkipWhile[T any](seq iter.Seq[T], condition func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		skipping := true
+		for v := range seq {
+			if skipping && condition(v) {
+				continue
+			}
+			skipping = false
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SeqChunk returns a lazy sequence yielding consecutive, non-overlapping chunks of `size` elements.
+// The last chunk may contain fewer elements. Each chunk is a newly allocated slice.
+//
+// Parameters:
+//   - `seq`: The source sequence.
+//   - `size`: The number of elements per chunk. If it is less than or equal to 0, nothing is yielded.
+//
+// Returns:
+//   - An `iter.Seq[[]T]` over the chunks.
+//
+// Example:
+//
+//	for batch := range SeqChunk(SeqFromSlice(records), 100) {
+//		store(batch)
+//	}
+func SeqChunk[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if size <= 0 {
+			return
+		}
+		chunk := make([]T, 0, size)
+		for v := range seq {
+			chunk = append(chunk, v)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// SeqWindow returns a lazy sequence yielding every sliding window of `size` consecutive elements,
+// advancing one element at a time. Each window is a newly allocated slice.
+//
+// Parameters:
+//   - `seq`: The source sequence.
+//   - `size`: The number of elements per window. If it is less than or equal to 0, nothing is yielded.
+//
+// Returns:
+//   - An `iter.Seq[[]T]` over the windows. A sequence shorter than `size` yields nothing.
+//
+// Example:
+//
+//	windows := SeqCollect(SeqWindow(SeqFromSlice([]int{1, 2, 3, 4}), 2))
+//	// windows will be [][]int{{1, 2}, {2, 3}, {3, 4}}
+func SeqWindow[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if size <= 0 {
+			return
+		}
+		buffer := make([]T, 0, size)
+		for v := range seq {
+			if len(buffer) == size {
+				buffer = buffer[1:]
+			}
+			buffer = append(buffer, v)
+			if len(buffer) == size {
+				window := make([]T, size)
+				copy(window, buffer)
+				if !yield(window) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SeqZip returns a lazy sequence pairing the elements of `left` and `right` positionally.
+// The sequence ends as soon as either input is exhausted.
+//
+// Parameters:
+//   - `left`: The sequence providing the first element of each pair.
+//   - `right`: The sequence providing the second element of each pair.
+//
+// Returns:
+//   - An `iter.Seq2[A, B]` over the paired elements.
+//
+// Example:
+//
+//	for name, age := range SeqZip(SeqFromSlice(names), SeqFromSlice(ages)) {
+//		fmt.Println(name, age)
+//	}
+func SeqZip[A, B any](left iter.Seq[A], right iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, stop := iter.Pull(right)
+		defer stop()
+		for a := range left {
+			b, ok := next()
+			if !ok || !yield(a, b) {
+				return
+			}
+		}
+	}
+}
+
+// SeqDistinct returns a lazy sequence yielding each distinct element of `seq` once,
+// in order of first occurrence.
+//
+// Parameters:
+//   - `seq`: The source sequence.
+//
+// Returns:
+//   - An `iter.Seq[T]` without duplicates.
+//
+// Example:
+//
+//	unique := SeqCollect(SeqDistinct(SeqFromSlice([]int{1, 2, 1, 3})))
+//	// unique will be []int{1, 2, 3}
+func SeqDistinct[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[T]struct{})
+		for v := range seq {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = itemExists
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SeqEnumerate returns a lazy sequence yielding each element of `seq` along with its zero-based position.
+//
+// Parameters:
+//   - `seq`: The source sequence.
+//
+// Returns:
+//   - An `iter.Seq2[int, T]` over index-element pairs.
+//
+// Example:
+//
+//	for i, v := range SeqEnumerate(SeqFromChan(ch)) {
+//		fmt.Println(i, v)
+//	}
+func SeqEnumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// SeqCollect drains `seq` into a new slice.
+//
+// Parameters:
+//   - `seq`: The sequence to consume.
+//
+// Returns:
+//   - A slice containing every element yielded by `seq`, in order.
+//
+// Example:
+//
+//	result := SeqCollect(SeqFilter(SeqFromSlice(numbers), isEven))
+func SeqCollect[T any](seq iter.Seq[T]) []T {
+	result := make([]T, 0)
+	for v := range seq {
+		result = append(result, v)
+	}
+	return result
+}
+
+// SeqReduce folds the elements of `seq` into a single value using `accumulator`, starting from `initialValue`.
+//
+// Parameters:
+//   - `seq`: The sequence to consume.
+//   - `accumulator`: A function combining the running result with the next element.
+//   - `initialValue`: The starting value of the accumulation.
+//
+// Returns:
+//   - The final accumulated value.
+//
+// Example:
+//
+//	total := SeqReduce(SeqFromSlice(numbers), func(acc, n int) int { return acc + n }, 0)
+func SeqReduce[T, U any](seq iter.Seq[T], accumulator func(U, T) U, initialValue U) U {
+	result := initialValue
+	for v := range seq {
+		result = accumulator(result, v)
+	}
+	return result
+}
+
+// SeqFirst returns the first element of `seq`, consuming nothing beyond it.
+//
+// Parameters:
+//   - `seq`: The sequence to read from.
+//
+// Returns:
+//   - The first element and `true`, or the zero value of `T` and `false` if the sequence is empty.
+//
+// Example:
+//
+//	first, ok := SeqFirst(SeqFilter(SeqFromSlice(numbers), isEven))
+func SeqFirst[T any](seq iter.Seq[T]) (T, bool) {
+	for v := range seq {
+		return v, true
+	}
+	var zero T
+	return zero, false
+}
+
+// SeqCount consumes `seq` and returns the number of elements it yielded.
+//
+// Parameters:
+//   - `seq`: The sequence to consume.
+//
+// Returns:
+//   - The number of elements in the sequence.
+//
+// Example:
+//
+//	n := SeqCount(SeqFilter(SeqFromSlice(numbers), isEven))
+func SeqCount[T any](seq iter.Seq[T]) int {
+	count := 0
+	for range seq {
+		count++
+	}
+	return count
+}
+
+// SeqGroupBy consumes `seq` and groups its elements by the key returned by `getKey`,
+// preserving the encounter order within each group.
+//
+// Parameters:
+//   - `seq`: The sequence to consume.
+//   - `getKey`: A function returning the grouping key of an element.
+//
+// Returns:
+//   - A map from each key to the elements sharing it.
+//
+// Example:
+//
+//	byLength := SeqGroupBy(SeqFromSlice(words), func(w string) int { return len(w) })
+func SeqGroupBy[T any, K comparable](seq iter.Seq[T], getKey func(T) K) map[K][]T {
+	result := make(map[K][]T)
+	for v := range seq {
+		key := getKey(v)
+		result[key] = append(result[key], v)
+	}
+	return result
+}
@@ -0,0 +1,376 @@
+package unify4g
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Asc creates a `SortKey` ordering values by the key returned by `key`, in ascending order.
+//
+// Parameters:
+//   - `key`: Extracts the value to order by.
+//
+// Returns:
+//   - A `SortKey` for use with `SortBy`, `IsSorted` or `TopK`.
+//
+// Example:
+//
+//	sorted := SortBy(users, Asc(func(u User) string { return u.Name }))
+func Asc[T any, K cmp.Ordered](key func(T) K) SortKey[T] {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// Desc creates a `SortKey` ordering values by the key returned by `key`, in descending order.
+//
+// Parameters:
+//   - `key`: Extracts the value to order by.
+//
+// Returns:
+//   - A `SortKey` for use with `SortBy`, `IsSorted` or `TopK`.
+//
+// Example:
+//
+//	sorted := SortBy(users, Desc(func(u User) int { return u.Age }))
+func Desc[T any, K cmp.Ordered](key func(T) K) SortKey[T] {
+	return func(a, b T) int {
+		return cmp.Compare(key(b), key(a))
+	}
+}
+
+// AscBy creates a `SortKey` ordering values by the key returned by `key`, compared with `compare`,
+// in ascending order. It allows custom orderings such as `NaturalCompare` or `CompareIgnoreAccents`.
+//
+// Parameters:
+//   - `key`: Extracts the value to order by.
+//   - `compare`: Compares two keys, returning a negative, zero or positive number.
+//
+// Returns:
+//   - A `SortKey` for use with `SortBy`, `IsSorted` or `TopK`.
+//
+// Example:
+//
+//	sorted := SortBy(files, AscBy(func(f File) string { return f.Name }, NaturalCompare))
+func AscBy[T, K any](key func(T) K, compare func(a, b K) int) SortKey[T] {
+	return func(a, b T) int {
+		return compare(key(a), key(b))
+	}
+}
+
+// DescBy creates a `SortKey` ordering values by the key returned by `key`, compared with `compare`,
+// in descending order.
+//
+// Parameters:
+//   - `key`: Extracts the value to order by.
+//   - `compare`: Compares two keys, returning a negative, zero or positive number.
+//
+// Returns:
+//   - A `SortKey` for use with `SortBy`, `IsSorted` or `TopK`.
+//
+// Example:
+//
+//	sorted := SortBy(files, DescBy(func(f File) string { return f.Name }, NaturalCompare))
+func DescBy[T, K any](key func(T) K, compare func(a, b K) int) SortKey[T] {
+	return func(a, b T) int {
+		return compare(key(b), key(a))
+	}
+}
+
+// SortBy returns a sorted copy of `slice`, ordered by `keys` in priority order: the second key
+// only breaks ties of the first, and so on. The sort is stable, so elements equal on every key
+// keep their original relative order, which makes the result deterministic.
+//
+// Parameters:
+//   - `slice`: The input slice; it is not modified.
+//   - `keys`: The ordering keys, from most to least significant.
+//
+// Returns:
+//   - A new, sorted slice.
+//
+// Example:
+//
+//	rows := SortBy(rows,
+//		Asc(func(r Row) string { return r.Department }),
+//		Desc(func(r Row) float64 { return r.Salary }))
+//	// rows are grouped by department, highest salary first within each department
+func SortBy[T any](slice []T, keys ...SortKey[T]) []T {
+	sorted := slices.Clone(slice)
+	if sorted == nil {
+		sorted = []T{}
+	}
+	slices.SortStableFunc(sorted, compareByKeys(keys))
+	return sorted
+}
+
+// IsSorted reports whether `slice` is ordered according to `keys`.
+//
+// Parameters:
+//   - `slice`: The slice to check.
+//   - `keys`: The ordering keys, from most to least significant.
+//
+// Returns:
+//   - `true` if no element sorts before its predecessor.
+//
+// Example:
+//
+//	ok := IsSorted([]int{1, 2, 2, 3}, Asc(func(n int) int { return n }))
+//	// ok will be true
+func IsSorted[T any](slice []T, keys ...SortKey[T]) bool {
+	return slices.IsSortedFunc(slice, compareByKeys(keys))
+}
+
+// BinarySearchBy searches `slice`, sorted in ascending order of `key`, for an element whose key
+// equals `target`.
+//
+// Parameters:
+//   - `slice`: The sorted slice to search.
+//   - `target`: The key to find.
+//   - `key`: Extracts the key of an element.
+//
+// Returns:
+//   - The position of the first matching element, or the position where `target` would be inserted.
+//   - `true` if a matching element was found.
+//
+// Example:
+//
+//	users := SortBy(users, Asc(func(u User) int { return u.ID }))
+//	index, found := BinarySearchBy(users, 42, func(u User) int { return u.ID })
+func BinarySearchBy[T any, K cmp.Ordered](slice []T, target K, key func(T) K) (int, bool) {
+	return slices.BinarySearchFunc(slice, target, func(item T, target K) int {
+		return cmp.Compare(key(item), target)
+	})
+}
+
+// TopK returns the first `k` elements of `slice` in the order defined by `keys`, as `SortBy` would,
+// without sorting the whole slice. It runs in O(n log k) time and O(k) extra memory.
+//
+// Parameters:
+//   - `slice`: The input slice; it is not modified.
+//   - `k`: The number of elements to return; clamped to the range [0, len(slice)].
+//   - `keys`: The ordering keys, from most to least significant.
+//
+// Returns:
+//   - A new slice with the `k` leading elements, sorted.
+//
+// Example:
+//
+//	slowest := TopK(requests, 10, Desc(func(r Request) time.Duration { return r.Latency }))
+func TopK[T any](slice []T, k int, keys ...SortKey[T]) []T {
+	k = max(0, min(k, len(slice)))
+	if k == 0 {
+		return []T{}
+	}
+	compare := compareByKeys(keys)
+	h := &topKHeap[T]{compare: compare}
+	for i, item := range slice {
+		candidate := topKItem[T]{value: item, index: i}
+		if h.Len() < k {
+			heap.Push(h, candidate)
+		} else if h.less(candidate, h.items[0]) {
+			h.items[0] = candidate
+			heap.Fix(h, 0)
+		}
+	}
+	slices.SortFunc(h.items, func(a, b topKItem[T]) int {
+		return cmp.Or(compare(a.value, b.value), cmp.Compare(a.index, b.index))
+	})
+	return Map(h.items, func(item topKItem[T]) T { return item.value })
+}
+
+// SortMapByValue returns the entries of `m` ordered by ascending value. Entries with equal values
+// are ordered by ascending key, so the result is deterministic.
+//
+// Parameters:
+//   - `m`: The map to sort.
+//
+// Returns:
+//   - A slice of `MapEntry` values.
+//
+// Example:
+//
+//	scores := map[string]int{"bob": 3, "alice": 5, "carol": 3}
+//	entries := SortMapByValue(scores)
+//	// entries will be [{bob 3} {carol 3} {alice 5}]
+func SortMapByValue[K, V cmp.Ordered](m map[K]V) []MapEntry[K, V] {
+	return sortMapEntries(m, func(a, b MapEntry[K, V]) int {
+		return cmp.Or(cmp.Compare(a.Value, b.Value), cmp.Compare(a.Key, b.Key))
+	})
+}
+
+// SortMapByValueDesc returns the entries of `m` ordered by descending value. Entries with equal
+// values are ordered by ascending key, so the result is deterministic.
+//
+// Parameters:
+//   - `m`: The map to sort.
+//
+// Returns:
+//   - A slice of `MapEntry` values.
+//
+// Example:
+//
+//	scores := map[string]int{"bob": 3, "alice": 5, "carol": 3}
+//	entries := SortMapByValueDesc(scores)
+//	// entries will be [{alice 5} {bob 3} {carol 3}]
+func SortMapByValueDesc[K, V cmp.Ordered](m map[K]V) []MapEntry[K, V] {
+	return sortMapEntries(m, func(a, b MapEntry[K, V]) int {
+		return cmp.Or(cmp.Compare(b.Value, a.Value), cmp.Compare(a.Key, b.Key))
+	})
+}
+
+// NaturalCompare compares two strings in natural (alphanumeric) order: runs of digits are compared
+// by their numeric value, so "file2" sorts before "file10", while other characters are compared
+// rune by rune. When two strings are equal numerically (e.g. "a01" and "a1"), the one with fewer
+// leading zeros sorts first, and the comparison falls back to plain byte order to stay total.
+//
+// Parameters:
+//   - `a`: The first string.
+//   - `b`: The second string.
+//
+// Returns:
+//   - A negative number, zero or a positive number when `a` sorts before, together with, or after `b`.
+//
+// Example:
+//
+//	files := []string{"file10.txt", "file2.txt", "file1.txt"}
+//	slices.SortFunc(files, NaturalCompare)
+//	// files will be []string{"file1.txt", "file2.txt", "file10.txt"}
+func NaturalCompare(a, b string) int {
+	zeros := 0
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			startA, startB := i, j
+			for i < len(a) && a[i] == '0' {
+				i++
+			}
+			for j < len(b) && b[j] == '0' {
+				j++
+			}
+			leadA, leadB := i-startA, j-startB
+			numA, numB := i, j
+			for i < len(a) && isDigit(a[i]) {
+				i++
+			}
+			for j < len(b) && isDigit(b[j]) {
+				j++
+			}
+			// Digit runs without leading zeros compare first by length, then lexically.
+			if c := cmp.Or(cmp.Compare(i-numA, j-numB), strings.Compare(a[numA:i], b[numB:j])); c != 0 {
+				return c
+			}
+			if zeros == 0 {
+				zeros = cmp.Compare(leadA, leadB)
+			}
+			continue
+		}
+		ra, sizeA := utf8.DecodeRuneInString(a[i:])
+		rb, sizeB := utf8.DecodeRuneInString(b[j:])
+		if ra != rb {
+			return cmp.Compare(ra, rb)
+		}
+		i += sizeA
+		j += sizeB
+	}
+	return cmp.Or(cmp.Compare(len(a)-i, len(b)-j), zeros, strings.Compare(a, b))
+}
+
+// NaturalLess reports whether `a` sorts before `b` in natural order, as defined by `NaturalCompare`.
+// It has the signature expected by `Sort`.
+//
+// Parameters:
+//   - `a`: The first string.
+//   - `b`: The second string.
+//
+// Returns:
+//   - `true` if `a` sorts before `b`.
+//
+// Example:
+//
+//	sorted := Sort([]string{"v1.10", "v1.9"}, NaturalLess)
+//	// sorted will be []string{"v1.9", "v1.10"}
+func NaturalLess(a, b string) bool {
+	return NaturalCompare(a, b) < 0
+}
+
+// CompareIgnoreAccents compares two strings ignoring accents and letter case, so that "élan",
+// "Elan" and "elan" sort together instead of after "z". Accents are stripped with `RemoveAccents`.
+// Strings that are equal once normalized are ordered by plain byte order, to keep the ordering total.
+//
+// Parameters:
+//   - `a`: The first string.
+//   - `b`: The second string.
+//
+// Returns:
+//   - A negative number, zero or a positive number when `a` sorts before, together with, or after `b`.
+//
+// Example:
+//
+//	names := []string{"Zoë", "Émile", "adam"}
+//	slices.SortFunc(names, CompareIgnoreAccents)
+//	// names will be []string{"adam", "Émile", "Zoë"}
+func CompareIgnoreAccents(a, b string) int {
+	fold := func(s string) string {
+		return strings.Map(unicode.ToLower, RemoveAccents(s))
+	}
+	return cmp.Or(strings.Compare(fold(a), fold(b)), strings.Compare(a, b))
+}
+
+// compareByKeys combines `keys` into a single comparison function, applied in priority order.
+func compareByKeys[T any](keys []SortKey[T]) func(a, b T) int {
+	return func(a, b T) int {
+		for _, key := range keys {
+			if c := key(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// sortMapEntries collects the entries of `m` and sorts them with `compare`.
+func sortMapEntries[K comparable, V any](m map[K]V, compare func(a, b MapEntry[K, V]) int) []MapEntry[K, V] {
+	entries := make([]MapEntry[K, V], 0, len(m))
+	for k, v := range m {
+		entries = append(entries, MapEntry[K, V]{Key: k, Value: v})
+	}
+	slices.SortFunc(entries, compare)
+	return entries
+}
+
+// isDigit reports whether the byte `c` is an ASCII digit.
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// topKItem is an element tracked by `TopK`, with its input position used to break ties.
+type topKItem[T any] struct {
+	value T
+	index int
+}
+
+// topKHeap is a max-heap (by sort order) holding the best candidates seen by `TopK`,
+// so that the worst of them sits at the root and can be replaced in O(log k).
+type topKHeap[T any] struct {
+	items   []topKItem[T]
+	compare func(a, b T) int
+}
+
+// less reports whether `a` sorts strictly before `b`, breaking ties by input position.
+func (h *topKHeap[T]) less(a, b topKItem[T]) bool {
+	return cmp.Or(h.compare(a.value, b.value), cmp.Compare(a.index, b.index)) < 0
+}
+
+func (h *topKHeap[T]) Len() int           { return len(h.items) }
+func (h *topKHeap[T]) Less(i, j int) bool { return h.less(h.items[j], h.items[i]) }
+func (h *topKHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *topKHeap[T]) Push(x any)         { h.items = append(h.items, x.(topKItem[T])) }
+func (h *topKHeap[T]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
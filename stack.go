@@ -1,5 +1,7 @@
 package unify4g
 
+import "iter"
+
 // Stack is a generic stack data structure that stores elements of type `T`.
 // It provides basic stack operations like push, pop, and peek.
 // The type `T` must be comparable, allowing the stack to handle any type that supports equality comparison.
@@ -92,3 +94,23 @@ func (stack *Stack[T]) Size() int {
 func (stack *Stack[T]) Clear() {
 	stack.items = make([]T, 0)
 }
+
+// All returns a lazy sequence over the elements of the stack, from the top to the bottom,
+// which is the order in which `Pop` would return them. The stack itself is not modified.
+// Returns:
+//   - An `iter.Seq[T]` yielding each element of the stack.
+//
+// Example usage:
+//
+//	for item := range stack.All() {
+//		fmt.Println(item)
+//	}
+func (stack *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(stack.items) - 1; i >= 0; i-- {
+			if !yield(stack.items[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,499 @@
+package unify4g
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+)
+
+// Welford is an online accumulator that computes the count, mean, variance, minimum and maximum
+// of a stream of values in a single pass and constant memory, using Welford's algorithm.
+// It is numerically stable and suitable for data sets too large to hold in memory.
+// The zero value is an empty accumulator ready to use.
+type Welford struct {
+	count    int
+	mean     float64
+	m2       float64
+	min, max float64
+}
+
+// Min returns the smallest element of `values`.
+//
+// Parameters:
+//   - `values`: The input slice.
+//
+// Returns:
+//   - The smallest element, and `true`; or the zero value and `false` if `values` is empty.
+//
+// Example:
+//
+//	smallest, ok := Min([]int{3, 1, 2})
+//	// smallest will be 1, ok will be true
+func Min[T cmp.Ordered](values []T) (T, bool) {
+	if len(values) == 0 {
+		var zero T
+		return zero, false
+	}
+	return slices.Min(values), true
+}
+
+// Max returns the largest element of `values`.
+//
+// Parameters:
+//   - `values`: The input slice.
+//
+// Returns:
+//   - The largest element, and `true`; or the zero value and `false` if `values` is empty.
+//
+// Example:
+//
+//	largest, ok := Max([]int{3, 1, 2})
+//	// largest will be 3, ok will be true
+func Max[T cmp.Ordered](values []T) (T, bool) {
+	if len(values) == 0 {
+		var zero T
+		return zero, false
+	}
+	return slices.Max(values), true
+}
+
+// MinBy returns the element of `values` with the smallest key, as computed by `key`.
+// If several elements share the smallest key, the first one is returned.
+//
+// Parameters:
+//   - `values`: The input slice.
+//   - `key`: Returns the value to compare for an element.
+//
+// Returns:
+//   - The element with the smallest key, and `true`; or the zero value and `false` if `values` is empty.
+//
+// Example:
+//
+//	cheapest, ok := MinBy(products, func(p Product) float64 { return p.Price })
+func MinBy[T any, K cmp.Ordered](values []T, key func(T) K) (T, bool) {
+	return extremeBy(values, key, func(candidate, best K) bool { return candidate < best })
+}
+
+// MaxBy returns the element of `values` with the largest key, as computed by `key`.
+// If several elements share the largest key, the first one is returned.
+//
+// Parameters:
+//   - `values`: The input slice.
+//   - `key`: Returns the value to compare for an element.
+//
+// Returns:
+//   - The element with the largest key, and `true`; or the zero value and `false` if `values` is empty.
+//
+// Example:
+//
+//	oldest, ok := MaxBy(users, func(u User) int { return u.Age })
+func MaxBy[T any, K cmp.Ordered](values []T, key func(T) K) (T, bool) {
+	return extremeBy(values, key, func(candidate, best K) bool { return candidate > best })
+}
+
+// Mean returns the arithmetic mean of `values`.
+//
+// Parameters:
+//   - `values`: The input slice.
+//
+// Returns:
+//   - The mean as a float64, and `true`; or 0 and `false` if `values` is empty.
+//
+// Example:
+//
+//	avg, ok := Mean([]int{1, 2, 3, 4})
+//	// avg will be 2.5
+func Mean[T Number](values []T) (float64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	return welfordOf(values).Mean(), true
+}
+
+// Median returns the middle value of `values` once sorted. For an even number of elements,
+// the mean of the two middle values is returned. The input slice is not modified.
+//
+// Parameters:
+//   - `values`: The input slice.
+//
+// Returns:
+//   - The median as a float64, and `true`; or 0 and `false` if `values` is empty.
+//
+// Example:
+//
+//	median, ok := Median([]int{5, 1, 4, 2})
+//	// median will be 3
+func Median[T Number](values []T) (float64, bool) {
+	return Percentile(values, 50, PercentileLinear)
+}
+
+// Mode returns the most frequent values of `values`. When several values share the highest
+// frequency, all of them are returned in order of first appearance.
+//
+// Parameters:
+//   - `values`: The input slice.
+//
+// Returns:
+//   - A slice with the most frequent values; empty if `values` is empty.
+//
+// Example:
+//
+//	modes := Mode([]int{1, 2, 2, 3, 3})
+//	// modes will be []int{2, 3}
+func Mode[T comparable](values []T) []T {
+	frequencies := Frequencies(values)
+	highest := 0
+	for _, count := range frequencies {
+		highest = max(highest, count)
+	}
+	return Filter(Unique(values), func(v T) bool { return frequencies[v] == highest })
+}
+
+// Variance returns the population variance of `values`, i.e. the mean squared deviation from the mean.
+//
+// Parameters:
+//   - `values`: The input slice.
+//
+// Returns:
+//   - The population variance, and `true`; or 0 and `false` if `values` is empty.
+//
+// Example:
+//
+//	variance, ok := Variance([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+//	// variance will be 4
+func Variance[T Number](values []T) (float64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	return welfordOf(values).Variance(), true
+}
+
+// SampleVariance returns the sample variance of `values`, using Bessel's correction (dividing by n-1).
+//
+// Parameters:
+//   - `values`: The input slice.
+//
+// Returns:
+//   - The sample variance, and `true`; or 0 and `false` if `values` has fewer than two elements.
+//
+// Example:
+//
+//	variance, ok := SampleVariance([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+//	// variance will be 4.571428...
+func SampleVariance[T Number](values []T) (float64, bool) {
+	if len(values) < 2 {
+		return 0, false
+	}
+	return welfordOf(values).SampleVariance(), true
+}
+
+// StdDev returns the population standard deviation of `values`.
+//
+// Parameters:
+//   - `values`: The input slice.
+//
+// Returns:
+//   - The population standard deviation, and `true`; or 0 and `false` if `values` is empty.
+//
+// Example:
+//
+//	deviation, ok := StdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+//	// deviation will be 2
+func StdDev[T Number](values []T) (float64, bool) {
+	variance, ok := Variance(values)
+	return math.Sqrt(variance), ok
+}
+
+// SampleStdDev returns the sample standard deviation of `values`, using Bessel's correction.
+//
+// Parameters:
+//   - `values`: The input slice.
+//
+// Returns:
+//   - The sample standard deviation, and `true`; or 0 and `false` if `values` has fewer than two elements.
+//
+// Example:
+//
+//	deviation, ok := SampleStdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+func SampleStdDev[T Number](values []T) (float64, bool) {
+	variance, ok := SampleVariance(values)
+	return math.Sqrt(variance), ok
+}
+
+// Percentile returns the `p`-th percentile of `values`, where `p` is between 0 and 100.
+//
+// The values are sorted (on a copy) and the percentile's rank is computed as `p/100 * (n-1)`.
+// When the rank falls between two data points, `method` decides the result:
+//   - `PercentileLinear`: linear interpolation between the two points.
+//   - `PercentileLower`: the lower point.
+//   - `PercentileHigher`: the higher point.
+//   - `PercentileNearest`: the closest point.
+//   - `PercentileMidpoint`: the average of the two points.
+//
+// Parameters:
+//   - `values`: The input slice.
+//   - `p`: The percentile to compute, in the range [0, 100].
+//   - `method`: The interpolation method.
+//
+// Returns:
+//   - The percentile as a float64, and `true`; or 0 and `false` if `values` is empty,
+//     `p` is out of range or `method` is unknown.
+//
+// Example:
+//
+//	latencies := []int{10, 20, 30, 40}
+//	p90, _ := Percentile(latencies, 90, PercentileLinear)
+//	// p90 will be 37
+//	p90, _ = Percentile(latencies, 90, PercentileLower)
+//	// p90 will be 30
+func Percentile[T Number](values []T, p float64, method PercentileMethod) (float64, bool) {
+	if len(values) == 0 || math.IsNaN(p) || p < 0 || p > 100 {
+		return 0, false
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	rank := p / 100 * float64(len(sorted)-1)
+	lowerIndex, upperIndex := int(math.Floor(rank)), int(math.Ceil(rank))
+	lower, upper := float64(sorted[lowerIndex]), float64(sorted[upperIndex])
+	switch method {
+	case PercentileLinear:
+		return lower + (upper-lower)*(rank-float64(lowerIndex)), true
+	case PercentileLower:
+		return lower, true
+	case PercentileHigher:
+		return upper, true
+	case PercentileNearest:
+		return float64(sorted[int(math.Round(rank))]), true
+	case PercentileMidpoint:
+		return (lower + upper) / 2, true
+	default:
+		return 0, false
+	}
+}
+
+// Histogram counts how many of `values` fall into each bucket delimited by the ascending `edges`.
+//
+// `n` edges define `n-1` buckets: bucket i covers [edges[i], edges[i+1]), except the last bucket,
+// which also includes its upper edge. Values outside [edges[0], edges[n-1]] are not counted.
+//
+// Parameters:
+//   - `values`: The input slice.
+//   - `edges`: The bucket boundaries, in strictly ascending order.
+//
+// Returns:
+//   - The buckets in ascending order; empty if fewer than two edges are given or they are not ascending.
+//
+// Example:
+//
+//	buckets := Histogram([]int{1, 5, 7, 10, 12}, []float64{0, 5, 10})
+//	// buckets will be [{0 5 1} {5 10 3}]; 12 is out of range
+func Histogram[T Number](values []T, edges []float64) []HistogramBucket {
+	buckets := make([]HistogramBucket, 0)
+	if len(edges) < 2 {
+		return buckets
+	}
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i-1] < edges[i]) {
+			return buckets
+		}
+		buckets = append(buckets, HistogramBucket{Lower: edges[i-1], Upper: edges[i]})
+	}
+	last := edges[len(edges)-1]
+	for _, value := range values {
+		v := float64(value)
+		if v < edges[0] || v > last || math.IsNaN(v) {
+			continue
+		}
+		index := sort.SearchFloat64s(edges, v)
+		if index == len(edges) || edges[index] != v {
+			index--
+		}
+		buckets[min(index, len(buckets)-1)].Count++
+	}
+	return buckets
+}
+
+// HistogramN splits the range between the smallest and largest of `values` into `n` buckets of
+// equal width and counts the values in each, as described for `Histogram`.
+//
+// Parameters:
+//   - `values`: The input slice.
+//   - `n`: The number of buckets.
+//
+// Returns:
+//   - The buckets in ascending order; empty if `values` is empty or `n` is not positive.
+//     If all values are equal, a single bucket holding all of them is returned.
+//
+// Example:
+//
+//	buckets := HistogramN([]int{0, 1, 2, 3, 4, 10}, 2)
+//	// buckets will be [{0 5 5} {5 10 1}]
+func HistogramN[T Number](values []T, n int) []HistogramBucket {
+	if len(values) == 0 || n <= 0 {
+		return []HistogramBucket{}
+	}
+	lowest, _ := Min(values)
+	highest, _ := Max(values)
+	low, high := float64(lowest), float64(highest)
+	if low == high {
+		return []HistogramBucket{{Lower: low, Upper: high, Count: len(values)}}
+	}
+	edges := make([]float64, n+1)
+	width := (high - low) / float64(n)
+	for i := range edges {
+		edges[i] = low + width*float64(i)
+	}
+	edges[n] = high
+	return Histogram(values, edges)
+}
+
+// NewWelford creates an accumulator initialized with the given values.
+//
+// Parameters:
+//   - `values`: The initial values to add (optional).
+//
+// Returns:
+//   - A pointer to a new Welford accumulator.
+//
+// Example:
+//
+//	acc := NewWelford(1.5, 2.5)
+//	acc.Add(3.5)
+//	fmt.Println(acc.Mean()) // 2.5
+func NewWelford(values ...float64) *Welford {
+	acc := &Welford{}
+	acc.AddAll(values...)
+	return acc
+}
+
+// Add incorporates a value into the accumulator.
+//
+// Parameters:
+//   - `value`: The value to add.
+func (acc *Welford) Add(value float64) {
+	acc.count++
+	if acc.count == 1 {
+		acc.min, acc.max = value, value
+	} else {
+		acc.min = math.Min(acc.min, value)
+		acc.max = math.Max(acc.max, value)
+	}
+	delta := value - acc.mean
+	acc.mean += delta / float64(acc.count)
+	acc.m2 += delta * (value - acc.mean)
+}
+
+// AddAll incorporates several values into the accumulator.
+//
+// Parameters:
+//   - `values`: The values to add.
+func (acc *Welford) AddAll(values ...float64) {
+	for _, value := range values {
+		acc.Add(value)
+	}
+}
+
+// Merge combines the statistics of `other` into the accumulator, as if all of its values had been
+// added directly. This allows partial accumulators computed in parallel to be joined.
+//
+// Parameters:
+//   - `other`: The accumulator to merge; it is not modified.
+//
+// Example:
+//
+//	left, right := NewWelford(1, 2), NewWelford(3, 4)
+//	left.Merge(right)
+//	// left.Mean() will be 2.5
+func (acc *Welford) Merge(other *Welford) {
+	if other == nil || other.count == 0 {
+		return
+	}
+	if acc.count == 0 {
+		*acc = *other
+		return
+	}
+	total := acc.count + other.count
+	delta := other.mean - acc.mean
+	acc.mean += delta * float64(other.count) / float64(total)
+	acc.m2 += other.m2 + delta*delta*float64(acc.count)*float64(other.count)/float64(total)
+	acc.min = math.Min(acc.min, other.min)
+	acc.max = math.Max(acc.max, other.max)
+	acc.count = total
+}
+
+// Count returns the number of values added.
+func (acc *Welford) Count() int {
+	return acc.count
+}
+
+// Mean returns the mean of the values added, or 0 if none.
+func (acc *Welford) Mean() float64 {
+	return acc.mean
+}
+
+// Min returns the smallest value added, or 0 if none.
+func (acc *Welford) Min() float64 {
+	return acc.min
+}
+
+// Max returns the largest value added, or 0 if none.
+func (acc *Welford) Max() float64 {
+	return acc.max
+}
+
+// Variance returns the population variance of the values added, or 0 if none.
+func (acc *Welford) Variance() float64 {
+	if acc.count == 0 {
+		return 0
+	}
+	return acc.m2 / float64(acc.count)
+}
+
+// SampleVariance returns the sample variance of the values added, or 0 if fewer than two.
+func (acc *Welford) SampleVariance() float64 {
+	if acc.count < 2 {
+		return 0
+	}
+	return acc.m2 / float64(acc.count-1)
+}
+
+// StdDev returns the population standard deviation of the values added.
+func (acc *Welford) StdDev() float64 {
+	return math.Sqrt(acc.Variance())
+}
+
+// SampleStdDev returns the sample standard deviation of the values added.
+func (acc *Welford) SampleStdDev() float64 {
+	return math.Sqrt(acc.SampleVariance())
+}
+
+// Reset discards every value added, returning the accumulator to its empty state.
+func (acc *Welford) Reset() {
+	*acc = Welford{}
+}
+
+// welfordOf builds an accumulator over numeric `values`.
+func welfordOf[T Number](values []T) *Welford {
+	acc := &Welford{}
+	for _, value := range values {
+		acc.Add(float64(value))
+	}
+	return acc
+}
+
+// extremeBy returns the first element of `values` whose key is not beaten by any other, per `better`.
+func extremeBy[T any, K cmp.Ordered](values []T, key func(T) K, better func(candidate, best K) bool) (T, bool) {
+	var result T
+	if len(values) == 0 {
+		return result, false
+	}
+	result = values[0]
+	bestKey := key(result)
+	for _, item := range values[1:] {
+		if k := key(item); better(k, bestKey) {
+			result, bestKey = item, k
+		}
+	}
+	return result, true
+}
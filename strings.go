@@ -3,9 +3,11 @@ package unify4g
 import (
 	"crypto/sha256"
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 )
@@ -155,7 +157,7 @@ func IsBlank(s string) bool {
 	if s == "" {
 		return true
 	}
-	if regexp.MustCompile(`^\s+$`).MatchString(s) {
+	if regexpBlank.MatchString(s) {
 		return true
 	}
 	return false
@@ -725,8 +727,7 @@ func OnlyDigits(sequence string) string {
 		return ""
 	}
 	if utf8.RuneCountInString(sequence) > 0 {
-		re, _ := regexp.Compile(`[\D]`)
-		sequence = re.ReplaceAllString(sequence, "")
+		sequence = regexpNonDigits.ReplaceAllString(sequence, "")
 	}
 	return sequence
 }
@@ -1967,6 +1968,8 @@ func RemoveEndIgnoreCase(str string, remove string) string {
 
 // RemovePattern removes all substrings from the source string that match the specified
 // regular expression pattern. If no matches are found, the original string is returned unchanged.
+// The pattern is compiled once through the package-wide cache of `CompileCached`; the function
+// panics if it is invalid.
 //
 // Parameters:
 //   - `str`: The source string from which substrings matching the pattern will be removed.
@@ -1982,7 +1985,7 @@ func RemoveEndIgnoreCase(str string, remove string) string {
 //	pattern := "[0-9]+" // matches all digits
 //	result := RemovePattern(input, pattern) // result will be "abcxyz" since all digits are removed from the string.
 func RemovePattern(str string, pattern string) string {
-	return regexp.MustCompile(pattern).ReplaceAllString(str, "")
+	return MustCompileCached(pattern).ReplaceAllString(str, "")
 }
 
 // RemoveStart removes a substring only if it is at the beginning of a source string,
@@ -2212,7 +2215,7 @@ func ReverseDelimited(str string, del string) string {
 //	input := "   Hello, World!   "
 //	result := Strip(input) // result will be "Hello, World!".
 func Strip(str string) string {
-	return regexp.MustCompile(`^\s+|\s+$`).ReplaceAllString(str, "")
+	return regexpSurroundingSpaces.ReplaceAllString(str, "")
 }
 
 // StripEnd removes whitespace from the end of a string.
@@ -2233,7 +2236,7 @@ func Strip(str string) string {
 //	input := "Hello, World!   "
 //	result := StripEnd(input) // result will be "Hello, World!".
 func StripEnd(str string) string {
-	return regexp.MustCompile(`\s+$`).ReplaceAllString(str, "")
+	return regexpTrailingSpaces.ReplaceAllString(str, "")
 }
 
 // StripStart removes whitespace from the start of a string.
@@ -2254,7 +2257,7 @@ func StripEnd(str string) string {
 //	input := "   Hello, World!"
 //	result := StripStart(input) // result will be "Hello, World!".
 func StripStart(str string) string {
-	return regexp.MustCompile(`^\s+`).ReplaceAllString(str, "")
+	return regexpLeadingSpaces.ReplaceAllString(str, "")
 }
 
 // SwapCase returns a new string with all uppercase letters converted to lowercase
@@ -2445,7 +2448,7 @@ func ContainsAllWords(str string, words ...string) bool {
 	}
 	found := 0
 	for _, word := range words {
-		if regexp.MustCompile(`.*\b` + word + `\b.*`).MatchString(str) {
+		if MustCompileCached(`.*\b` + word + `\b.*`).MatchString(str) {
 			found++
 		}
 	}
@@ -2515,6 +2518,468 @@ func InitialsDelimited(str string, delimiters ...string) string {
 	return string(buff[:count])
 }
 
+// Inflector pluralizes and singularizes English words.
+//
+// A word is first looked up in the uncountable words ("sheep", "information"), which are never
+// inflected, then in the irregular forms ("person" and "people"), and is otherwise transformed by
+// the first matching replacement rule. Rules are tried from the most recently added one, so that
+// rules added with `AddPlural` and `AddSingular` take precedence over the built-in ones. Only the
+// last word of a phrase is inflected ("user account" gives "user accounts"), and words written in
+// uppercase stay in uppercase, except the acronyms known to `CaseConverter`, which take a lowercase
+// "s" ("ID" gives "IDs").
+//
+// An Inflector must not be modified while it is in use by other goroutines.
+type Inflector struct {
+	plurals      []inflectionRule
+	singulars    []inflectionRule
+	irregulars   map[string]string
+	singularsOf  map[string]string
+	uncountables map[string]struct{}
+}
+
+// inflectionRule replaces the end of a word matched by `pattern` with `replacement`.
+type inflectionRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// defaultInflector is the Inflector used by the package-level inflection functions.
+var defaultInflector = NewInflector()
+
+// NewInflector creates an Inflector with the common English rules, irregular forms and uncountable words.
+//
+// Returns:
+//   - A pointer to a new Inflector.
+//
+// Example:
+//
+//	inflector := NewInflector().AddIrregular("cactus", "cacti").AddUncountable("feedback")
+//	inflector.Pluralize("cactus") // "cacti"
+func NewInflector() *Inflector {
+	inflector := &Inflector{
+		irregulars:   make(map[string]string),
+		singularsOf:  make(map[string]string),
+		uncountables: make(map[string]struct{}),
+	}
+	for _, rule := range defaultPluralRules {
+		inflector.AddPlural(rule[0], rule[1])
+	}
+	for _, rule := range defaultSingularRules {
+		inflector.AddSingular(rule[0], rule[1])
+	}
+	for _, irregular := range defaultIrregulars {
+		inflector.AddIrregular(irregular[0], irregular[1])
+	}
+	return inflector.AddUncountable(defaultUncountables...)
+}
+
+// AddPlural adds a pluralization rule, tried before the existing ones. The pattern is matched
+// case-insensitively and the replacement may refer to its groups as "${1}".
+//
+// Parameters:
+//   - `pattern`: The regular expression matching the end of singular words; it panics if it is invalid.
+//   - `replacement`: The replacement of the matched text.
+//
+// Returns:
+//   - The inflector, for chaining.
+//
+// Example:
+//
+//	NewInflector().AddPlural(`(cact)us$`, "${1}i").Pluralize("cactus") // "cacti"
+func (inflector *Inflector) AddPlural(pattern, replacement string) *Inflector {
+	inflector.plurals = append(inflector.plurals, inflectionRule{regexp.MustCompile("(?i)" + pattern), replacement})
+	return inflector
+}
+
+// AddSingular adds a singularization rule, tried before the existing ones, like `AddPlural`.
+//
+// Parameters:
+//   - `pattern`: The regular expression matching the end of plural words; it panics if it is invalid.
+//   - `replacement`: The replacement of the matched text.
+//
+// Returns:
+//   - The inflector, for chaining.
+//
+// Example:
+//
+//	NewInflector().AddSingular(`(cact)i$`, "${1}us").Singularize("cacti") // "cactus"
+func (inflector *Inflector) AddSingular(pattern, replacement string) *Inflector {
+	inflector.singulars = append(inflector.singulars, inflectionRule{regexp.MustCompile("(?i)" + pattern), replacement})
+	return inflector
+}
+
+// AddIrregular adds a word whose plural does not follow the rules. Irregular forms match whole words only.
+//
+// Parameters:
+//   - `singular`: The singular form, e.g. "person".
+//   - `plural`: The plural form, e.g. "people".
+//
+// Returns:
+//   - The inflector, for chaining.
+//
+// Example:
+//
+//	NewInflector().AddIrregular("octopus", "octopodes").Pluralize("octopus") // "octopodes"
+func (inflector *Inflector) AddIrregular(singular, plural string) *Inflector {
+	singular, plural = strings.ToLower(singular), strings.ToLower(plural)
+	delete(inflector.uncountables, singular)
+	delete(inflector.uncountables, plural)
+	inflector.irregulars[singular] = plural
+	inflector.singularsOf[plural] = singular
+	return inflector
+}
+
+// AddUncountable adds words that have no plural form and are returned unchanged.
+//
+// Parameters:
+//   - `words`: The uncountable words, e.g. "equipment".
+//
+// Returns:
+//   - The inflector, for chaining.
+//
+// Example:
+//
+//	NewInflector().AddUncountable("luggage").Pluralize("luggage") // "luggage"
+func (inflector *Inflector) AddUncountable(words ...string) *Inflector {
+	for _, word := range words {
+		inflector.uncountables[strings.ToLower(word)] = struct{}{}
+	}
+	return inflector
+}
+
+// Pluralize returns the plural form of `word`, or of the last word of a phrase.
+//
+// Parameters:
+//   - `word`: The singular word or phrase.
+//
+// Returns:
+//   - The plural form; words that are already plural are usually returned unchanged.
+//
+// Example:
+//
+//	NewInflector().Pluralize("Category") // "Categories"
+func (inflector *Inflector) Pluralize(word string) string {
+	return inflector.inflect(word, true, inflector.plurals, inflector.irregulars, inflector.singularsOf)
+}
+
+// Singularize returns the singular form of `word`, or of the last word of a phrase.
+//
+// Parameters:
+//   - `word`: The plural word or phrase.
+//
+// Returns:
+//   - The singular form; words that are already singular are usually returned unchanged.
+//
+// Example:
+//
+//	NewInflector().Singularize("user accounts") // "user account"
+func (inflector *Inflector) Singularize(word string) string {
+	return inflector.inflect(word, false, inflector.singulars, inflector.singularsOf, inflector.irregulars)
+}
+
+// Pluralize returns the plural form of an English word, or of the last word of a phrase.
+// See `Inflector` for the rules; use `NewInflector` to customize them.
+//
+// Parameters:
+//   - `word`: The singular word or phrase.
+//
+// Returns:
+//   - The plural form.
+//
+// Example:
+//
+//	Pluralize("box")    // "boxes"
+//	Pluralize("person") // "people"
+//	Pluralize("sheep")  // "sheep"
+func Pluralize(word string) string {
+	return defaultInflector.Pluralize(word)
+}
+
+// Singularize returns the singular form of an English word, or of the last word of a phrase.
+//
+// Parameters:
+//   - `word`: The plural word or phrase.
+//
+// Returns:
+//   - The singular form.
+//
+// Example:
+//
+//	Singularize("cities")   // "city"
+//	Singularize("children") // "child"
+func Singularize(word string) string {
+	return defaultInflector.Singularize(word)
+}
+
+// PluralizeCount prefixes `word` with `count`, using its plural form unless the count is 1 or -1.
+//
+// Parameters:
+//   - `word`: The singular word or phrase.
+//   - `count`: The number of items.
+//
+// Returns:
+//   - The count followed by the word.
+//
+// Example:
+//
+//	PluralizeCount("file", 1) // "1 file"
+//	PluralizeCount("file", 3) // "3 files"
+func PluralizeCount(word string, count int) string {
+	if count != 1 && count != -1 {
+		word = Pluralize(word)
+	}
+	return strconv.Itoa(count) + " " + word
+}
+
+// Humanize turns an identifier into a phrase for users: words are split as by `SplitWords`, the
+// first one is capitalized, known acronyms are written in uppercase and other words in lowercase.
+//
+// Parameters:
+//   - `str`: The identifier, in any naming convention.
+//
+// Returns:
+//   - The human-readable phrase.
+//
+// Example:
+//
+//	Humanize("user_id")        // "User ID"
+//	Humanize("createdAt")      // "Created at"
+//	Humanize("HTTPStatusCode") // "HTTP status code"
+func Humanize(str string) string {
+	words := defaultCaseConverter.Words(str)
+	for i, word := range words {
+		if acronym, ok := defaultCaseConverter.acronym(word); ok {
+			words[i] = acronym
+		} else if i == 0 {
+			words[i] = defaultCaseConverter.capitalize(word)
+		} else {
+			words[i] = strings.ToLower(word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// Ordinalize returns `number` followed by its English ordinal suffix.
+//
+// Parameters:
+//   - `number`: The number.
+//
+// Returns:
+//   - The ordinal, e.g. "1st", "2nd", "3rd", "4th", "11th" or "22nd".
+//
+// Example:
+//
+//	Ordinalize(3)   // "3rd"
+//	Ordinalize(112) // "112th"
+func Ordinalize(number int) string {
+	suffix := "th"
+	lastTwo := number % 100
+	if lastTwo < 0 {
+		lastTwo = -lastTwo
+	}
+	if lastTwo < 11 || lastTwo > 13 {
+		switch lastTwo % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(number) + suffix
+}
+
+// NumberToWords spells out `number` in English words, using the short scale
+// (million, billion, trillion, ...).
+//
+// Parameters:
+//   - `number`: The number to spell out.
+//
+// Returns:
+//   - The number in words.
+//
+// Example:
+//
+//	NumberToWords(0)       // "zero"
+//	NumberToWords(-42)     // "minus forty-two"
+//	NumberToWords(1250300) // "one million two hundred fifty thousand three hundred"
+func NumberToWords(number int64) string {
+	if number == 0 {
+		return numberWordsSmall[0]
+	}
+	value, sign := uint64(number), ""
+	if number < 0 {
+		value, sign = uint64(-(number+1))+1, "minus "
+	}
+	var groups []string
+	for scale := 0; value > 0; scale++ {
+		chunk := int(value % 1000)
+		value /= 1000
+		if chunk == 0 {
+			continue
+		}
+		words := numberChunkToWords(chunk)
+		if scale > 0 {
+			words += " " + numberWordsScales[scale]
+		}
+		groups = append([]string{words}, groups...)
+	}
+	return sign + strings.Join(groups, " ")
+}
+
+// HumanizeBytes formats a size in bytes with binary (IEC) units, which are powers of 1024,
+// keeping at most one decimal.
+//
+// Parameters:
+//   - `size`: The size in bytes.
+//
+// Returns:
+//   - The formatted size, e.g. "512 B", "1.5 MiB" or "2 GiB".
+//
+// Example:
+//
+//	HumanizeBytes(1572864) // "1.5 MiB"
+func HumanizeBytes(size uint64) string {
+	return humanizeBytes(size, 1024, []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"})
+}
+
+// HumanizeBytesSI formats a size in bytes with decimal (SI) units, which are powers of 1000,
+// keeping at most one decimal.
+//
+// Parameters:
+//   - `size`: The size in bytes.
+//
+// Returns:
+//   - The formatted size, e.g. "512 B", "1.5 MB" or "2 GB".
+//
+// Example:
+//
+//	HumanizeBytesSI(1500000) // "1.5 MB"
+func HumanizeBytesSI(size uint64) string {
+	return humanizeBytes(size, 1000, []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"})
+}
+
+// ParseBytes parses a human-readable size such as "1.5 MiB", "10MB" or "512" into a number of bytes.
+// Units are case-insensitive: binary units ("KiB", "Ki", "MiB", ...) are powers of 1024 and decimal
+// units ("kB", "K", "MB", ...) are powers of 1000. A number without unit is in bytes.
+//
+// Parameters:
+//   - `str`: The size to parse.
+//
+// Returns:
+//   - The size in bytes, rounded to the nearest byte.
+//   - An error if the number or the unit is invalid, or if the size overflows an uint64.
+//
+// Example:
+//
+//	size, err := ParseBytes("1.5 MiB")
+//	// size will be 1572864
+func ParseBytes(str string) (uint64, error) {
+	text := strings.TrimSpace(str)
+	end := strings.IndexFunc(text, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' })
+	if end < 0 {
+		end = len(text)
+	}
+	value, err := strconv.ParseFloat(text[:end], 64)
+	if end == 0 || err != nil {
+		return 0, fmt.Errorf("parse bytes: invalid size '%s'", str)
+	}
+	unit := strings.ToLower(strings.TrimSpace(text[end:]))
+	multiplier, ok := byteUnitMultipliers[unit]
+	if !ok {
+		return 0, fmt.Errorf("parse bytes: unknown unit '%s' in '%s'", text[end:], str)
+	}
+	size := math.Round(value * multiplier)
+	if size >= math.MaxUint64 {
+		return 0, fmt.Errorf("parse bytes: size '%s' overflows uint64", str)
+	}
+	return uint64(size), nil
+}
+
+// HumanizeDuration formats `duration` with its most significant unit among days, hours, minutes
+// and seconds, followed by the next unit when it is not zero. Durations under a second are
+// written in milliseconds, and shorter ones as by `time.Duration.String`.
+//
+// Parameters:
+//   - `duration`: The duration to format.
+//
+// Returns:
+//   - The formatted duration, e.g. "3h 2m", "1d", "45s" or "250ms".
+//
+// Example:
+//
+//	HumanizeDuration(3*time.Hour + 2*time.Minute + 30*time.Second) // "3h 2m"
+func HumanizeDuration(duration time.Duration) string {
+	if duration < 0 {
+		return "-" + HumanizeDuration(-max(duration, -math.MaxInt64))
+	}
+	if duration < time.Millisecond {
+		return duration.String()
+	}
+	if duration < time.Second {
+		return strconv.FormatInt(duration.Milliseconds(), 10) + "ms"
+	}
+	units := []struct {
+		size   time.Duration
+		suffix string
+	}{{24 * time.Hour, "d"}, {time.Hour, "h"}, {time.Minute, "m"}, {time.Second, "s"}}
+	for i, unit := range units {
+		if duration < unit.size {
+			continue
+		}
+		text := strconv.FormatInt(int64(duration/unit.size), 10) + unit.suffix
+		if i+1 < len(units) {
+			if next := (duration % unit.size) / units[i+1].size; next > 0 {
+				text += " " + strconv.FormatInt(int64(next), 10) + units[i+1].suffix
+			}
+		}
+		return text
+	}
+	return duration.String()
+}
+
+// RelativeTime describes `t` relative to `reference` with `HumanizeDuration`, such as "3h 2m ago"
+// or "in 5m". Times less than a second apart are described as "just now".
+//
+// Parameters:
+//   - `t`: The time to describe.
+//   - `reference`: The reference time, usually the current time.
+//
+// Returns:
+//   - The relative description.
+//
+// Example:
+//
+//	now := time.Now()
+//	RelativeTime(now.Add(-90*time.Minute), now) // "1h 30m ago"
+func RelativeTime(t, reference time.Time) string {
+	elapsed := reference.Sub(t)
+	switch {
+	case elapsed > -time.Second && elapsed < time.Second:
+		return "just now"
+	case elapsed > 0:
+		return HumanizeDuration(elapsed) + " ago"
+	default:
+		return "in " + HumanizeDuration(-elapsed)
+	}
+}
+
+// TimeAgo describes `t` relative to the current time, like `RelativeTime`.
+//
+// Parameters:
+//   - `t`: The time to describe.
+//
+// Returns:
+//   - The relative description, e.g. "3h 2m ago".
+//
+// Example:
+//
+//	TimeAgo(time.Now().Add(-45 * time.Second)) // "45s ago"
+func TimeAgo(t time.Time) string {
+	return RelativeTime(t, time.Now())
+}
+
 // isDelimiter checks if a character is a delimiter.
 //
 // This helper function determines whether a given character `c` is a
@@ -2713,3 +3178,188 @@ func stringInSlice(a string, list []string) bool {
 	}
 	return false
 }
+
+// defaultPluralRules lists the pluralization rules of `NewInflector`, from the most general to the most specific.
+var defaultPluralRules = [][2]string{
+	{`$`, "s"},
+	{`s$`, "s"},
+	{`^(ax|test)is$`, "${1}es"},
+	{`(octop|vir)us$`, "${1}i"},
+	{`(octop|vir)i$`, "${1}i"},
+	{`(alias|status|campus)$`, "${1}es"},
+	{`(bu)s$`, "${1}ses"},
+	{`(buffal|tomat|potat|her|ech)o$`, "${1}oes"},
+	{`([ti])um$`, "${1}a"},
+	{`([ti])a$`, "${1}a"},
+	{`sis$`, "ses"},
+	{`(?:([^f])fe|([lr])f)$`, "${1}${2}ves"},
+	{`(hive)$`, "${1}s"},
+	{`([^aeiouy]|qu)y$`, "${1}ies"},
+	{`(x|ch|ss|sh)$`, "${1}es"},
+	{`(matr|vert|ind)(?:ix|ex)$`, "${1}ices"},
+	{`^(m|l)ouse$`, "${1}ice"},
+	{`^(m|l)ice$`, "${1}ice"},
+	{`^(ox)$`, "${1}en"},
+	{`^(oxen)$`, "${1}"},
+	{`(quiz)$`, "${1}zes"},
+}
+
+// defaultSingularRules lists the singularization rules of `NewInflector`, from the most general to the most specific.
+var defaultSingularRules = [][2]string{
+	{`s$`, ""},
+	{`(ss)$`, "${1}"},
+	{`(n)ews$`, "${1}ews"},
+	{`([ti])a$`, "${1}um"},
+	{`((a)naly|(b)a|(d)iagno|(p)arenthe|(p)rogno|(s)ynop|(t)he)(sis|ses)$`, "${1}sis"},
+	{`(^analy)(sis|ses)$`, "${1}sis"},
+	{`([^f])ves$`, "${1}fe"},
+	{`(hive)s$`, "${1}"},
+	{`(tive)s$`, "${1}"},
+	{`([lr])ves$`, "${1}f"},
+	{`([^aeiouy]|qu)ies$`, "${1}y"},
+	{`(s)eries$`, "${1}eries"},
+	{`(m)ovies$`, "${1}ovie"},
+	{`(x|ch|ss|sh)es$`, "${1}"},
+	{`^(m|l)ice$`, "${1}ouse"},
+	{`(bus)(es)?$`, "${1}"},
+	{`(o)es$`, "${1}"},
+	{`(shoe)s$`, "${1}"},
+	{`(cris|test)(is|es)$`, "${1}is"},
+	{`^(a)x[ie]s$`, "${1}xis"},
+	{`(octop|vir)(us|i)$`, "${1}us"},
+	{`(alias|status|campus)(es)?$`, "${1}"},
+	{`^(ox)en`, "${1}"},
+	{`(vert|ind)ices$`, "${1}ex"},
+	{`(matr)ices$`, "${1}ix"},
+	{`(quiz)zes$`, "${1}"},
+	{`(database)s$`, "${1}"},
+}
+
+// defaultIrregulars lists the singular and plural forms of the irregular words of `NewInflector`.
+var defaultIrregulars = [][2]string{
+	{"person", "people"},
+	{"man", "men"},
+	{"woman", "women"},
+	{"child", "children"},
+	{"foot", "feet"},
+	{"tooth", "teeth"},
+	{"goose", "geese"},
+	{"criterion", "criteria"},
+	{"sex", "sexes"},
+	{"move", "moves"},
+	{"zombie", "zombies"},
+}
+
+// defaultUncountables lists the uncountable words of `NewInflector`.
+var defaultUncountables = []string{
+	"equipment", "information", "rice", "money", "species", "series", "fish", "sheep", "deer",
+	"jeans", "police", "news", "software", "feedback", "metadata",
+}
+
+// numberWordsSmall, numberWordsTens and numberWordsScales are the English words used by `NumberToWords`.
+var (
+	numberWordsSmall = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+		"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+	}
+	numberWordsTens   = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	numberWordsScales = []string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+)
+
+// byteUnitMultipliers maps the lowercase units accepted by `ParseBytes` to their size in bytes.
+var byteUnitMultipliers = map[string]float64{
+	"": 1, "b": 1, "byte": 1, "bytes": 1,
+	"k": 1e3, "kb": 1e3, "ki": 1 << 10, "kib": 1 << 10,
+	"m": 1e6, "mb": 1e6, "mi": 1 << 20, "mib": 1 << 20,
+	"g": 1e9, "gb": 1e9, "gi": 1 << 30, "gib": 1 << 30,
+	"t": 1e12, "tb": 1e12, "ti": 1 << 40, "tib": 1 << 40,
+	"p": 1e15, "pb": 1e15, "pi": 1 << 50, "pib": 1 << 50,
+	"e": 1e18, "eb": 1e18, "ei": 1 << 60, "eib": 1 << 60,
+}
+
+// inflect applies the irregular forms `forms` or the `rules` to the last word of `word`. Words found
+// among `inflected`, the irregular forms of the opposite direction, are already inflected. Acronyms
+// known to the default CaseConverter, such as "ID", take or lose a lowercase "s" whatever the rules.
+func (inflector *Inflector) inflect(word string, plural bool, rules []inflectionRule, forms, inflected map[string]string) string {
+	start := strings.LastIndexFunc(word, func(r rune) bool { return !unicode.IsLetter(r) }) + 1
+	prefix, last := word[:start], word[start:]
+	if last == "" {
+		return word
+	}
+	lower := strings.ToLower(last)
+	if _, ok := inflector.uncountables[lower]; ok {
+		return word
+	}
+	if _, ok := inflected[lower]; ok {
+		return word
+	}
+	if acronym, ok := defaultCaseConverter.acronyms[lower]; ok && acronym == last {
+		if plural {
+			return prefix + acronym + "s"
+		}
+		return word
+	}
+	if acronym, ok := defaultCaseConverter.acronyms[strings.TrimSuffix(lower, "s")]; ok && strings.HasPrefix(last, acronym) {
+		if plural {
+			return word
+		}
+		return prefix + acronym
+	}
+	if form, ok := forms[lower]; ok {
+		return prefix + matchInflectionCase(last, form)
+	}
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].pattern.MatchString(last) {
+			return prefix + matchInflectionCase(last, rules[i].pattern.ReplaceAllString(last, rules[i].replacement))
+		}
+	}
+	return word
+}
+
+// matchInflectionCase writes `inflected` in uppercase if `word` is, and capitalizes it if `word` is capitalized.
+func matchInflectionCase(word, inflected string) string {
+	if len(word) > 1 && strings.ToUpper(word) == word {
+		return strings.ToUpper(inflected)
+	}
+	if first, _ := utf8.DecodeRuneInString(word); unicode.IsUpper(first) {
+		return Capitalize(inflected)
+	}
+	return inflected
+}
+
+// numberChunkToWords spells out a number between 1 and 999.
+func numberChunkToWords(number int) string {
+	var parts []string
+	if number >= 100 {
+		parts = append(parts, numberWordsSmall[number/100]+" hundred")
+		number %= 100
+	}
+	switch {
+	case number >= 20:
+		word := numberWordsTens[number/10]
+		if number%10 != 0 {
+			word += "-" + numberWordsSmall[number%10]
+		}
+		parts = append(parts, word)
+	case number > 0:
+		parts = append(parts, numberWordsSmall[number])
+	}
+	return strings.Join(parts, " ")
+}
+
+// humanizeBytes formats `size` with the largest of `units`, successive powers of `base`, under which it fits.
+func humanizeBytes(size uint64, base float64, units []string) string {
+	value, unit := float64(size), 0
+	for value >= base && unit < len(units)-1 {
+		value /= base
+		unit++
+	}
+	if unit == 0 {
+		return strconv.FormatUint(size, 10) + " " + units[0]
+	}
+	text := strconv.FormatFloat(value, 'f', 1, 64)
+	if text == strconv.FormatFloat(base, 'f', 1, 64) && unit < len(units)-1 {
+		text, unit = "1.0", unit+1
+	}
+	return strings.TrimSuffix(text, ".0") + " " + units[unit]
+}
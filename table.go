@@ -0,0 +1,581 @@
+package unify4g
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Table holds tabular data and renders it as ASCII, Unicode box-drawing, Markdown, CSV or HTML.
+//
+// Column widths are measured with `DisplayWidth`, so accented, CJK and emoji content stays aligned.
+// Configuration methods return the table itself so that calls can be chained.
+type Table struct {
+	headers  []string
+	rows     [][]string
+	aligns   map[int]TableAlign
+	maxWidth int
+	overflow TableOverflow
+	style    *Style
+}
+
+// tableBorder holds the characters used to draw the frame of a text table.
+type tableBorder struct {
+	horizontal, vertical                  string
+	topLeft, topMiddle, topRight          string
+	middleLeft, middleMiddle, middleRight string
+	bottomLeft, bottomMiddle, bottomRight string
+}
+
+var (
+	asciiTableBorder   = tableBorder{"-", "|", "+", "+", "+", "+", "+", "+", "+", "+", "+"}
+	unicodeTableBorder = tableBorder{"─", "│", "┌", "┬", "┐", "├", "┼", "┤", "└", "┴", "┘"}
+)
+
+// NewTable creates an empty table with the given column headers.
+//
+// Parameters:
+//   - `headers`: The column titles (optional). A table without headers is rendered without a header row,
+//     except in Markdown, which requires one and gets an empty header instead.
+//
+// Returns:
+//   - A pointer to a new Table.
+//
+// Example:
+//
+//	table := NewTable("Name", "Age").
+//		AddRow("Alice", 30).
+//		AddRow("Bob", 4).
+//		SetAlign(1, TableAlignRight)
+//	fmt.Println(table.Render(TableFormatUnicode))
+//	// ┌───────┬─────┐
+//	// │ Name  │ Age │
+//	// ├───────┼─────┤
+//	// │ Alice │  30 │
+//	// │ Bob   │   4 │
+//	// └───────┴─────┘
+func NewTable(headers ...string) *Table {
+	return &Table{headers: append([]string{}, headers...), aligns: make(map[int]TableAlign)}
+}
+
+// NewTableFromRows creates a table from a matrix of strings, using the first row as headers.
+//
+// Parameters:
+//   - `rows`: The header row followed by the data rows.
+//
+// Returns:
+//   - A pointer to a new Table.
+//
+// Example:
+//
+//	table := NewTableFromRows([][]string{{"Key", "Value"}, {"env", "prod"}})
+func NewTableFromRows(rows [][]string) *Table {
+	if len(rows) == 0 {
+		return NewTable()
+	}
+	table := NewTable(rows[0]...)
+	for _, row := range rows[1:] {
+		table.rows = append(table.rows, append([]string{}, row...))
+	}
+	return table
+}
+
+// NewTableFromMaps creates a table with one row per map. The headers are the union of the keys
+// of all maps, sorted alphabetically; missing values are rendered as empty cells.
+//
+// Parameters:
+//   - `rows`: The rows, keyed by column name.
+//
+// Returns:
+//   - A pointer to a new Table.
+//
+// Example:
+//
+//	table := NewTableFromMaps([]map[string]interface{}{
+//		{"name": "Alice", "age": 30},
+//		{"name": "Bob"},
+//	})
+//	// headers will be ["age", "name"]
+func NewTableFromMaps(rows []map[string]interface{}) *Table {
+	keys := make(map[string]struct{})
+	for _, row := range rows {
+		for key := range row {
+			keys[key] = struct{}{}
+		}
+	}
+	headers := make([]string, 0, len(keys))
+	for key := range keys {
+		headers = append(headers, key)
+	}
+	sort.Strings(headers)
+	table := NewTable(headers...)
+	for _, row := range rows {
+		cells := make([]string, len(headers))
+		for i, header := range headers {
+			if value, exists := row[header]; exists {
+				cells[i] = formatTableCell(value)
+			}
+		}
+		table.rows = append(table.rows, cells)
+	}
+	return table
+}
+
+// NewTableFromStructs creates a table with one row per struct of the slice `rows`.
+//
+// Each exported field becomes a column titled with its `table` tag, or with the field name when the
+// tag is absent. Fields tagged `table:"-"` are skipped. Nil pointer elements produce empty rows.
+//
+// Parameters:
+//   - `rows`: A slice or array of structs or pointers to structs.
+//
+// Returns:
+//   - A pointer to a new Table.
+//   - An error if `rows` is not a slice or array of structs.
+//
+// Example:
+//
+//	type User struct {
+//		Name  string `table:"Name"`
+//		Email string `table:"E-mail"`
+//		token string
+//	}
+//	table, err := NewTableFromStructs([]User{{Name: "Alice", Email: "alice@example.com"}})
+func NewTableFromStructs(rows interface{}) (*Table, error) {
+	value := reflect.ValueOf(rows)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, fmt.Errorf("table rows must be a slice of structs, got %T", rows)
+	}
+	elemType := value.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("table rows must be a slice of structs, got %T", rows)
+	}
+	var headers []string
+	var fields []int
+	for i := 0; i < elemType.NumField(); i++ {
+		field := elemType.Field(i)
+		tag := field.Tag.Get("table")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		if tag == "" {
+			tag = field.Name
+		}
+		headers = append(headers, tag)
+		fields = append(fields, i)
+	}
+	table := NewTable(headers...)
+	for i := 0; i < value.Len(); i++ {
+		item := value.Index(i)
+		cells := make([]string, len(fields))
+		if item.Kind() == reflect.Ptr {
+			if item.IsNil() {
+				table.rows = append(table.rows, cells)
+				continue
+			}
+			item = item.Elem()
+		}
+		for j, index := range fields {
+			cells[j] = formatTableCell(item.Field(index).Interface())
+		}
+		table.rows = append(table.rows, cells)
+	}
+	return table, nil
+}
+
+// AddRow appends a row to the table. Values are converted to text: strings are kept as is,
+// numbers, booleans and `fmt.Stringer` values are formatted with `fmt`, nil becomes an empty cell,
+// and other values (maps, slices, structs) are serialized to JSON.
+//
+// Parameters:
+//   - `cells`: The values of the row, in column order.
+//
+// Returns:
+//   - The table, for chaining.
+func (table *Table) AddRow(cells ...interface{}) *Table {
+	table.rows = append(table.rows, Map(cells, formatTableCell))
+	return table
+}
+
+// SetAlign sets the horizontal alignment of a column.
+//
+// Parameters:
+//   - `column`: The zero-based column index.
+//   - `align`: The alignment to apply.
+//
+// Returns:
+//   - The table, for chaining.
+func (table *Table) SetAlign(column int, align TableAlign) *Table {
+	table.aligns[column] = align
+	return table
+}
+
+// SetMaxWidth limits the display width of every column. Wider cells are cut and end with "...", or
+// wrapped, depending on `SetOverflow`; widths under 4 leave no room for the ellipsis and only cut the
+// cells. The limit applies to the ASCII, Unicode and Markdown formats; CSV and HTML always contain
+// the full values.
+//
+// Parameters:
+//   - `width`: The maximum column width; 0 or less removes the limit.
+//
+// Returns:
+//   - The table, for chaining.
+func (table *Table) SetMaxWidth(width int) *Table {
+	table.maxWidth = width
+	return table
+}
+
+// SetOverflow chooses how cells wider than the maximum column width are handled.
+//
+// Parameters:
+//   - `overflow`: `TableOverflowTruncate` (default) or `TableOverflowWrap`.
+//
+// Returns:
+//   - The table, for chaining.
+func (table *Table) SetOverflow(overflow TableOverflow) *Table {
+	table.overflow = overflow
+	return table
+}
+
+// SetStyle enables colored output for the ASCII and Unicode formats, using the same palette as `Color`:
+// headers use `Key`, numbers use `Number`, booleans use `True`/`False`, "null" uses `Null` and other
+// text uses `String`. Pass `TerminalStyle` for the default terminal colors, or nil to disable colors.
+//
+// Parameters:
+//   - `style`: The color style, or nil.
+//
+// Returns:
+//   - The table, for chaining.
+func (table *Table) SetStyle(style *Style) *Table {
+	table.style = style
+	return table
+}
+
+// SortByColumn sorts the rows by the text of a column, using `NaturalCompare` so that numbers and
+// names with numeric parts sort as expected. The sort is stable.
+//
+// Parameters:
+//   - `column`: The zero-based column index.
+//   - `descending`: Whether to sort in descending order.
+//
+// Returns:
+//   - The table, for chaining.
+func (table *Table) SortByColumn(column int, descending bool) *Table {
+	slices.SortStableFunc(table.rows, func(a, b []string) int {
+		c := NaturalCompare(tableCell(a, column), tableCell(b, column))
+		if descending {
+			return -c
+		}
+		return c
+	})
+	return table
+}
+
+// Render formats the table.
+//
+// Parameters:
+//   - `format`: The output format.
+//
+// Returns:
+//   - The rendered table. Text formats end with a newline.
+//
+// Example:
+//
+//	table := NewTable("Name", "Age").AddRow("Alice", 30)
+//	fmt.Print(table.Render(TableFormatMarkdown))
+//	// | Name  | Age |
+//	// | ----- | --- |
+//	// | Alice | 30  |
+func (table *Table) Render(format TableFormat) string {
+	switch format {
+	case TableFormatUnicode:
+		return table.renderText(unicodeTableBorder)
+	case TableFormatMarkdown:
+		return table.renderMarkdown()
+	case TableFormatCSV:
+		return table.renderCSV()
+	case TableFormatHTML:
+		return table.renderHTML()
+	default:
+		return table.renderText(asciiTableBorder)
+	}
+}
+
+// String renders the table in the ASCII format.
+func (table *Table) String() string {
+	return table.Render(TableFormatASCII)
+}
+
+// columns returns the number of columns of the table.
+func (table *Table) columns() int {
+	count := len(table.headers)
+	for _, row := range table.rows {
+		count = max(count, len(row))
+	}
+	return count
+}
+
+// lines splits a cell into display lines, applying the maximum width and overflow mode.
+func (table *Table) lines(cell string) []string {
+	if table.maxWidth <= 0 {
+		return strings.Split(cell, "\n")
+	}
+	if table.overflow == TableOverflowWrap {
+		return strings.Split((&lineWrapper{width: table.maxWidth}).wrap(cell), "\n")
+	}
+	return Map(strings.Split(cell, "\n"), func(line string) string { return truncateDisplay(line, table.maxWidth, "...") })
+}
+
+// align returns the alignment of a column.
+func (table *Table) align(column int) TableAlign {
+	return table.aligns[column]
+}
+
+// colorize wraps `text` with the color of the style matching its content.
+func (table *Table) colorize(text string, header bool) string {
+	if table.style == nil || text == "" {
+		return text
+	}
+	color := table.style.String
+	switch {
+	case header:
+		color = table.style.Key
+	case text == "true":
+		color = table.style.True
+	case text == "false":
+		color = table.style.False
+	case text == "null":
+		color = table.style.Null
+	default:
+		if _, err := strconv.ParseFloat(text, 64); err == nil {
+			color = table.style.Number
+		}
+	}
+	return color[0] + text + color[1]
+}
+
+// renderText draws the table with the given border characters.
+func (table *Table) renderText(border tableBorder) string {
+	columns := table.columns()
+	if columns == 0 {
+		return ""
+	}
+	split := func(row []string) [][]string {
+		cells := make([][]string, columns)
+		for i := range cells {
+			cells[i] = table.lines(tableCell(row, i))
+		}
+		return cells
+	}
+	header := split(table.headers)
+	body := Map(table.rows, split)
+	widths := make([]int, columns)
+	for _, row := range append([][][]string{header}, body...) {
+		for i, cell := range row {
+			for _, line := range cell {
+				widths[i] = max(widths[i], DisplayWidth(line))
+			}
+		}
+	}
+	var builder strings.Builder
+	rule := func(left, middle, right string) {
+		builder.WriteString(left)
+		for i, width := range widths {
+			if i > 0 {
+				builder.WriteString(middle)
+			}
+			builder.WriteString(strings.Repeat(border.horizontal, width+2))
+		}
+		builder.WriteString(right)
+		builder.WriteByte('\n')
+	}
+	writeRow := func(cells [][]string, isHeader bool) {
+		height := 1
+		for _, cell := range cells {
+			height = max(height, len(cell))
+		}
+		for line := 0; line < height; line++ {
+			builder.WriteString(border.vertical)
+			for i, cell := range cells {
+				text := ""
+				if line < len(cell) {
+					text = cell[line]
+				}
+				builder.WriteByte(' ')
+				builder.WriteString(padDisplay(table.colorize(text, isHeader), widths[i], table.align(i)))
+				builder.WriteByte(' ')
+				builder.WriteString(border.vertical)
+			}
+			builder.WriteByte('\n')
+		}
+	}
+	rule(border.topLeft, border.topMiddle, border.topRight)
+	if len(table.headers) > 0 {
+		writeRow(header, true)
+		rule(border.middleLeft, border.middleMiddle, border.middleRight)
+	}
+	for _, row := range body {
+		writeRow(row, false)
+	}
+	rule(border.bottomLeft, border.bottomMiddle, border.bottomRight)
+	return builder.String()
+}
+
+// renderMarkdown formats the table as a GitHub Flavored Markdown table.
+func (table *Table) renderMarkdown() string {
+	columns := table.columns()
+	if columns == 0 {
+		return ""
+	}
+	format := func(row []string) []string {
+		cells := make([]string, columns)
+		for i := range cells {
+			lines := Map(table.lines(tableCell(row, i)), func(line string) string {
+				return strings.ReplaceAll(line, "|", `\|`)
+			})
+			cells[i] = strings.Join(lines, "<br>")
+		}
+		return cells
+	}
+	header := format(table.headers)
+	body := Map(table.rows, format)
+	widths := make([]int, columns)
+	for _, row := range append([][]string{header}, body...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], DisplayWidth(cell), 3)
+		}
+	}
+	var builder strings.Builder
+	writeRow := func(cells []string) {
+		builder.WriteString("|")
+		for i, cell := range cells {
+			builder.WriteString(" " + padDisplay(cell, widths[i], table.align(i)) + " |")
+		}
+		builder.WriteByte('\n')
+	}
+	writeRow(header)
+	builder.WriteString("|")
+	for i, width := range widths {
+		switch table.align(i) {
+		case TableAlignRight:
+			builder.WriteString(" " + strings.Repeat("-", width-1) + ": |")
+		case TableAlignCenter:
+			builder.WriteString(" :" + strings.Repeat("-", width-2) + ": |")
+		default:
+			builder.WriteString(" " + strings.Repeat("-", width) + " |")
+		}
+	}
+	builder.WriteByte('\n')
+	for _, row := range body {
+		writeRow(row)
+	}
+	return builder.String()
+}
+
+// renderCSV formats the table as comma-separated values.
+func (table *Table) renderCSV() string {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+	columns := table.columns()
+	record := func(row []string) []string {
+		cells := make([]string, columns)
+		for i := range cells {
+			cells[i] = tableCell(row, i)
+		}
+		return cells
+	}
+	if len(table.headers) > 0 {
+		_ = writer.Write(record(table.headers))
+	}
+	for _, row := range table.rows {
+		_ = writer.Write(record(row))
+	}
+	writer.Flush()
+	return builder.String()
+}
+
+// renderHTML formats the table as an HTML table element.
+func (table *Table) renderHTML() string {
+	columns := table.columns()
+	var builder strings.Builder
+	writeRow := func(row []string, tag string) {
+		builder.WriteString("    <tr>")
+		for i := 0; i < columns; i++ {
+			builder.WriteString("<" + tag)
+			switch table.align(i) {
+			case TableAlignRight:
+				builder.WriteString(` style="text-align: right"`)
+			case TableAlignCenter:
+				builder.WriteString(` style="text-align: center"`)
+			}
+			builder.WriteString(">")
+			builder.WriteString(strings.ReplaceAll(html.EscapeString(tableCell(row, i)), "\n", "<br>"))
+			builder.WriteString("</" + tag + ">")
+		}
+		builder.WriteString("</tr>\n")
+	}
+	builder.WriteString("<table>\n")
+	if len(table.headers) > 0 {
+		builder.WriteString("  <thead>\n")
+		writeRow(table.headers, "th")
+		builder.WriteString("  </thead>\n")
+	}
+	builder.WriteString("  <tbody>\n")
+	for _, row := range table.rows {
+		writeRow(row, "td")
+	}
+	builder.WriteString("  </tbody>\n</table>\n")
+	return builder.String()
+}
+
+// tableCell returns the cell of `row` at `column`, or an empty string for missing cells.
+func tableCell(row []string, column int) string {
+	if column < len(row) {
+		return row[column]
+	}
+	return ""
+}
+
+// formatTableCell converts a value to the text of a table cell. Nil pointers give an empty cell,
+// without calling their String method, which may not handle nil receivers.
+func formatTableCell(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr && v.IsNil() {
+		return ""
+	}
+	if stringer, ok := value.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct, reflect.Ptr:
+		return JsonN(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// padDisplay pads `text` with spaces to `width` display columns according to `align`.
+func padDisplay(text string, width int, align TableAlign) string {
+	gap := width - DisplayWidth(text)
+	if gap <= 0 {
+		return text
+	}
+	switch align {
+	case TableAlignRight:
+		return strings.Repeat(" ", gap) + text
+	case TableAlignCenter:
+		return strings.Repeat(" ", gap/2) + text + strings.Repeat(" ", gap-gap/2)
+	default:
+		return text + strings.Repeat(" ", gap)
+	}
+}
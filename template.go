@@ -0,0 +1,339 @@
+package unify4g
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Template is a compiled text template whose `${...}` placeholders are replaced with values looked
+// up in a map or a struct. Compile a template once with `NewTemplate` and execute it many times;
+// a Template is safe for concurrent use.
+//
+// A placeholder holds a path, an optional default value introduced by ":-", and optional filters
+// separated by '|':
+//
+//	${user.name}                  value at the path, resolved like `FieldByPath`
+//	${user.name:-guest}           "guest" when the value is missing, nil or an empty string
+//	${title|abbrev:20|upper}      filters applied from left to right
+//	$${literal}                   the text "${literal}"
+//
+// Default values cannot contain '|' or '}'.
+//
+// Filters:
+//   - Text: upper, lower, trim, title (`TitleCase`), camel, pascal, snake, kebab, slug (`Slugify`).
+//   - Length: abbrev:N (`Abbreviate`), truncate:N (`Truncate`, by display width).
+//   - Encoding: json (`JsonN` of the raw value).
+//   - Escaping: html, shell, sql (see `TemplateEscape`), and raw, which disables the escaping
+//     configured for the template when it is the last filter. The configured escaping is still
+//     applied after an escaping filter of another mode, or followed by other filters, and only
+//     once when the last filter applies it.
+type Template struct {
+	source   string
+	segments []templateSegment
+	options  TemplateOptionsConfig
+}
+
+// templateSegment is either literal text or a placeholder of a Template.
+type templateSegment struct {
+	literal     string
+	placeholder *templatePlaceholder
+}
+
+// templatePlaceholder is a compiled `${...}` placeholder.
+type templatePlaceholder struct {
+	source       string
+	path         []pathSegment
+	defaultValue string
+	hasDefault   bool
+	filters      []func(interface{}) interface{}
+	escape       TemplateEscape
+	escaped      bool
+	raw          bool
+}
+
+// templateEscapeFilters maps the escaping filters to the escaping they apply.
+var templateEscapeFilters = map[string]TemplateEscape{
+	"html":  TemplateEscapeHTML,
+	"shell": TemplateEscapeShell,
+	"sql":   TemplateEscapeSQLIdentifier,
+}
+
+// templateFilterFactories builds the filters available in placeholders from their argument.
+var templateFilterFactories = map[string]func(arg string) (func(interface{}) interface{}, error){
+	"upper":    templateTextFilter(strings.ToUpper),
+	"lower":    templateTextFilter(strings.ToLower),
+	"trim":     templateTextFilter(strings.TrimSpace),
+	"title":    templateTextFilter(TitleCase),
+	"camel":    templateTextFilter(CamelCase),
+	"pascal":   templateTextFilter(PascalCase),
+	"snake":    templateTextFilter(SnakeCase),
+	"kebab":    templateTextFilter(KebabCase),
+	"slug":     templateTextFilter(Slugify),
+	"html":     templateTextFilter(func(value string) string { return escapeTemplateValue(value, TemplateEscapeHTML) }),
+	"shell":    templateTextFilter(func(value string) string { return escapeTemplateValue(value, TemplateEscapeShell) }),
+	"sql":      templateTextFilter(func(value string) string { return escapeTemplateValue(value, TemplateEscapeSQLIdentifier) }),
+	"raw":      templateTextFilter(func(value string) string { return value }),
+	"abbrev":   templateWidthFilter(Abbreviate),
+	"truncate": templateWidthFilter(Truncate),
+	"json": func(arg string) (func(interface{}) interface{}, error) {
+		return func(value interface{}) interface{} { return JsonN(value) }, nil
+	},
+}
+
+// NewTemplate compiles `text` into a Template.
+//
+// Parameters:
+//   - `text`: The template text.
+//   - `options`: The execution configuration; nil uses `DefaultTemplateOptionsConfig`.
+//
+// Returns:
+//   - The compiled template.
+//   - An error if a placeholder is not closed, has an invalid path, or uses an unknown filter or an invalid filter argument.
+//
+// Example:
+//
+//	greeting, err := NewTemplate("Hello ${user.name:-guest|title}!", nil)
+//	if err != nil {
+//		return err
+//	}
+//	message, err := greeting.Execute(map[string]interface{}{"user": map[string]interface{}{"name": "ada lovelace"}})
+//	// message will be "Hello Ada Lovelace!"
+func NewTemplate(text string, options *TemplateOptionsConfig) (*Template, error) {
+	if options == nil {
+		options = DefaultTemplateOptionsConfig
+	}
+	template := &Template{source: text, options: *options}
+	var literal strings.Builder
+	for i := 0; i < len(text); {
+		switch {
+		case strings.HasPrefix(text[i:], "$${"):
+			literal.WriteString("${")
+			i += 3
+		case strings.HasPrefix(text[i:], "${"):
+			end := strings.IndexByte(text[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("template: unclosed placeholder at offset %d", i)
+			}
+			placeholder, err := compilePlaceholder(text[i : i+end+1])
+			if err != nil {
+				return nil, err
+			}
+			if literal.Len() > 0 {
+				template.segments = append(template.segments, templateSegment{literal: literal.String()})
+				literal.Reset()
+			}
+			template.segments = append(template.segments, templateSegment{placeholder: placeholder})
+			i += end + 1
+		default:
+			literal.WriteByte(text[i])
+			i++
+		}
+	}
+	if literal.Len() > 0 {
+		template.segments = append(template.segments, templateSegment{literal: literal.String()})
+	}
+	return template, nil
+}
+
+// Execute renders the template with the values found in `data`, a map, a struct, or a pointer to one.
+//
+// Parameters:
+//   - `data`: The root of the placeholder paths.
+//
+// Returns:
+//   - The rendered text.
+//   - An error if the template is strict and a value without default is missing.
+//
+// Example:
+//
+//	command, _ := NewTemplate("git clone ${repo} ${dir}", &TemplateOptionsConfig{Escape: TemplateEscapeShell})
+//	line, err := command.Execute(map[string]string{"repo": "https://example.com/x.git", "dir": "my dir"})
+//	// line will be "git clone 'https://example.com/x.git' 'my dir'"
+func (template *Template) Execute(data interface{}) (string, error) {
+	var builder strings.Builder
+	builder.Grow(len(template.source))
+	for _, segment := range template.segments {
+		if segment.placeholder == nil {
+			builder.WriteString(segment.literal)
+			continue
+		}
+		value, err := template.render(segment.placeholder, data)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(value)
+	}
+	return builder.String(), nil
+}
+
+// String returns the source text of the template.
+func (template *Template) String() string {
+	return template.source
+}
+
+// Interpolate replaces the `${...}` placeholders of `text` with the values found in `data`, using
+// `DefaultTemplateOptionsConfig`. See `Template` for the placeholder syntax; use `NewTemplate` to
+// compile a template that is rendered repeatedly.
+//
+// Parameters:
+//   - `text`: The template text.
+//   - `data`: The root of the placeholder paths: a map, a struct, or a pointer to one.
+//
+// Returns:
+//   - The rendered text.
+//   - An error if the template cannot be compiled.
+//
+// Example:
+//
+//	message, err := Interpolate("Hello ${user.name:-guest}, you have ${count} new ${noun|upper}", map[string]interface{}{
+//		"user":  map[string]interface{}{},
+//		"count": 3,
+//		"noun":  "messages",
+//	})
+//	// message will be "Hello guest, you have 3 new MESSAGES"
+func Interpolate(text string, data interface{}) (string, error) {
+	return InterpolateWith(text, data, nil)
+}
+
+// InterpolateWith replaces the `${...}` placeholders of `text` like `Interpolate`, using the given options.
+//
+// Parameters:
+//   - `text`: The template text.
+//   - `data`: The root of the placeholder paths.
+//   - `options`: The configuration; nil uses `DefaultTemplateOptionsConfig`.
+//
+// Returns:
+//   - The rendered text.
+//   - An error if the template cannot be compiled, or if it is strict and a value is missing.
+//
+// Example:
+//
+//	page, err := InterpolateWith("<h1>${title}</h1>", map[string]string{"title": "Tom & Jerry"}, &TemplateOptionsConfig{Escape: TemplateEscapeHTML})
+//	// page will be "<h1>Tom &amp; Jerry</h1>"
+func InterpolateWith(text string, data interface{}, options *TemplateOptionsConfig) (string, error) {
+	template, err := NewTemplate(text, options)
+	if err != nil {
+		return "", err
+	}
+	return template.Execute(data)
+}
+
+// render returns the text inserted for `placeholder`.
+func (template *Template) render(placeholder *templatePlaceholder, data interface{}) (string, error) {
+	value, found := lookupTemplateValue(data, placeholder.path)
+	if !found || value == nil || value == "" {
+		switch {
+		case placeholder.hasDefault:
+			value = placeholder.defaultValue
+		case !found && template.options.Strict:
+			return "", fmt.Errorf("template: missing value for '%s'", placeholder.source)
+		}
+	}
+	for _, filter := range placeholder.filters {
+		value = filter(value)
+	}
+	text := templateText(value)
+	if placeholder.raw || (placeholder.escaped && placeholder.escape == template.options.Escape) {
+		return text, nil
+	}
+	return escapeTemplateValue(text, template.options.Escape), nil
+}
+
+// compilePlaceholder compiles `source`, a complete "${...}" placeholder.
+func compilePlaceholder(source string) (*templatePlaceholder, error) {
+	parts := strings.Split(source[2:len(source)-1], "|")
+	expression, defaultValue, hasDefault := strings.Cut(parts[0], ":-")
+	path := strings.TrimSpace(expression)
+	if path == "" {
+		return nil, fmt.Errorf("template: empty path in '%s'", source)
+	}
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("template: invalid path in '%s': %v", source, err)
+	}
+	placeholder := &templatePlaceholder{source: source, path: segments, defaultValue: defaultValue, hasDefault: hasDefault}
+	for _, part := range parts[1:] {
+		name, arg, _ := strings.Cut(strings.TrimSpace(part), ":")
+		factory, ok := templateFilterFactories[name]
+		if !ok {
+			return nil, fmt.Errorf("template: unknown filter '%s' in '%s'", name, source)
+		}
+		filter, err := factory(arg)
+		if err != nil {
+			return nil, fmt.Errorf("template: filter '%s' in '%s': %v", name, source, err)
+		}
+		placeholder.filters = append(placeholder.filters, filter)
+		placeholder.raw = name == "raw"
+		placeholder.escape, placeholder.escaped = templateEscapeFilters[name]
+	}
+	return placeholder, nil
+}
+
+// lookupTemplateValue returns the value found at `path` inside `data`, and whether it exists.
+func lookupTemplateValue(data interface{}, path []pathSegment) (interface{}, bool) {
+	value := reflect.ValueOf(data)
+	for _, segment := range path {
+		if !value.IsValid() {
+			return nil, false
+		}
+		var err error
+		if value, err = stepPath(value, segment, ""); err != nil {
+			return nil, false
+		}
+	}
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, true
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() || !value.CanInterface() {
+		return nil, false
+	}
+	return value.Interface(), true
+}
+
+// templateText converts a placeholder value to text: scalars with `ToStringE`, other values as JSON.
+func templateText(value interface{}) string {
+	if text, err := ToStringE(value); err == nil {
+		return text
+	}
+	return JsonN(value)
+}
+
+// templateTextFilter adapts a string transformation into a filter that takes no argument.
+func templateTextFilter(transform func(string) string) func(string) (func(interface{}) interface{}, error) {
+	return func(arg string) (func(interface{}) interface{}, error) {
+		if arg != "" {
+			return nil, fmt.Errorf("unexpected argument '%s'", arg)
+		}
+		return func(value interface{}) interface{} { return transform(templateText(value)) }, nil
+	}
+}
+
+// templateWidthFilter adapts a shortening function into a filter taking a positive width argument.
+func templateWidthFilter(shorten func(string, int) string) func(string) (func(interface{}) interface{}, error) {
+	return func(arg string) (func(interface{}) interface{}, error) {
+		width, err := strconv.Atoi(strings.TrimSpace(arg))
+		if err != nil || width <= 0 {
+			return nil, fmt.Errorf("expected a positive width, got '%s'", arg)
+		}
+		return func(value interface{}) interface{} { return shorten(templateText(value), width) }, nil
+	}
+}
+
+// escapeTemplateValue escapes `value` according to `mode`.
+func escapeTemplateValue(value string, mode TemplateEscape) string {
+	switch mode {
+	case TemplateEscapeHTML:
+		return EscapeHTML(value)
+	case TemplateEscapeShell:
+		return QuoteShell(value)
+	case TemplateEscapeSQLIdentifier:
+		return QuoteSQLIdentifier(value)
+	default:
+		return value
+	}
+}
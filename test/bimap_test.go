@@ -0,0 +1,46 @@
+package example_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestBiMap_PutAndLookup(t *testing.T) {
+	bi := unify4g.NewBiMap[string, int]()
+	if err := bi.Put("one", 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := bi.Put("uno", 1); err == nil {
+		t.Errorf("Expected error when binding a value to a second key")
+	}
+	if key, ok := bi.GetKey(1); !ok || key != "one" {
+		t.Errorf("Expected inverse lookup to return one but got %q", key)
+	}
+	bi.ForcePut("uno", 1)
+	if bi.ContainsKey("one") || bi.Size() != 1 {
+		t.Errorf("Expected ForcePut to evict the previous key")
+	}
+	if err := bi.Put("uno", 2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if bi.ContainsValue(1) {
+		t.Errorf("Expected old value to be released after key update")
+	}
+}
+
+func TestBiMap_Inverse(t *testing.T) {
+	bi := unify4g.NewBiMap[string, string]()
+	_ = bi.Put("US", "United States")
+	_ = bi.Put("VN", "Vietnam")
+	inverse := bi.Inverse()
+	if code, _ := inverse.Get("Vietnam"); code != "VN" {
+		t.Errorf("Expected VN but got %q", code)
+	}
+	bi.RemoveValue("Vietnam")
+	keys := bi.Keys()
+	sort.Strings(keys)
+	unify4g.AssertEqual(t, keys, []string{"US"})
+	unify4g.AssertEqual(t, inverse.Size(), 2)
+}
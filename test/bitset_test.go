@@ -0,0 +1,179 @@
+package example_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestBitSet_SetTestClear(t *testing.T) {
+	bitset := unify4g.NewBitSet(1, 64, 200)
+	if !bitset.Test(1) || !bitset.Test(64) || !bitset.Test(200) {
+		t.Errorf("Expected bits 1, 64 and 200 to be set")
+	}
+	if bitset.Test(2) || bitset.Test(-1) || bitset.Test(10000) {
+		t.Errorf("Expected bits 2, -1 and 10000 to be clear")
+	}
+	bitset.Clear(64)
+	if bitset.Test(64) {
+		t.Errorf("Expected bit 64 to be cleared")
+	}
+	bitset.Flip(64)
+	bitset.Flip(1)
+	if !bitset.Test(64) || bitset.Test(1) {
+		t.Errorf("Expected flip to toggle bits 1 and 64")
+	}
+	if bitset.Cardinality() != 2 {
+		t.Errorf("Expected cardinality to be %d but got %d", 2, bitset.Cardinality())
+	}
+}
+
+func TestBitSet_Ranges(t *testing.T) {
+	bitset := unify4g.NewBitSet()
+	bitset.SetRange(10, 140)
+	if bitset.Cardinality() != 130 {
+		t.Errorf("Expected cardinality to be %d but got %d", 130, bitset.Cardinality())
+	}
+	bitset.ClearRange(20, 30)
+	if bitset.Cardinality() != 120 || bitset.Test(25) {
+		t.Errorf("Expected bits 20..29 to be cleared")
+	}
+	bitset.FlipRange(0, 12)
+	if !bitset.Test(0) || bitset.Test(10) || bitset.Test(11) || !bitset.Test(12) {
+		t.Errorf("Unexpected bits after FlipRange: %s", bitset.String())
+	}
+}
+
+func TestBitSet_Iteration(t *testing.T) {
+	bitset := unify4g.NewBitSet(0, 1, 2, 70, 130)
+	var got []int
+	for i, ok := bitset.NextSet(0); ok; i, ok = bitset.NextSet(i + 1) {
+		got = append(got, i)
+	}
+	unify4g.AssertEqual(t, got, []int{0, 1, 2, 70, 130})
+	unify4g.AssertEqual(t, bitset.NextClear(0), 3)
+	unify4g.AssertEqual(t, bitset.NextClear(70), 71)
+	unify4g.AssertEqual(t, bitset.NextClear(500), 500)
+}
+
+func TestBitSet_Algebra(t *testing.T) {
+	a := unify4g.NewBitSet(1, 2, 3, 100)
+	b := unify4g.NewBitSet(2, 3, 4)
+	unify4g.AssertEqual(t, a.And(b).Slice(), []int{2, 3})
+	unify4g.AssertEqual(t, a.Or(b).Slice(), []int{1, 2, 3, 4, 100})
+	unify4g.AssertEqual(t, a.Xor(b).Slice(), []int{1, 4, 100})
+	unify4g.AssertEqual(t, a.AndNot(b).Slice(), []int{1, 100})
+	unify4g.AssertEqual(t, a.Slice(), []int{1, 2, 3, 100})
+}
+
+func TestBitSet_Serialization(t *testing.T) {
+	bitset := unify4g.NewBitSet(3, 5, 700)
+	decoded, err := unify4g.NewBitSetFromBase64(bitset.ToBase64())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !decoded.Equal(bitset) {
+		t.Errorf("Expected %s but got %s", bitset.String(), decoded.String())
+	}
+	if _, err := unify4g.NewBitSetFromBase64("AAA="); err == nil {
+		t.Errorf("Expected an error for a malformed payload")
+	}
+}
+
+func TestBitSet_HashSetConversion(t *testing.T) {
+	hash := unify4g.NewHashSet(4, 8, -1)
+	bitset := unify4g.NewBitSetFromHashSet(hash)
+	unify4g.AssertEqual(t, bitset.Slice(), []int{4, 8})
+	back := bitset.HashSet()
+	if back.Size() != 2 || !back.Contains(4) || !back.Contains(8) {
+		t.Errorf("Expected round-trip HashSet to contain 4 and 8")
+	}
+}
+
+func TestRoaringBitmap_Basic(t *testing.T) {
+	rb := unify4g.NewRoaringBitmap(1, 70000, 4000000000)
+	if !rb.Contains(70000) || rb.Contains(2) {
+		t.Errorf("Unexpected membership")
+	}
+	rb.AddRange(100, 10100)
+	if rb.Cardinality() != 10003 {
+		t.Errorf("Expected cardinality to be %d but got %d", 10003, rb.Cardinality())
+	}
+	rb.Remove(100)
+	if rb.Contains(100) || rb.Cardinality() != 10002 {
+		t.Errorf("Expected 100 to be removed")
+	}
+}
+
+func TestRoaringBitmap_Algebra(t *testing.T) {
+	a := unify4g.NewRoaringBitmap(1, 2, 3, 1<<20)
+	b := unify4g.NewRoaringBitmap(2, 3, 4)
+	unify4g.AssertEqual(t, a.And(b).Slice(), []uint32{2, 3})
+	unify4g.AssertEqual(t, a.Or(b).Slice(), []uint32{1, 2, 3, 4, 1 << 20})
+	unify4g.AssertEqual(t, a.Xor(b).Slice(), []uint32{1, 4, 1 << 20})
+	unify4g.AssertEqual(t, a.AndNot(b).Slice(), []uint32{1, 1 << 20})
+
+	evens, odds := unify4g.NewRoaringBitmap(), unify4g.NewRoaringBitmap()
+	for v := uint32(0); v < 6000; v += 2 {
+		evens.Add(v)
+		odds.Add(v + 1)
+	}
+	unify4g.AssertTrue(t, evens.And(odds).IsEmpty())
+	unify4g.AssertEqual(t, evens.Or(odds).Cardinality(), 6000)
+	unify4g.AssertEqual(t, evens.Xor(odds).Cardinality(), 6000)
+	unify4g.AssertEqual(t, evens.AndNot(odds).Slice(), evens.Slice())
+	dense := evens.Or(odds)
+	unify4g.AssertEqual(t, dense.And(evens).Slice(), evens.Slice())
+	unify4g.AssertEqual(t, dense.AndNot(evens).Slice(), odds.Slice())
+	unify4g.AssertEqual(t, dense.Xor(odds).Slice(), evens.Slice())
+}
+
+func TestRoaringBitmap_Serialization(t *testing.T) {
+	rb := unify4g.NewRoaringBitmap(7, 1<<31)
+	rb.AddRange(1<<16, 1<<16+5000)
+	data, _ := rb.MarshalBinary()
+	decoded := unify4g.NewRoaringBitmap()
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	unify4g.AssertEqual(t, decoded.Slice(), rb.Slice())
+	small := unify4g.NewRoaringBitmap(7, 1<<16)
+	unify4g.AssertEqual(t, unify4g.NewRoaringBitmapFromBitSet(small.BitSet()).Slice(), []uint32{7, 1 << 16})
+}
+
+func TestRoaringBitmap_UnmarshalMalformed(t *testing.T) {
+	container := func(key uint16, values ...uint16) []byte {
+		data := binary.LittleEndian.AppendUint16(nil, key)
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(values)))
+		for _, v := range values {
+			data = binary.LittleEndian.AppendUint16(data, v)
+		}
+		return data
+	}
+	encode := func(n uint32, containers ...[]byte) []byte {
+		data := binary.LittleEndian.AppendUint32(nil, n)
+		for _, c := range containers {
+			data = append(data, c...)
+		}
+		return data
+	}
+	valid := encode(2, container(1, 2, 5), container(3, 0))
+	unify4g.AssertNil(t, unify4g.NewRoaringBitmap().UnmarshalBinary(valid))
+
+	dense := binary.LittleEndian.AppendUint16(nil, 0)
+	dense = binary.LittleEndian.AppendUint32(dense, 5000)
+	dense = append(dense, make([]byte, 1024*8)...)
+	malformed := [][]byte{
+		encode(1<<31, container(1, 2)),
+		encode(2, container(3, 2), container(1, 2)),
+		encode(2, container(1, 2), container(1, 3)),
+		encode(1, container(1, 5, 2)),
+		encode(1, container(1, 2, 2)),
+		encode(1, container(1)),
+		encode(1, dense),
+	}
+	for _, data := range malformed {
+		unify4g.AssertNotNil(t, unify4g.NewRoaringBitmap().UnmarshalBinary(data))
+	}
+}
@@ -0,0 +1,83 @@
+package example_test
+
+import (
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestCasing_SplitWords(t *testing.T) {
+	cases := map[string][]string{
+		"HTTPServerID":      {"HTTP", "Server", "ID"},
+		"parse-json_v2 API": {"parse", "json", "v2", "API"},
+		"userName":          {"user", "Name"},
+		"UTF8Encoder":       {"UTF8", "Encoder"},
+		"JSONAPIURL":        {"JSON", "API", "URL"},
+		"IDEConfig":         {"IDE", "Config"},
+		"  __ ":             {},
+		"Straße öffnen":     {"Straße", "öffnen"},
+		"JSONAPIs":          {"JSON", "APIs"},
+		"SQLiteDB":          {"SQLite", "DB"},
+	}
+	for input, expected := range cases {
+		unify4g.AssertEqual(t, unify4g.SplitWords(input), expected)
+	}
+	converter := unify4g.NewCaseConverter().SetSplitDigits(true)
+	unify4g.AssertEqual(t, converter.Words("Version2Beta"), []string{"Version", "2", "Beta"})
+}
+
+func TestCasing_Conversions(t *testing.T) {
+	input := "HTTP server_id"
+	unify4g.AssertEqual(t, unify4g.CamelCase(input), "httpServerID")
+	unify4g.AssertEqual(t, unify4g.PascalCase(input), "HTTPServerID")
+	unify4g.AssertEqual(t, unify4g.SnakeCase(input), "http_server_id")
+	unify4g.AssertEqual(t, unify4g.ScreamingSnakeCase(input), "HTTP_SERVER_ID")
+	unify4g.AssertEqual(t, unify4g.KebabCase(input), "http-server-id")
+	unify4g.AssertEqual(t, unify4g.TrainCase(input), "HTTP-Server-ID")
+	unify4g.AssertEqual(t, unify4g.DotCase(input), "http.server.id")
+	unify4g.AssertEqual(t, unify4g.TitleCase(input), "HTTP Server ID")
+	unify4g.AssertEqual(t, unify4g.SnakeCase("CreatedAt"), "created_at")
+	unify4g.AssertEqual(t, unify4g.TrainCase("content_type"), "Content-Type")
+	unify4g.AssertEqual(t, unify4g.CamelCase(""), "")
+}
+
+func TestCasing_Acronyms(t *testing.T) {
+	plain := unify4g.NewCaseConverter().SetAcronyms()
+	unify4g.AssertEqual(t, plain.Camel("user_id"), "userId")
+	unify4g.AssertEqual(t, plain.Pascal("HTTPServer"), "HttpServer")
+
+	custom := unify4g.NewCaseConverter().AddAcronyms("GraphQL", "OAuth", "K8S")
+	unify4g.AssertEqual(t, custom.Pascal("graphql_endpoint"), "GraphQLEndpoint")
+	unify4g.AssertEqual(t, custom.Snake("GraphQLEndpoint"), "graphql_endpoint")
+	unify4g.AssertEqual(t, custom.Camel("oauth token"), "oauthToken")
+	unify4g.AssertEqual(t, custom.Title("oauth_token"), "OAuth Token")
+	unify4g.AssertEqual(t, custom.Snake("K8SCluster"), "k8s_cluster")
+}
+
+func TestCasing_RoundTrip(t *testing.T) {
+	converter := unify4g.NewCaseConverter().AddAcronyms("GraphQL")
+	inputs := []string{"HTTPServerID", "user_profile_url", "utf8Encoder", "GraphQLEndpoint", "json-api-url", "x.request.id", "Version2Beta"}
+	conversions := []func(string) string{converter.Camel, converter.Pascal, converter.Snake, converter.ScreamingSnake, converter.Kebab, converter.Train, converter.Dot, converter.Title}
+	for _, input := range inputs {
+		snake := converter.Snake(input)
+		for _, convert := range conversions {
+			unify4g.AssertEqual(t, converter.Snake(convert(input)), snake)
+		}
+	}
+}
+
+func TestCasing_PluralAcronyms(t *testing.T) {
+	cases := map[string]string{
+		"URLs":        "urls",
+		"userIDs":     "user_ids",
+		"listAPIs":    "list_apis",
+		"IPv4Address": "ipv4_address",
+		"userIDsList": "user_ids_list",
+	}
+	for input, expected := range cases {
+		unify4g.AssertEqual(t, unify4g.SnakeCase(input), expected)
+	}
+	unify4g.AssertEqual(t, unify4g.CamelCase("user_ids"), "userIDs")
+	unify4g.AssertEqual(t, unify4g.PascalCase("list_apis"), "ListAPIs")
+	unify4g.AssertEqual(t, unify4g.Humanize("userIDs"), "User IDs")
+}
@@ -0,0 +1,93 @@
+package example_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+type castLevel int
+
+func TestCast_Numbers(t *testing.T) {
+	n := 5
+	values := []interface{}{5, int8(5), uint16(5), 5.0, "5", " 5 ", json.Number("5"), "5.0", &n, castLevel(5)}
+	for _, v := range values {
+		got, err := unify4g.ToIntE(v)
+		unify4g.AssertNil(t, err)
+		unify4g.AssertEqual(t, got, 5)
+	}
+	for _, v := range []interface{}{2.5, "abc", "", []int{1}, uint64(1 << 63)} {
+		_, err := unify4g.ToInt64E(v)
+		unify4g.AssertNotNil(t, err)
+	}
+	unify4g.AssertEqual(t, unify4g.ToInt("x", 42), 42)
+	unify4g.AssertEqual(t, unify4g.ToInt("x"), 0)
+	unify4g.AssertEqual(t, unify4g.ToInt(nil, 42), 0)
+	unify4g.AssertEqual(t, unify4g.ToInt64(true), int64(1))
+
+	f, err := unify4g.ToFloat64E(json.Number("3.25"))
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, f, 3.25)
+	unify4g.AssertEqual(t, unify4g.ToFloat64("1e3"), 1000.0)
+	unify4g.AssertEqual(t, unify4g.ToFloat64(map[string]int{}, -1), -1.0)
+}
+
+func TestCast_BoolAndString(t *testing.T) {
+	for _, v := range []interface{}{true, "TRUE", "yes", "On", 1, 0.5, json.Number("2")} {
+		unify4g.AssertTrue(t, unify4g.ToBool(v))
+	}
+	for _, v := range []interface{}{false, "false", "no", "off", 0, nil} {
+		unify4g.AssertFalse(t, unify4g.ToBool(v, true))
+	}
+	_, err := unify4g.ToBoolE("maybe")
+	unify4g.AssertNotNil(t, err)
+
+	unify4g.AssertEqual(t, unify4g.ToString(3.50), "3.5")
+	unify4g.AssertEqual(t, unify4g.ToString(uint8(7)), "7")
+	unify4g.AssertEqual(t, unify4g.ToString([]byte("data")), "data")
+	unify4g.AssertEqual(t, unify4g.ToString(castLevel(2)), "2")
+	unify4g.AssertEqual(t, unify4g.ToString(time.Second), "1s")
+	unify4g.AssertEqual(t, unify4g.ToString(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)), "2024-01-02T00:00:00Z")
+	unify4g.AssertEqual(t, unify4g.ToString(struct{}{}, "n/a"), "n/a")
+}
+
+func TestCast_DurationAndTime(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.ToDuration("1m30s"), 90*time.Second)
+	unify4g.AssertEqual(t, unify4g.ToDuration(int64(time.Millisecond)), time.Millisecond)
+	unify4g.AssertEqual(t, unify4g.ToDuration("1000"), time.Microsecond)
+	unify4g.AssertEqual(t, unify4g.ToDuration("soon", time.Hour), time.Hour)
+
+	expected := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, v := range []interface{}{"2024-03-01", "2024-03-01T00:00:00Z", "2024-03-01 00:00:00", expected.Unix(), json.Number("1709251200")} {
+		got, err := unify4g.ToTimeE(v)
+		unify4g.AssertNil(t, err)
+		unify4g.AssertTrue(t, got.Equal(expected))
+	}
+	_, err := unify4g.ToTimeE("yesterday")
+	unify4g.AssertNotNil(t, err)
+}
+
+func TestCast_SliceAndMap(t *testing.T) {
+	ids, err := unify4g.ToSliceE[int]([]interface{}{1.0, "2", json.Number("3")})
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, ids, []int{1, 2, 3})
+	_, err = unify4g.ToSliceE[int]([]interface{}{1, "two"})
+	unify4g.AssertNotNil(t, err)
+	unify4g.AssertEqual(t, unify4g.ToSlice[string]([]int{1, 2}), []string{"1", "2"})
+	unify4g.AssertEqual(t, unify4g.ToSlice[castLevel]([]interface{}{1, 2}), []castLevel{1, 2})
+	unify4g.AssertEqual(t, unify4g.ToSlice("abc", []int{}), []int{})
+
+	var decoded map[string]interface{}
+	unify4g.AssertNil(t, unify4g.UnmarshalFromStringN(`{"labels": {"a": 1}, "ports": [80, 443]}`, &decoded))
+	unify4g.AssertEqual(t, unify4g.ToSlice[int](decoded["ports"]), []int{80, 443})
+	unify4g.AssertEqual(t, unify4g.ToStringMap(decoded["labels"]), map[string]interface{}{"a": 1.0})
+	unify4g.AssertEqual(t, unify4g.ToStringMap(map[interface{}]interface{}{"a": 1, 2: "b"}), map[string]interface{}{"a": 1, "2": "b"})
+	unify4g.AssertEqual(t, unify4g.ToStringMap(`{"a": true}`), map[string]interface{}{"a": true})
+	unify4g.AssertEqual(t, unify4g.ToStringMap(struct {
+		Name string `json:"name"`
+	}{"x"}), map[string]interface{}{"name": "x"})
+	_, err = unify4g.ToStringMapE(42)
+	unify4g.AssertNotNil(t, err)
+}
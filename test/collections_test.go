@@ -0,0 +1,182 @@
+package example_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestGenericCounterparts_MatchReflective(t *testing.T) {
+	numbers := []int{1, 2, 3, 4, 5, 6, 7}
+	isEven := func(n int) bool { return n%2 == 0 }
+	isEvenN := func(v interface{}) bool { return v.(int)%2 == 0 }
+
+	first, ok := unify4g.FindFirst(numbers, isEven)
+	if !ok || first != unify4g.Find(numbers, isEvenN) {
+		t.Errorf("FindFirst mismatch: got %d", first)
+	}
+	if _, ok := unify4g.FindFirst(numbers, func(n int) bool { return n > 10 }); ok {
+		t.Errorf("Expected FindFirst to report no match")
+	}
+	unify4g.AssertEqual(t, unify4g.CountIf(numbers, isEven), unify4g.Count(numbers, isEvenN))
+	unify4g.AssertEqual(t, unify4g.RemoveIf(numbers, isEven), unify4g.RemoveN(numbers, isEvenN))
+	unify4g.AssertEqual(t, unify4g.SubSlice(numbers, -1, 3), unify4g.Slice(numbers, -1, 3))
+	unify4g.AssertEqual(t, unify4g.SubSlice(numbers, 5, 100), unify4g.Slice(numbers, 5, 100))
+	unify4g.AssertEqual(t, unify4g.SliceByIndices(numbers, []int{1, 3, 9, -1}), unify4g.SliceWithIndices(numbers, []int{1, 3, 9, -1}))
+
+	evens, odds := unify4g.PartitionSlice(numbers, isEven)
+	evensN, oddsN := unify4g.Partition(numbers, isEvenN)
+	unify4g.AssertEqual(t, evens, evensN)
+	unify4g.AssertEqual(t, odds, oddsN)
+
+	for _, positions := range []int{0, 2, 7, 9, -3} {
+		unify4g.AssertEqual(t, unify4g.RotateSliceLeft(numbers, positions), unify4g.RotateLeft(numbers, positions))
+	}
+	for _, positions := range []int{0, 2, 7, 9, -2, -9} {
+		unify4g.AssertEqual(t, unify4g.RotateSliceRight(numbers, positions), unify4g.RotateRight(numbers, positions))
+	}
+	unify4g.AssertEqual(t, unify4g.RotateSliceLeft([]int{}, 3), []int{})
+
+	concat := unify4g.FoldRight([]string{"a", "b", "c"}, func(acc string, s string) string { return acc + s }, "")
+	unify4g.AssertEqual(t, concat, "cba")
+}
+
+func TestZip2(t *testing.T) {
+	pairs := unify4g.Zip2([]int{1, 2, 3}, []string{"a", "b"})
+	unify4g.AssertEqual(t, pairs, []unify4g.Pair[int, string]{{First: 1, Second: "a"}, {First: 2, Second: "b"}})
+}
+
+func TestForEach(t *testing.T) {
+	sum := 0
+	unify4g.ForEach([]int{10, 20}, func(i int, v int) { sum += i + v })
+	unify4g.AssertEqual(t, sum, 31)
+}
+
+func benchmarkInput() []int {
+	numbers := make([]int, 10000)
+	for i := range numbers {
+		numbers[i] = i
+	}
+	return numbers
+}
+
+func BenchmarkFilterN(b *testing.B) {
+	numbers := benchmarkInput()
+	for i := 0; i < b.N; i++ {
+		_ = unify4g.FilterN(numbers, func(v interface{}) bool { return v.(int)%2 == 0 })
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	numbers := benchmarkInput()
+	for i := 0; i < b.N; i++ {
+		_ = unify4g.Filter(numbers, func(n int) bool { return n%2 == 0 })
+	}
+}
+
+func BenchmarkPartition(b *testing.B) {
+	numbers := benchmarkInput()
+	for i := 0; i < b.N; i++ {
+		_, _ = unify4g.Partition(numbers, func(v interface{}) bool { return v.(int)%2 == 0 })
+	}
+}
+
+func BenchmarkPartitionSlice(b *testing.B) {
+	numbers := benchmarkInput()
+	for i := 0; i < b.N; i++ {
+		_, _ = unify4g.PartitionSlice(numbers, func(n int) bool { return n%2 == 0 })
+	}
+}
+
+func BenchmarkRotateLeft(b *testing.B) {
+	numbers := benchmarkInput()
+	for i := 0; i < b.N; i++ {
+		_ = unify4g.RotateLeft(numbers, 123)
+	}
+}
+
+func BenchmarkRotateSliceLeft(b *testing.B) {
+	numbers := benchmarkInput()
+	for i := 0; i < b.N; i++ {
+		_ = unify4g.RotateSliceLeft(numbers, 123)
+	}
+}
+
+func BenchmarkZip(b *testing.B) {
+	numbers := benchmarkInput()
+	for i := 0; i < b.N; i++ {
+		_ = unify4g.Zip(numbers, numbers)
+	}
+}
+
+func BenchmarkZip2(b *testing.B) {
+	numbers := benchmarkInput()
+	for i := 0; i < b.N; i++ {
+		_ = unify4g.Zip2(numbers, numbers)
+	}
+}
+
+func BenchmarkSliceWithIndices(b *testing.B) {
+	numbers := benchmarkInput()
+	indices := []int{1, 10, 100, 1000, 5000}
+	for i := 0; i < b.N; i++ {
+		_ = unify4g.SliceWithIndices(numbers, indices)
+	}
+}
+
+func BenchmarkSliceByIndices(b *testing.B) {
+	numbers := benchmarkInput()
+	indices := []int{1, 10, 100, 1000, 5000}
+	for i := 0; i < b.N; i++ {
+		_ = unify4g.SliceByIndices(numbers, indices)
+	}
+}
+
+func BenchmarkFind(b *testing.B) {
+	numbers := benchmarkInput()
+	for i := 0; i < b.N; i++ {
+		_ = unify4g.Find(numbers, func(v interface{}) bool { return v.(int) == 9999 })
+	}
+}
+
+func BenchmarkFindFirst(b *testing.B) {
+	numbers := benchmarkInput()
+	for i := 0; i < b.N; i++ {
+		_, _ = unify4g.FindFirst(numbers, func(n int) bool { return n == 9999 })
+	}
+}
+
+type setUser struct {
+	ID   int
+	Name string
+}
+
+func TestSetOperationsBy(t *testing.T) {
+	byID := func(u setUser) int { return u.ID }
+	a := []setUser{{1, "a"}, {2, "b"}, {1, "a2"}, {3, "c"}}
+	b := []setUser{{3, "c2"}, {4, "d"}, {2, "b2"}}
+
+	unify4g.AssertEqual(t, unify4g.UniqueBy(a, byID), []setUser{{1, "a"}, {2, "b"}, {3, "c"}})
+	unify4g.AssertEqual(t, unify4g.IntersectBy(a, b, byID), []setUser{{2, "b"}, {3, "c"}})
+	unify4g.AssertEqual(t, unify4g.DifferenceBy(a, b, byID), []setUser{{1, "a"}})
+	unify4g.AssertEqual(t, unify4g.UnionBy(a, b, byID), []setUser{{1, "a"}, {2, "b"}, {3, "c"}, {4, "d"}})
+	unify4g.AssertEqual(t, unify4g.SymmetricDifferenceBy(a, b, byID), []setUser{{1, "a"}, {4, "d"}})
+	unify4g.AssertEqual(t, unify4g.SymmetricDifference([]int{1, 2, 3, 4, 1}, []int{3, 4, 5, 6}), []int{1, 2, 5, 6})
+}
+
+func TestSetOperationsFunc(t *testing.T) {
+	words := []string{"Go", "go", "Rust", "JAVA"}
+	unify4g.AssertEqual(t, unify4g.UniqueFunc(words, strings.EqualFold), []string{"Go", "Rust", "JAVA"})
+	unify4g.AssertEqual(t, unify4g.IntersectFunc(words, []string{"GO", "java"}, strings.EqualFold), []string{"Go", "JAVA"})
+	unify4g.AssertEqual(t, unify4g.DifferenceFunc(words, []string{"java"}, strings.EqualFold), []string{"Go", "Rust"})
+	unify4g.AssertEqual(t, unify4g.UnionFunc([]string{"Go"}, []string{"GO", "C"}, strings.EqualFold), []string{"Go", "C"})
+}
+
+func TestMultisetOperations(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.Frequencies([]string{"a", "b", "a"}), map[string]int{"a": 2, "b": 1})
+	unify4g.AssertEqual(t, unify4g.CountBy([]string{"go", "rust", "java", "c"}, func(s string) int { return len(s) }), map[int]int{2: 1, 4: 2, 1: 1})
+	unify4g.AssertEqual(t, unify4g.MultisetIntersect([]int{1, 1, 2, 3, 1}, []int{1, 1, 3, 3}), []int{1, 1, 3})
+	unify4g.AssertEqual(t, unify4g.MultisetDifference([]int{1, 1, 2, 3, 1}, []int{1, 3}), []int{1, 2, 1})
+	unify4g.AssertEqual(t, unify4g.MultisetIntersect([]int{}, []int{1}), []int{})
+}
@@ -0,0 +1,101 @@
+package example_test
+
+import (
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func sampleConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"db": map[string]interface{}{"host": "localhost", "port": 5432, "password": "secret"},
+		"servers": []interface{}{
+			map[string]interface{}{"name": "a", "port": 80},
+			map[string]interface{}{"name": "b", "port": 81},
+		},
+		"empty": map[string]interface{}{},
+		"log":   "debug",
+	}
+}
+
+func TestFlattenAndUnflattenMap(t *testing.T) {
+	flat := unify4g.FlattenMap(sampleConfig(), ".")
+	unify4g.AssertEqual(t, flat["db.host"], "localhost")
+	unify4g.AssertEqual(t, flat["servers.1.port"], 81)
+	unify4g.AssertEqual(t, flat["empty"], map[string]interface{}{})
+	unify4g.AssertEqual(t, len(flat), 9)
+
+	nested, err := unify4g.UnflattenMap(flat, ".")
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, nested, sampleConfig())
+
+	_, err = unify4g.UnflattenMap(map[string]interface{}{"a": 1, "a.b": 2}, ".")
+	unify4g.AssertNotNil(t, err)
+
+	flat = unify4g.FlattenMap(map[string]interface{}{"a": map[string]interface{}{"b": 1}}, "/")
+	unify4g.AssertEqual(t, flat, map[string]interface{}{"a/b": 1})
+}
+
+func TestGetSetDeleteIn(t *testing.T) {
+	config := sampleConfig()
+	port, ok := unify4g.GetIn(config, "servers[1].port")
+	unify4g.AssertTrue(t, ok)
+	unify4g.AssertEqual(t, port, 81)
+	name, _ := unify4g.GetIn(config, "servers.0.name")
+	unify4g.AssertEqual(t, name, "a")
+	_, ok = unify4g.GetIn(config, "servers[5].port")
+	unify4g.AssertFalse(t, ok)
+	_, ok = unify4g.GetIn(config, "db..host")
+	unify4g.AssertFalse(t, ok)
+
+	unify4g.AssertNil(t, unify4g.SetIn(config, "servers[2].port", 8080))
+	servers := config["servers"].([]interface{})
+	unify4g.AssertEqual(t, len(servers), 3)
+	unify4g.AssertEqual(t, servers[2], map[string]interface{}{"port": 8080})
+	unify4g.AssertNotNil(t, unify4g.SetIn(config, "servers[4].port", 8080))
+	unify4g.AssertNotNil(t, unify4g.SetIn(config, "servers[1000000000]", 1))
+	unify4g.AssertEqual(t, len(config["servers"].([]interface{})), 3)
+	unify4g.AssertNil(t, unify4g.SetIn(config, "cache.nodes[0]", "redis"))
+	unify4g.AssertEqual(t, config["cache"], map[string]interface{}{"nodes": []interface{}{"redis"}})
+	unify4g.AssertNotNil(t, unify4g.SetIn(config, "log.level", "info"))
+
+	unify4g.AssertTrue(t, unify4g.DeleteIn(config, "db.password"))
+	unify4g.AssertTrue(t, unify4g.DeleteIn(config, "servers[0]"))
+	name, _ = unify4g.GetIn(config, "servers[0].name")
+	unify4g.AssertEqual(t, name, "b")
+	unify4g.AssertFalse(t, unify4g.DeleteIn(config, "db.password"))
+}
+
+func TestPickAndOmitKeys(t *testing.T) {
+	config := sampleConfig()
+	picked := unify4g.PickKeys(config, "db.host", "l*")
+	unify4g.AssertEqual(t, picked, map[string]interface{}{"db": map[string]interface{}{"host": "localhost"}, "log": "debug"})
+
+	omitted := unify4g.OmitKeys(config, "*.password", "servers", "empty")
+	unify4g.AssertEqual(t, omitted, map[string]interface{}{"db": map[string]interface{}{"host": "localhost", "port": 5432}, "log": "debug"})
+	unify4g.AssertEqual(t, config["db"].(map[string]interface{})["password"], "secret")
+}
+
+func TestDeepMergeMapWith(t *testing.T) {
+	newTarget := func() map[string]interface{} {
+		return map[string]interface{}{"tags": []interface{}{"a", "b"}, "db": map[string]interface{}{"port": 80}}
+	}
+	source := map[string]interface{}{"tags": []interface{}{"b", "c"}, "db": map[string]interface{}{"host": "x"}}
+
+	target := newTarget()
+	unify4g.AssertNil(t, unify4g.DeepMergeMapWith(target, source, nil))
+	unify4g.AssertEqual(t, target["tags"], []interface{}{"b", "c"})
+	unify4g.AssertEqual(t, target["db"], map[string]interface{}{"port": 80, "host": "x"})
+
+	target = newTarget()
+	unify4g.AssertNil(t, unify4g.DeepMergeMapWith(target, source, &unify4g.MergeOptionsConfig{ArrayStrategy: unify4g.MergeArrayAppend}))
+	unify4g.AssertEqual(t, target["tags"], []interface{}{"a", "b", "b", "c"})
+
+	target = newTarget()
+	unify4g.AssertNil(t, unify4g.DeepMergeMapWith(target, source, &unify4g.MergeOptionsConfig{ArrayStrategy: unify4g.MergeArrayDedupe}))
+	unify4g.AssertEqual(t, target["tags"], []interface{}{"a", "b", "c"})
+
+	err := unify4g.DeepMergeMapWith(newTarget(), map[string]interface{}{"db": map[string]interface{}{"port": "80"}}, &unify4g.MergeOptionsConfig{StrictTypes: true})
+	unify4g.AssertNotNil(t, err)
+	unify4g.AssertEqual(t, err.Error(), "merge conflict at 'db.port': cannot merge string into int")
+}
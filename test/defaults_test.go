@@ -0,0 +1,120 @@
+package example_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+type defaultsDatabase struct {
+	URL      string `env:"URL,required"`
+	MaxConns int    `env:"MAX_CONNS" default:"10"`
+}
+
+type defaultsCache struct {
+	TTL time.Duration `env:"TTL" default:"5m"`
+}
+
+type defaultsConfig struct {
+	Name    string            `env:"NAME" default:"service"`
+	Port    int               `env:"PORT" default:"8080"`
+	Debug   bool              `env:"DEBUG" default:"true"`
+	Ratio   float64           `default:"0.5"`
+	Timeout time.Duration     `default:"1m30s"`
+	Hosts   []string          `env:"HOSTS" default:"a.local, b.local"`
+	Weights map[string]int    `default:"a:1,b:2"`
+	Limit   *int              `default:"3"`
+	Started time.Time         `default:"2024-01-02T03:04:05Z"`
+	DB      defaultsDatabase  `env:"DB"`
+	Cache   *defaultsCache    `env:"CACHE"`
+	Extra   map[string]string `env:"EXTRA"`
+}
+
+func TestApplyDefaults(t *testing.T) {
+	cfg := defaultsConfig{Port: 9000, Cache: &defaultsCache{}}
+	unify4g.AssertNil(t, unify4g.ApplyDefaults(&cfg))
+	unify4g.AssertEqual(t, cfg.Name, "service")
+	unify4g.AssertEqual(t, cfg.Port, 9000)
+	unify4g.AssertTrue(t, cfg.Debug)
+	unify4g.AssertEqual(t, cfg.Ratio, 0.5)
+	unify4g.AssertEqual(t, cfg.Timeout, 90*time.Second)
+	unify4g.AssertEqual(t, cfg.Hosts, []string{"a.local", "b.local"})
+	unify4g.AssertEqual(t, cfg.Weights, map[string]int{"a": 1, "b": 2})
+	unify4g.AssertEqual(t, *cfg.Limit, 3)
+	unify4g.AssertEqual(t, cfg.Started.Year(), 2024)
+	unify4g.AssertEqual(t, cfg.DB.MaxConns, 10)
+	unify4g.AssertEqual(t, cfg.Cache.TTL, 5*time.Minute)
+
+	var invalid struct {
+		Port int `default:"http"`
+	}
+	err := unify4g.ApplyDefaults(&invalid)
+	unify4g.AssertNotNil(t, err)
+	unify4g.AssertTrue(t, strings.Contains(err.Error(), "'Port'"))
+	unify4g.AssertNotNil(t, unify4g.ApplyDefaults(invalid))
+}
+
+func TestBindEnv(t *testing.T) {
+	t.Setenv("APP_PORT", "9090")
+	t.Setenv("APP_HOSTS", "x,y")
+	t.Setenv("APP_DB_URL", "postgres://localhost")
+	t.Setenv("APP_CACHE_TTL", "10s")
+	t.Setenv("APP_EXTRA", "region:eu, zone:a")
+
+	var cfg defaultsConfig
+	unify4g.AssertNil(t, unify4g.ApplyDefaults(&cfg))
+	report, err := unify4g.BindEnv(&cfg, "APP_")
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, cfg.Port, 9090)
+	unify4g.AssertEqual(t, cfg.Name, "service")
+	unify4g.AssertEqual(t, cfg.Hosts, []string{"x", "y"})
+	unify4g.AssertEqual(t, cfg.DB.URL, "postgres://localhost")
+	unify4g.AssertEqual(t, cfg.Cache.TTL, 10*time.Second)
+	unify4g.AssertEqual(t, cfg.Extra, map[string]string{"region": "eu", "zone": "a"})
+	unify4g.AssertEqual(t, report.Used, []string{"APP_PORT", "APP_HOSTS", "APP_DB_URL", "APP_CACHE_TTL", "APP_EXTRA"})
+	unify4g.AssertEqual(t, report.Missing, []string{"APP_NAME", "APP_DEBUG", "APP_DB_MAX_CONNS"})
+}
+
+func TestBindEnv_Errors(t *testing.T) {
+	var cfg defaultsConfig
+	report, err := unify4g.BindEnv(&cfg, "MISSING")
+	unify4g.AssertNotNil(t, err)
+	unify4g.AssertTrue(t, strings.Contains(err.Error(), "MISSING_DB_URL"))
+	unify4g.AssertEqual(t, len(report.Used), 0)
+	unify4g.AssertNil(t, cfg.Cache)
+
+	t.Setenv("BAD_PORT", "http")
+	t.Setenv("BAD_DB_URL", "x")
+	_, err = unify4g.BindEnv(&cfg, "BAD")
+	unify4g.AssertNotNil(t, err)
+	unify4g.AssertTrue(t, strings.Contains(err.Error(), "BAD_PORT"))
+}
+
+type defaultsRule struct {
+	Name     string        `env:"NAME" default:"rule"`
+	Fallback *defaultsRule `env:"FALLBACK"`
+}
+
+func TestDefaults_SelfReferencingTypes(t *testing.T) {
+	t.Setenv("RULE_NAME", "primary")
+	var rule defaultsRule
+	report, err := unify4g.BindEnv(&rule, "RULE")
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, rule.Name, "primary")
+	unify4g.AssertNil(t, rule.Fallback)
+	unify4g.AssertEqual(t, report.Missing, []string(nil))
+
+	cyclic := &defaultsRule{}
+	cyclic.Fallback = cyclic
+	unify4g.AssertNil(t, unify4g.ApplyDefaults(cyclic))
+	unify4g.AssertEqual(t, cyclic.Name, "rule")
+	_, err = unify4g.BindEnv(cyclic, "RULE")
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, cyclic.Name, "primary")
+
+	chain := &defaultsRule{Fallback: &defaultsRule{}}
+	unify4g.AssertNil(t, unify4g.ApplyDefaults(chain))
+	unify4g.AssertEqual(t, chain.Fallback.Name, "rule")
+}
@@ -0,0 +1,135 @@
+package example_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestDiffLines(t *testing.T) {
+	edits := unify4g.DiffLines("a\nb\nc\n", "a\nx\nc\n")
+	expected := []unify4g.DiffEdit{
+		{Operation: unify4g.DiffEqual, Text: "a\n"},
+		{Operation: unify4g.DiffDelete, Text: "b\n"},
+		{Operation: unify4g.DiffInsert, Text: "x\n"},
+		{Operation: unify4g.DiffEqual, Text: "c\n"},
+	}
+	unify4g.AssertEqual(t, edits, expected)
+	unify4g.AssertEqual(t, len(unify4g.DiffLines("same\n", "same\n")), 1)
+	unify4g.AssertEqual(t, len(unify4g.DiffLines("", "")), 0)
+}
+
+func TestDiffWordsAndChars(t *testing.T) {
+	words := unify4g.DiffWords("the quick fox", "the slow fox")
+	unify4g.AssertEqual(t, unify4g.FormatDiff(words, false), "the [-quick-]{+slow+} fox")
+	chars := unify4g.DiffChars("kitten", "sitting")
+	unify4g.AssertEqual(t, unify4g.FormatDiff(chars, false), "[-k-]{+s+}itt[-e-]{+i+}n{+g+}")
+	colored := unify4g.FormatDiff(unify4g.DiffChars("ab", "ac"), true)
+	unify4g.AssertEqual(t, colored, "a\x1B[31mb\x1B[0m\x1B[32mc\x1B[0m")
+}
+
+func TestDiffEditsRebuildInputs(t *testing.T) {
+	pairs := [][2]string{
+		{"ABCABBA", "CBABAC"},
+		{"", "new\ntext\n"},
+		{"old\ntext\n", ""},
+		{"héllo wörld", "hello world!"},
+	}
+	for _, pair := range pairs {
+		var a, b strings.Builder
+		for _, edit := range unify4g.DiffChars(pair[0], pair[1]) {
+			if edit.Operation != unify4g.DiffInsert {
+				a.WriteString(edit.Text)
+			}
+			if edit.Operation != unify4g.DiffDelete {
+				b.WriteString(edit.Text)
+			}
+		}
+		unify4g.AssertEqual(t, a.String(), pair[0])
+		unify4g.AssertEqual(t, b.String(), pair[1])
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	patch := unify4g.UnifiedDiff("a\nb\nc\n", "a\nx\nc\n", nil)
+	unify4g.AssertEqual(t, patch, "--- a\n+++ b\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n")
+	unify4g.AssertEqual(t, unify4g.UnifiedDiff("same\n", "same\n", nil), "")
+
+	options := &unify4g.DiffOptionsConfig{Context: 0, FromFile: "old.txt", ToFile: "new.txt"}
+	patch = unify4g.UnifiedDiff("1\n2\n3\n", "1\n3\n", options)
+	unify4g.AssertEqual(t, patch, "--- old.txt\n+++ new.txt\n@@ -2 +1,0 @@\n-2\n")
+
+	patch = unify4g.UnifiedDiff("a\nb", "a\nc", nil)
+	unify4g.AssertEqual(t, patch, "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n")
+
+	colored := unify4g.UnifiedDiff("a\n", "b\n", &unify4g.DiffOptionsConfig{Context: 3, FromFile: "a", ToFile: "b", Color: true})
+	unify4g.AssertTrue(t, strings.Contains(colored, "\x1B[31m-a\x1B[0m\n"))
+	unify4g.AssertTrue(t, strings.Contains(colored, "\x1B[32m+b\x1B[0m\n"))
+}
+
+func TestUnifiedDiffSeparateHunks(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 20; i++ {
+		line := string(rune('a' + i))
+		a = append(a, line)
+		switch i {
+		case 2:
+			b = append(b, "X")
+		case 15:
+		default:
+			b = append(b, line)
+		}
+	}
+	original, modified := strings.Join(a, "\n")+"\n", strings.Join(b, "\n")+"\n"
+	patch := unify4g.UnifiedDiff(original, modified, nil)
+	unify4g.AssertEqual(t, strings.Count(patch, "@@ -"), 2)
+	unify4g.AssertTrue(t, strings.Contains(patch, "@@ -1,6 +1,6 @@"))
+	unify4g.AssertTrue(t, strings.Contains(patch, "@@ -13,7 +13,6 @@"))
+}
+
+func TestApplyPatch(t *testing.T) {
+	pairs := [][2]string{
+		{"a\nb\nc\n", "a\nx\nc\n"},
+		{"", "new\nfile\n"},
+		{"gone\n", ""},
+		{"a\nb", "a\nb\nc"},
+		{"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "0\n1\n2\n3\n5\n6\n7\n8\nnine\n10\n11\n"},
+	}
+	for _, pair := range pairs {
+		patched, err := unify4g.ApplyPatch(pair[0], unify4g.UnifiedDiff(pair[0], pair[1], nil))
+		unify4g.AssertNil(t, err)
+		unify4g.AssertEqual(t, patched, pair[1])
+	}
+
+	// the hunk still applies after lines were inserted above it
+	patch := unify4g.UnifiedDiff("a\nb\nc\nd\ne\nf\ng\n", "a\nb\nc\nD\ne\nf\ng\n", &unify4g.DiffOptionsConfig{Context: 1})
+	patched, err := unify4g.ApplyPatch("top\na\nb\nc\nd\ne\nf\ng\n", patch)
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, patched, "top\na\nb\nc\nD\ne\nf\ng\n")
+
+	_, err = unify4g.ApplyPatch("a\ny\nc\n", unify4g.UnifiedDiff("a\nb\nc\n", "a\nx\nc\n", nil))
+	unify4g.AssertNotNil(t, err)
+	_, err = unify4g.ApplyPatch("a\n", "@@ -1,2 +1,2 @@\n a\n")
+	unify4g.AssertNotNil(t, err)
+}
+
+func TestDiffLargeInputs(t *testing.T) {
+	var a, b strings.Builder
+	for i := 0; i < 8000; i++ {
+		fmt.Fprintf(&a, "old line %d\n", i)
+		fmt.Fprintf(&b, "new line %d\n", i)
+	}
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	edits := unify4g.DiffLines(a.String(), b.String())
+	runtime.ReadMemStats(&after)
+	unify4g.AssertTrue(t, after.TotalAlloc-before.TotalAlloc < 64<<20)
+	unify4g.AssertEqual(t, len(edits), 2)
+
+	patched, err := unify4g.ApplyPatch(a.String(), unify4g.UnifiedDiff(a.String(), b.String(), nil))
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, patched, b.String())
+}
@@ -0,0 +1,66 @@
+package example_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestFuzzy_Distances(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.Levenshtein("kitten", "sitting"), 3)
+	unify4g.AssertEqual(t, unify4g.Levenshtein("", "abc"), 3)
+	unify4g.AssertEqual(t, unify4g.Levenshtein("héllo", "hello"), 1)
+	unify4g.AssertEqual(t, unify4g.DamerauLevenshtein("ca", "ac"), 1)
+	unify4g.AssertEqual(t, unify4g.DamerauLevenshtein("ca", "abc"), 2)
+	unify4g.AssertEqual(t, unify4g.Levenshtein("ca", "abc"), 3)
+
+	distance, ok := unify4g.Hamming("karolin", "kathrin")
+	unify4g.AssertTrue(t, ok)
+	unify4g.AssertEqual(t, distance, 3)
+	_, ok = unify4g.Hamming("go", "rust")
+	unify4g.AssertFalse(t, ok)
+
+	unify4g.AssertEqual(t, unify4g.LongestCommonSubsequence("ABCBDAB", "BDCABA"), "BDAB")
+	unify4g.AssertEqual(t, unify4g.LongestCommonSubsequence("abc", "xyz"), "")
+}
+
+func TestFuzzy_Similarity(t *testing.T) {
+	cases := []struct {
+		actual, expected float64
+	}{
+		{unify4g.Jaro("MARTHA", "MARHTA"), 0.944444},
+		{unify4g.JaroWinkler("MARTHA", "MARHTA"), 0.961111},
+		{unify4g.JaroWinkler("DIXON", "DICKSONX"), 0.813333},
+		{unify4g.Jaro("", ""), 1},
+		{unify4g.Jaro("abc", ""), 0},
+		{unify4g.Similarity("kitten", "sitting"), 1 - 3.0/7},
+		{unify4g.Similarity("", ""), 1},
+	}
+	for _, c := range cases {
+		if math.Abs(c.actual-c.expected) > 1e-6 {
+			t.Errorf("Expected %v but got %v", c.expected, c.actual)
+		}
+	}
+}
+
+func TestFuzzy_Find(t *testing.T) {
+	matches := unify4g.FuzzyFind("gco", []string{"git checkout", "git commit", "go vet"}, 0)
+	unify4g.AssertEqual(t, len(matches), 2)
+	unify4g.AssertEqual(t, matches[0].Value, "git commit")
+	unify4g.AssertEqual(t, matches[0].Index, 1)
+	unify4g.AssertEqual(t, matches[0].Positions, []int{0, 4, 5})
+	unify4g.AssertEqual(t, matches[1].Positions, []int{0, 4, 9})
+
+	matches = unify4g.FuzzyFind("GC", []string{"magic", "git commit", "getConfig"}, 2)
+	unify4g.AssertEqual(t, len(matches), 2)
+	unify4g.AssertEqual(t, matches[0].Value, "git commit")
+	unify4g.AssertEqual(t, matches[1].Value, "getConfig")
+
+	unify4g.AssertEqual(t, len(unify4g.FuzzyFind("cafe", []string{"Café Noir"}, 0)), 0)
+	matches = unify4g.FuzzyFindWith("cafe", []string{"Café Noir", "coffee"}, 0, &unify4g.FuzzyOptionsConfig{IgnoreAccents: true})
+	unify4g.AssertEqual(t, len(matches), 1)
+	unify4g.AssertEqual(t, matches[0].Positions, []int{0, 1, 2, 3})
+	unify4g.AssertEqual(t, len(unify4g.FuzzyFindWith("Go", []string{"go", "Go"}, 0, &unify4g.FuzzyOptionsConfig{CaseSensitive: true})), 1)
+	unify4g.AssertEqual(t, len(unify4g.FuzzyFind("", []string{"a", "b"}, 0)), 2)
+}
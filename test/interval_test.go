@@ -0,0 +1,76 @@
+package example_test
+
+import (
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestIntervalTree_Queries(t *testing.T) {
+	tree := unify4g.NewIntervalTree[int, string]()
+	tree.Insert(1, 5, "a")
+	tree.Insert(3, 9, "b")
+	tree.Insert(10, 12, "c")
+	tree.Insert(5, 6, "d")
+	if tree.Insert(7, 7, "empty") {
+		t.Errorf("Expected empty interval to be rejected")
+	}
+
+	values := func(entries []unify4g.IntervalEntry[int, string]) []string {
+		result := make([]string, 0, len(entries))
+		for _, e := range entries {
+			result = append(result, e.Value)
+		}
+		return result
+	}
+	unify4g.AssertEqual(t, values(tree.Stab(4)), []string{"a", "b"})
+	unify4g.AssertEqual(t, values(tree.Stab(5)), []string{"b", "d"})
+	unify4g.AssertEqual(t, values(tree.Stab(9)), []string{})
+	unify4g.AssertEqual(t, values(tree.Overlapping(8, 11)), []string{"b", "c"})
+	unify4g.AssertTrue(t, tree.AnyOverlap(11, 20))
+	unify4g.AssertFalse(t, tree.AnyOverlap(12, 20))
+}
+
+func TestIntervalTree_Delete(t *testing.T) {
+	tree := unify4g.NewIntervalTree[int, int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i+10, i)
+	}
+	for i := 0; i < 100; i += 2 {
+		if !tree.Delete(i, i+10) {
+			t.Fatalf("Expected interval [%d, %d) to be deleted", i, i+10)
+		}
+	}
+	if tree.Delete(0, 10) {
+		t.Errorf("Expected second delete of [0, 10) to fail")
+	}
+	unify4g.AssertEqual(t, tree.Size(), 50)
+	for _, e := range tree.Stab(50) {
+		if e.Value%2 == 0 || !e.Contains(50) {
+			t.Errorf("Unexpected entry %v", e.Interval)
+		}
+	}
+	unify4g.AssertEqual(t, len(tree.Stab(50)), 5)
+}
+
+func TestRangeSet_Merge(t *testing.T) {
+	set := unify4g.NewRangeSet(unify4g.NewInterval(1, 5), unify4g.NewInterval(5, 9), unify4g.NewInterval(20, 30))
+	unify4g.AssertEqual(t, set.String(), "[1, 9),[20, 30)")
+	set.Add(8, 21)
+	unify4g.AssertEqual(t, set.Ranges(), []unify4g.Interval[int]{{Start: 1, End: 30}})
+	set.Remove(10, 12)
+	unify4g.AssertEqual(t, set.String(), "[1, 10),[12, 30)")
+	unify4g.AssertTrue(t, set.Contains(9))
+	unify4g.AssertFalse(t, set.Contains(10))
+	unify4g.AssertTrue(t, set.ContainsRange(12, 30))
+	unify4g.AssertFalse(t, set.ContainsRange(5, 15))
+}
+
+func TestRangeSet_Algebra(t *testing.T) {
+	a := unify4g.NewRangeSet(unify4g.NewInterval(0, 10), unify4g.NewInterval(20, 30))
+	b := unify4g.NewRangeSet(unify4g.NewInterval(5, 25))
+	unify4g.AssertEqual(t, a.Union(b).String(), "[0, 30)")
+	unify4g.AssertEqual(t, a.Intersection(b).String(), "[5, 10),[20, 25)")
+	unify4g.AssertEqual(t, a.Difference(b).String(), "[0, 5),[25, 30)")
+	unify4g.AssertEqual(t, a.String(), "[0, 10),[20, 30)")
+}
@@ -0,0 +1,59 @@
+package example_test
+
+import (
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestMultiMap_ListSemantics(t *testing.T) {
+	multi := unify4g.NewMultiMap[string, int]()
+	multi.PutAll("a", 1, 2, 1)
+	multi.Put("b", 3)
+
+	unify4g.AssertEqual(t, multi.Get("a"), []int{1, 2, 1})
+	if multi.Size() != 4 || multi.KeyCount() != 2 {
+		t.Errorf("Expected size 4 and 2 keys but got %d and %d", multi.Size(), multi.KeyCount())
+	}
+	if !multi.Remove("a", 1) {
+		t.Errorf("Expected Remove to report success")
+	}
+	unify4g.AssertEqual(t, multi.Get("a"), []int{2, 1})
+	if !multi.ContainsEntry("a", 2) || multi.ContainsEntry("b", 2) {
+		t.Errorf("Unexpected ContainsEntry result")
+	}
+	multi.Remove("b", 3)
+	if multi.ContainsKey("b") || multi.KeyCount() != 1 {
+		t.Errorf("Expected key b to be removed with its last value")
+	}
+	unify4g.AssertEqual(t, multi.RemoveAll("a"), []int{2, 1})
+	if !multi.IsEmpty() {
+		t.Errorf("Expected map to be empty")
+	}
+}
+
+func TestMultiMap_SetSemantics(t *testing.T) {
+	multi := unify4g.NewSetMultiMap[string, string]()
+	multi.PutAll("go", "fast", "typed", "fast")
+	if multi.Put("go", "typed") {
+		t.Errorf("Expected duplicate Put to be ignored")
+	}
+	unify4g.AssertEqual(t, multi.Get("go"), []string{"fast", "typed"})
+	unify4g.AssertEqual(t, multi.Size(), 2)
+
+	unify4g.AssertTrue(t, multi.Remove("go", "fast"))
+	unify4g.AssertFalse(t, multi.Remove("go", "fast"))
+	unify4g.AssertTrue(t, multi.Put("go", "fast"))
+	unify4g.AssertEqual(t, multi.Get("go"), []string{"typed", "fast"})
+	multi.RemoveAll("go")
+	unify4g.AssertFalse(t, multi.ContainsEntry("go", "typed"))
+	unify4g.AssertTrue(t, multi.Put("go", "typed"))
+
+	index := unify4g.NewSetMultiMap[string, int]()
+	for i := 0; i < 100000; i++ {
+		index.Put("tag", i)
+		index.Put("tag", i)
+	}
+	unify4g.AssertEqual(t, index.Size(), 100000)
+	unify4g.AssertTrue(t, index.ContainsEntry("tag", 99999))
+}
@@ -0,0 +1,104 @@
+package example_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestParallelMap_PreservesOrder(t *testing.T) {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+	result, err := unify4g.ParallelMap(context.Background(), items, 8, func(_ context.Context, n int) (int, error) {
+		return n * 2, nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i, v := range result {
+		if v != i*2 {
+			t.Fatalf("Expected result[%d] to be %d but got %d", i, i*2, v)
+		}
+	}
+}
+
+func TestParallelMap_FirstErrorCancels(t *testing.T) {
+	boom := errors.New("boom")
+	var started atomic.Int32
+	items := make([]int, 1000)
+	_, err := unify4g.ParallelMap(context.Background(), items, 2, func(ctx context.Context, _ int) (int, error) {
+		if started.Add(1) == 3 {
+			return 0, boom
+		}
+		return 0, ctx.Err()
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("Expected boom error but got %v", err)
+	}
+	if started.Load() >= 1000 {
+		t.Errorf("Expected remaining work to be cancelled")
+	}
+}
+
+func TestParallelForEach_RecoversPanic(t *testing.T) {
+	err := unify4g.ParallelForEach(context.Background(), []int{1, 2, 3}, 3, func(_ context.Context, n int) error {
+		if n == 2 {
+			panic("unexpected")
+		}
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "panic at index 1") {
+		t.Errorf("Expected recovered panic error but got %v", err)
+	}
+}
+
+func TestParallelFilter(t *testing.T) {
+	result, err := unify4g.ParallelFilter(context.Background(), []int{1, 2, 3, 4, 5, 6}, 0, func(_ context.Context, n int) (bool, error) {
+		return n%2 == 0, nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	unify4g.AssertEqual(t, result, []int{2, 4, 6})
+}
+
+func TestParallelReduce(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e", "f", "g"}
+	result, err := unify4g.ParallelReduce(context.Background(), items, 3, "",
+		func(_ context.Context, acc string, s string) (string, error) { return acc + s, nil },
+		func(a, b string) string { return a + b })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	unify4g.AssertEqual(t, result, "abcdefg")
+}
+
+func TestParallelReduce_RecoversPanic(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	_, err := unify4g.ParallelReduce(context.Background(), items, 3, 0,
+		func(_ context.Context, acc int, n int) (int, error) {
+			if n == 6 {
+				panic("unexpected")
+			}
+			return acc + n, nil
+		},
+		func(a, b int) int { return a + b })
+	if err == nil || !strings.Contains(err.Error(), "panic at index 5") {
+		t.Errorf("Expected recovered panic error at the item index but got %v", err)
+	}
+}
+
+func TestParallel_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := unify4g.ParallelForEach(ctx, []int{1, 2, 3}, 2, func(context.Context, int) error { return nil })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled but got %v", err)
+	}
+}
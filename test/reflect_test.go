@@ -0,0 +1,228 @@
+package example_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+type reflectAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type reflectBase struct {
+	ID int `json:"id"`
+}
+
+type reflectUser struct {
+	reflectBase
+	Name      string                    `json:"name"`
+	Email     string                    `json:"email,omitempty"`
+	Password  string                    `json:"-"`
+	Tags      []string                  `json:"tags"`
+	Addresses []reflectAddress          `json:"addresses"`
+	Labels    map[string]reflectAddress `json:"labels"`
+	Manager   *reflectUser              `json:"manager"`
+	Created   time.Time                 `json:"created"`
+	secret    string
+}
+
+func TestDeepCopy(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	original := &reflectUser{
+		Name:      "Ann",
+		Tags:      []string{"a", "b"},
+		Addresses: []reflectAddress{{City: "Oslo"}},
+		Labels:    map[string]reflectAddress{"home": {City: "Bergen"}},
+		Created:   created,
+		secret:    "s3cr3t",
+	}
+	original.Manager = original
+
+	clone := unify4g.DeepCopy(original)
+	clone.Tags[0] = "z"
+	clone.Addresses[0].City = "Rome"
+	clone.Labels["home"] = reflectAddress{City: "Paris"}
+	unify4g.AssertEqual(t, original.Tags[0], "a")
+	unify4g.AssertEqual(t, original.Addresses[0].City, "Oslo")
+	unify4g.AssertEqual(t, original.Labels["home"].City, "Bergen")
+	unify4g.AssertTrue(t, clone.Manager == clone)
+	unify4g.AssertTrue(t, clone.Created.Equal(created))
+	unify4g.AssertEqual(t, reflect.ValueOf(clone).Elem().FieldByName("secret").String(), "s3cr3t")
+
+	zeroed := unify4g.DeepCopyWith(*original, &unify4g.CopyOptionsConfig{ZeroUnexported: true})
+	unify4g.AssertEqual(t, reflect.ValueOf(zeroed).FieldByName("secret").String(), "")
+
+	var data interface{} = map[string]interface{}{"list": []interface{}{1, "x"}}
+	copied := unify4g.DeepCopy(data).(map[string]interface{})
+	copied["list"].([]interface{})[0] = 2
+	unify4g.AssertEqual(t, data.(map[string]interface{})["list"].([]interface{})[0], 1)
+}
+
+func TestStructToMap(t *testing.T) {
+	user := reflectUser{
+		reflectBase: reflectBase{ID: 7},
+		Name:        "Ann",
+		Password:    "hidden",
+		Addresses:   []reflectAddress{{City: "Oslo", Zip: "0150"}},
+	}
+	m, err := unify4g.StructToMap(user, nil)
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, m["id"], 7)
+	unify4g.AssertEqual(t, m["name"], "Ann")
+	_, hasEmail := m["email"]
+	_, hasPassword := m["password"]
+	unify4g.AssertFalse(t, hasEmail)
+	unify4g.AssertFalse(t, hasPassword)
+	unify4g.AssertEqual(t, m["addresses"], []interface{}{map[string]interface{}{"city": "Oslo", "zip": "0150"}})
+
+	flat, err := unify4g.StructToMap(&user, &unify4g.StructMapOptionsConfig{OmitEmpty: true, Flatten: true, Separator: "."})
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, flat, map[string]interface{}{"id": 7, "name": "Ann", "addresses.0.city": "Oslo", "addresses.0.zip": "0150"})
+
+	_, err = unify4g.StructToMap(42, nil)
+	unify4g.AssertNotNil(t, err)
+}
+
+func TestMapToStruct(t *testing.T) {
+	var user reflectUser
+	err := unify4g.MapToStruct(map[string]interface{}{
+		"id":        float64(3),
+		"NAME":      "Bob",
+		"tags":      []interface{}{"x", "y"},
+		"addresses": []interface{}{map[string]interface{}{"city": "Oslo"}},
+		"labels":    map[string]interface{}{"work": map[string]interface{}{"city": "Rome"}},
+		"manager":   map[string]interface{}{"name": "Eve"},
+		"created":   "2024-01-02T03:04:05Z",
+		"unknown":   true,
+	}, &user)
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, user.ID, 3)
+	unify4g.AssertEqual(t, user.Name, "Bob")
+	unify4g.AssertEqual(t, user.Tags, []string{"x", "y"})
+	unify4g.AssertEqual(t, user.Addresses, []reflectAddress{{City: "Oslo"}})
+	unify4g.AssertEqual(t, user.Labels["work"].City, "Rome")
+	unify4g.AssertEqual(t, user.Manager.Name, "Eve")
+	unify4g.AssertEqual(t, user.Created.Year(), 2024)
+
+	err = unify4g.MapToStruct(map[string]interface{}{"addresses": []interface{}{map[string]interface{}{"city": 5}}}, &user)
+	unify4g.AssertNotNil(t, err)
+	unify4g.AssertTrue(t, strings.Contains(err.Error(), "addresses[0].city"))
+	unify4g.AssertNotNil(t, unify4g.MapToStruct(nil, user))
+}
+
+func TestFieldByPath(t *testing.T) {
+	user := &reflectUser{
+		Addresses: []reflectAddress{{City: "Oslo"}},
+		Labels:    map[string]reflectAddress{"home": {City: "Bergen"}},
+	}
+	city, err := unify4g.FieldByPath(user, "Addresses[0].City")
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, city, "Oslo")
+	city, err = unify4g.FieldByPath(user, "labels.home.city")
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, city, "Bergen")
+	_, err = unify4g.FieldByPath(user, "Addresses[3].City")
+	unify4g.AssertNotNil(t, err)
+	_, err = unify4g.FieldByPath(user, "Manager.Name")
+	unify4g.AssertNotNil(t, err)
+
+	unify4g.AssertNil(t, unify4g.SetFieldByPath(user, "Addresses[0].City", "Rome"))
+	unify4g.AssertNil(t, unify4g.SetFieldByPath(user, "Manager.Name", "Eve"))
+	unify4g.AssertNil(t, unify4g.SetFieldByPath(user, "Labels.work", map[string]interface{}{"city": "Paris"}))
+	unify4g.AssertNil(t, unify4g.SetFieldByPath(user, "id", 9.0))
+	unify4g.AssertEqual(t, user.Addresses[0].City, "Rome")
+	unify4g.AssertEqual(t, user.Manager.Name, "Eve")
+	unify4g.AssertEqual(t, user.Labels["work"].City, "Paris")
+	unify4g.AssertEqual(t, user.ID, 9)
+	unify4g.AssertNotNil(t, unify4g.SetFieldByPath(*user, "Name", "x"))
+	unify4g.AssertNotNil(t, unify4g.SetFieldByPath(user, "Name", 5))
+}
+
+func TestWalk(t *testing.T) {
+	user := &reflectUser{Name: "Ann", Tags: []string{"a"}, Labels: map[string]reflectAddress{"home": {City: "Oslo"}}}
+	user.Manager = user
+	var paths []string
+	unify4g.Walk(user, func(path string, value reflect.Value) bool {
+		paths = append(paths, path)
+		return path != "reflectBase"
+	})
+	unify4g.AssertTrue(t, unify4g.ContainsN(paths, "Tags[0]"))
+	unify4g.AssertTrue(t, unify4g.ContainsN(paths, "Labels[home].City"))
+	unify4g.AssertFalse(t, unify4g.ContainsN(paths, "reflectBase.ID"))
+	unify4g.AssertFalse(t, unify4g.ContainsN(paths, "Manager.Name"))
+}
+
+type ReflectInner struct {
+	Label string
+}
+
+type reflectOuter struct {
+	*ReflectInner
+	Code int
+}
+
+func TestFieldByPathNilEmbedded(t *testing.T) {
+	_, err := unify4g.FieldByPath(reflectOuter{}, "Label")
+	unify4g.AssertNotNil(t, err)
+	_, err = unify4g.FieldByPath(nil, "Label")
+	unify4g.AssertNotNil(t, err)
+
+	outer := &reflectOuter{}
+	unify4g.AssertNil(t, unify4g.SetFieldByPath(outer, "Label", "set"))
+	unify4g.AssertNotNil(t, outer.ReflectInner)
+	unify4g.AssertEqual(t, outer.Label, "set")
+	label, err := unify4g.FieldByPath(outer, "Label")
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, label, "set")
+}
+
+type reflectNumbers struct {
+	Small uint8   `json:"small"`
+	Count int     `json:"count"`
+	Ratio float32 `json:"ratio"`
+}
+
+func TestMapToStructNumberConversions(t *testing.T) {
+	var numbers reflectNumbers
+	unify4g.AssertNil(t, unify4g.MapToStruct(map[string]interface{}{"small": 255, "count": 3.0, "ratio": 0.5}, &numbers))
+	unify4g.AssertEqual(t, numbers, reflectNumbers{Small: 255, Count: 3, Ratio: 0.5})
+
+	invalid := []map[string]interface{}{
+		{"small": 300},
+		{"small": -1},
+		{"count": 3.7},
+		{"count": 1e20},
+		{"ratio": 1e300},
+		{"small": uint64(256)},
+	}
+	for _, m := range invalid {
+		unify4g.AssertNotNil(t, unify4g.MapToStruct(m, &numbers))
+	}
+	unify4g.AssertNotNil(t, unify4g.SetFieldByPath(&numbers, "Small", 300))
+	unify4g.AssertEqual(t, numbers.Small, uint8(255))
+}
+
+type reflectPoint struct {
+	X int
+}
+
+type reflectHolder struct {
+	Inner reflectPoint
+	P     *reflectPoint
+}
+
+func TestWalkPointerToFirstField(t *testing.T) {
+	holder := &reflectHolder{Inner: reflectPoint{X: 1}}
+	holder.P = &holder.Inner
+	var paths []string
+	unify4g.Walk(holder, func(path string, value reflect.Value) bool {
+		paths = append(paths, path)
+		return true
+	})
+	unify4g.AssertEqual(t, paths, []string{"Inner", "Inner.X", "P", "P.X"})
+}
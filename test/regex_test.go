@@ -0,0 +1,107 @@
+package example_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestRegexpCache(t *testing.T) {
+	cache := unify4g.NewRegexpCache(2)
+	first, err := cache.Compile(`\d+`)
+	unify4g.AssertNil(t, err)
+	again, _ := cache.Compile(`\d+`)
+	unify4g.AssertTrue(t, first == again)
+	unify4g.AssertEqual(t, cache.Len(), 1)
+
+	cache.MustCompile(`[a-z]+`)
+	cache.Compile(`\d+`) // most recently used, [a-z]+ is evicted next
+	cache.MustCompile(`\s+`)
+	unify4g.AssertEqual(t, cache.Len(), 2)
+	third, _ := cache.Compile(`\d+`)
+	unify4g.AssertTrue(t, first == third)
+
+	_, err = cache.Compile(`(`)
+	unify4g.AssertNotNil(t, err)
+	unify4g.AssertEqual(t, cache.Len(), 2)
+	cache.Clear()
+	unify4g.AssertEqual(t, cache.Len(), 0)
+
+	defer func() {
+		unify4g.AssertTrue(t, recover() != nil)
+	}()
+	cache.MustCompile(`(`)
+}
+
+func TestRegexpCacheConcurrent(t *testing.T) {
+	cache := unify4g.NewRegexpCache(8)
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			re := cache.MustCompile(fmt.Sprintf(`x{%d}`, i%12))
+			re.MatchString(strings.Repeat("x", i))
+		}(i)
+	}
+	wg.Wait()
+	unify4g.AssertEqual(t, cache.Len(), 8)
+}
+
+func TestCompileAll(t *testing.T) {
+	compiled, err := unify4g.CompileAll(`^\d{4}$`, `^[a-z]+$`)
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, len(compiled), 2)
+	unify4g.AssertTrue(t, compiled[1].MatchString("abc"))
+
+	_, err = unify4g.CompileAll(`ok`, `(`, `[`)
+	unify4g.AssertNotNil(t, err)
+	unify4g.AssertTrue(t, strings.Contains(err.Error(), "pattern 1"))
+	unify4g.AssertTrue(t, strings.Contains(err.Error(), "pattern 2"))
+
+	defer func() {
+		unify4g.AssertTrue(t, recover() != nil)
+	}()
+	unify4g.MustCompileAll(`ok`, `(`)
+}
+
+func TestReplaceAllFunc(t *testing.T) {
+	result := unify4g.ReplaceAllFunc("from 2024-05-01 to 2024-06-30", `(?P<y>\d{4})-(?P<m>\d{2})-(?P<d>\d{2})`, func(groups map[string]string) string {
+		return groups["d"] + "/" + groups["m"] + "/" + groups["y"]
+	})
+	unify4g.AssertEqual(t, result, "from 01/05/2024 to 30/06/2024")
+
+	result = unify4g.ReplaceAllFunc("xfoo foo", `\Bfoo`, func(groups map[string]string) string {
+		return strings.ToUpper(groups["0"])
+	})
+	unify4g.AssertEqual(t, result, "xFOO foo")
+
+	result = unify4g.ReplaceAllFunc("a1 b", `([a-z])(\d)?`, func(groups map[string]string) string {
+		return "<" + groups["1"] + groups["2"] + ">"
+	})
+	unify4g.AssertEqual(t, result, "<a1> <b>")
+}
+
+func TestExtractNamed(t *testing.T) {
+	named := unify4g.ExtractNamed("user=alice id=42", `user=(?P<user>\w+) id=(?P<id>\d+)(?P<suffix>!)?`)
+	unify4g.AssertEqual(t, named, map[string]string{"user": "alice", "id": "42", "suffix": ""})
+	unify4g.AssertNil(t, unify4g.ExtractNamed("nothing", `user=(?P<user>\w+)`))
+}
+
+func TestFindAllStringsLimit(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.FindAllStringsLimit("a1 b22 c333", `\d+`, 2), []string{"1", "22"})
+	unify4g.AssertEqual(t, unify4g.FindAllStringsLimit("a1 b22 c333", `\d+`, -1), []string{"1", "22", "333"})
+	unify4g.AssertEqual(t, len(unify4g.FindAllStringsLimit("abc", `\d+`, -1)), 0)
+}
+
+func TestRemovePatternCached(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		unify4g.AssertEqual(t, unify4g.RemovePattern("abc123xyz", "[0-9]+"), "abcxyz")
+	}
+	unify4g.AssertEqual(t, unify4g.Strip("  hi \n"), "hi")
+	unify4g.AssertTrue(t, unify4g.IsBlank(" \t"))
+	unify4g.AssertEqual(t, unify4g.OnlyDigits("a1-b2"), "12")
+}
@@ -0,0 +1,90 @@
+package example_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestEscapeHTML(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.EscapeHTML(`<a href="x">Tom & Jerry's</a>`), "&lt;a href=&#34;x&#34;&gt;Tom &amp; Jerry&#39;s&lt;/a&gt;")
+	unify4g.AssertEqual(t, unify4g.EscapeHTML("plain"), "plain")
+}
+
+func TestStripTags(t *testing.T) {
+	tests := []struct {
+		input    string
+		allowed  []string
+		expected string
+	}{
+		{`<p onclick="steal()">Hello <b>world</b><script>alert(1)</script></p>`, []string{"b"}, "Hello <b>world</b>"},
+		{`<B class="x">bold</B><br/>line`, []string{"b", "br"}, "<b>bold</b><br>line"},
+		{`a <!-- secret > comment --> b`, nil, "a  b"},
+		{`<img src="x" alt="a > b" onerror="alert(1)">text`, nil, "text"},
+		{`<style>body{}</style><SCRIPT>x</SCRIPT>visible`, []string{"script"}, "visible"},
+		{`<scr<script>ipt>alert(1)</script>`, nil, "ipt&gt;alert(1)"},
+		{`1 < 2 and 3 > 2`, nil, "1 &lt; 2 and 3 &gt; 2"},
+		{`Tom &amp; Jerry &#39;&#x27; & co`, nil, "Tom &amp; Jerry &#39;&#x27; &amp; co"},
+		{`<<b>script>alert(1)<</b>/script>`, nil, "&lt;script&gt;alert(1)&lt;/script&gt;"},
+		{`<<!-- x -->img src=x onerror=alert(1)>`, nil, "&lt;img src=x onerror=alert(1)&gt;"},
+		{`<<b>script>alert(1)<</b>/script>`, []string{"b"}, "&lt;<b>script&gt;alert(1)&lt;</b>/script&gt;"},
+		{`text <b unterminated`, []string{"b"}, "text "},
+		{`<a href="javascript:alert(1)">link</a>`, []string{"a"}, "<a>link</a>"},
+	}
+	for _, tt := range tests {
+		if result := unify4g.StripTags(tt.input, tt.allowed...); result != tt.expected {
+			t.Errorf("StripTags(%q, %v) = %q; want %q", tt.input, tt.allowed, result, tt.expected)
+		}
+	}
+}
+
+func TestQuoteShell(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.QuoteShell("simple"), "'simple'")
+	unify4g.AssertEqual(t, unify4g.QuoteShell(""), "''")
+	unify4g.AssertEqual(t, unify4g.QuoteShell("it's; rm -rf /"), `'it'\''s; rm -rf /'`)
+	unify4g.AssertEqual(t, unify4g.QuoteShell("$(id) `id` \"x\"\x00"), "'$(id) `id` \"x\"'")
+	unify4g.AssertEqual(t, unify4g.QuoteShellArgs("git", "commit", "-m", "it's done"), `'git' 'commit' '-m' 'it'\''s done'`)
+	unify4g.AssertEqual(t, unify4g.QuoteShellArgs(), "")
+}
+
+func TestQuoteSQLIdentifier(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.QuoteSQLIdentifier("users"), `"users"`)
+	unify4g.AssertEqual(t, unify4g.QuoteSQLIdentifier(`users"; DROP TABLE x; --`), `"users""; DROP TABLE x; --"`)
+	unify4g.AssertEqual(t, unify4g.QuoteSQLIdentifier("public", "order"), `"public"."order"`)
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := map[string]string{
+		"report.pdf":            "report.pdf",
+		"../../etc/passwd":      ".._.._etc_passwd",
+		`..\..\windows\win.ini`: ".._.._windows_win.ini",
+		"report: Q1?.pdf":       "report_ Q1_.pdf",
+		"CON":                   "_CON",
+		"lpt1.txt":              "_lpt1.txt",
+		"console.log":           "console.log",
+		"  name. . ":            "name",
+		"..":                    "_",
+		"":                      "_",
+		"line\nbreak\x00.txt":   "line_break.txt",
+		"invoice\u202Efdp.exe":  "invoicefdp.exe",
+		"résumé.doc":            "résumé.doc",
+	}
+	for input, expected := range tests {
+		if result := unify4g.SanitizeFilename(input); result != expected {
+			t.Errorf("SanitizeFilename(%q) = %q; want %q", input, result, expected)
+		}
+	}
+	long := unify4g.SanitizeFilename(strings.Repeat("é", 200) + ".txt")
+	unify4g.AssertTrue(t, len(long) <= 255)
+	unify4g.AssertTrue(t, strings.HasSuffix(long, "é.txt"))
+}
+
+func TestStripControlChars(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.StripControlChars("a\x00b\x1Bc\u0085d"), "abcd")
+	unify4g.AssertEqual(t, unify4g.StripControlChars("tab\tline\r\n"), "tab\tline\r\n")
+	unify4g.AssertEqual(t, unify4g.StripControlChars("if isAdmin {\u202E } \u2066// check\u2069"), "if isAdmin { } // check")
+	unify4g.AssertTrue(t, unify4g.ContainsBidiControl("access\u202Elevel"))
+	unify4g.AssertTrue(t, unify4g.ContainsBidiControl("x\u200Fy"))
+	unify4g.AssertFalse(t, unify4g.ContainsBidiControl("שלום world"))
+}
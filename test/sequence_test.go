@@ -0,0 +1,91 @@
+package example_test
+
+import (
+	"iter"
+	"sort"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestSeq_Pipeline(t *testing.T) {
+	calls := 0
+	source := unify4g.SeqMap(unify4g.SeqFromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}), func(n int) int {
+		calls++
+		return n * n
+	})
+	evens := unify4g.SeqFilter(source, func(n int) bool { return n%2 == 0 })
+	result := unify4g.SeqCollect(unify4g.SeqTake(evens, 2))
+	unify4g.AssertEqual(t, result, []int{4, 16})
+	if calls != 4 {
+		t.Errorf("Expected lazy evaluation to call the mapper %d times but got %d", 4, calls)
+	}
+}
+
+func TestSeq_SkipTakeWhile(t *testing.T) {
+	seq := unify4g.SeqFromSlice([]int{1, 2, 3, 10, 4, 5})
+	unify4g.AssertEqual(t, unify4g.SeqCollect(unify4g.SeqSkip(seq, 4)), []int{4, 5})
+	unify4g.AssertEqual(t, unify4g.SeqCollect(unify4g.SeqTakeWhile(seq, func(n int) bool { return n < 10 })), []int{1, 2, 3})
+	unify4g.AssertEqual(t, unify4g.SeqCollect(unify4g.SeqSkipWhile(seq, func(n int) bool { return n < 10 })), []int{10, 4, 5})
+}
+
+func TestSeq_ChunkWindow(t *testing.T) {
+	seq := unify4g.SeqFromSlice([]int{1, 2, 3, 4, 5})
+	unify4g.AssertEqual(t, unify4g.SeqCollect(unify4g.SeqChunk(seq, 2)), [][]int{{1, 2}, {3, 4}, {5}})
+	unify4g.AssertEqual(t, unify4g.SeqCollect(unify4g.SeqWindow(seq, 3)), [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}})
+}
+
+func TestSeq_ZipEnumerateDistinct(t *testing.T) {
+	names := unify4g.SeqFromSlice([]string{"a", "b", "c"})
+	ages := unify4g.SeqFromSlice([]int{1, 2})
+	pairs := map[string]int{}
+	for name, age := range unify4g.SeqZip(names, ages) {
+		pairs[name] = age
+	}
+	unify4g.AssertEqual(t, pairs, map[string]int{"a": 1, "b": 2})
+
+	var indexes []int
+	for i := range unify4g.SeqEnumerate(unify4g.SeqDistinct(unify4g.SeqFromSlice([]int{5, 5, 6, 5, 7}))) {
+		indexes = append(indexes, i)
+	}
+	unify4g.AssertEqual(t, indexes, []int{0, 1, 2})
+}
+
+func TestSeq_FlatMapAndTerminals(t *testing.T) {
+	seq := unify4g.SeqFlatMap(unify4g.SeqFromSlice([]int{1, 2, 3}), func(n int) iter.Seq[int] {
+		return unify4g.SeqFromSlice([]int{n, n * 10})
+	})
+	unify4g.AssertEqual(t, unify4g.SeqCount(seq), 6)
+	unify4g.AssertEqual(t, unify4g.SeqReduce(seq, func(acc, n int) int { return acc + n }, 0), 66)
+	first, ok := unify4g.SeqFirst(seq)
+	if !ok || first != 1 {
+		t.Errorf("Expected first element to be 1 but got %d", first)
+	}
+	groups := unify4g.SeqGroupBy(seq, func(n int) bool { return n >= 10 })
+	unify4g.AssertEqual(t, groups[true], []int{10, 20, 30})
+}
+
+func TestSeq_Adapters(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	unify4g.AssertEqual(t, unify4g.SeqCollect(unify4g.SeqFromChan(ch)), []int{1, 2, 3})
+
+	stack := unify4g.NewStack[int]()
+	stack.Push(1)
+	stack.Push(2)
+	unify4g.AssertEqual(t, unify4g.SeqCollect(stack.All()), []int{2, 1})
+
+	set := unify4g.NewHashSet(3, 1, 2)
+	values := unify4g.SeqCollect(set.All())
+	sort.Ints(values)
+	unify4g.AssertEqual(t, values, []int{1, 2, 3})
+
+	hashMap := unify4g.NewHashMap[string, int]()
+	hashMap.Put("a", 1)
+	keys := unify4g.SeqCollect(unify4g.SeqKeys(hashMap.All()))
+	unify4g.AssertEqual(t, keys, []string{"a"})
+	unify4g.AssertEqual(t, unify4g.SeqCollect(unify4g.SeqValues(unify4g.SeqFromMap(map[string]int{"x": 9}))), []int{9})
+}
@@ -0,0 +1,66 @@
+package example_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+type employee struct {
+	Name       string
+	Department string
+	Salary     int
+}
+
+func TestSortBy_MultiKeyStable(t *testing.T) {
+	staff := []employee{
+		{"dave", "ops", 50}, {"alice", "dev", 70}, {"bob", "dev", 90},
+		{"carol", "ops", 50}, {"erin", "dev", 70},
+	}
+	sorted := unify4g.SortBy(staff,
+		unify4g.Asc(func(e employee) string { return e.Department }),
+		unify4g.Desc(func(e employee) int { return e.Salary }))
+	names := unify4g.Map(sorted, func(e employee) string { return e.Name })
+	unify4g.AssertEqual(t, names, []string{"bob", "alice", "erin", "dave", "carol"})
+	unify4g.AssertEqual(t, staff[0].Name, "dave")
+	unify4g.AssertTrue(t, unify4g.IsSorted(sorted, unify4g.Asc(func(e employee) string { return e.Department })))
+	unify4g.AssertFalse(t, unify4g.IsSorted(staff, unify4g.Asc(func(e employee) string { return e.Department })))
+}
+
+func TestNaturalCompare(t *testing.T) {
+	files := []string{"file10.txt", "file2.txt", "file1.txt", "File3.txt", "file02.txt", "file"}
+	slices.SortFunc(files, unify4g.NaturalCompare)
+	unify4g.AssertEqual(t, files, []string{"File3.txt", "file", "file1.txt", "file2.txt", "file02.txt", "file10.txt"})
+	unify4g.AssertEqual(t, unify4g.Sort([]string{"v1.10", "v1.9"}, unify4g.NaturalLess), []string{"v1.9", "v1.10"})
+	unify4g.AssertEqual(t, unify4g.NaturalCompare("x99999999999999999999", "x100000000000000000000"), -1)
+	unify4g.AssertEqual(t, unify4g.NaturalCompare("a1", "a1"), 0)
+}
+
+func TestCompareIgnoreAccents(t *testing.T) {
+	names := []string{"Zoë", "Émile", "adam", "elan"}
+	slices.SortFunc(names, unify4g.CompareIgnoreAccents)
+	unify4g.AssertEqual(t, names, []string{"adam", "elan", "Émile", "Zoë"})
+}
+
+func TestBinarySearchByAndTopK(t *testing.T) {
+	staff := []employee{{"a", "x", 10}, {"b", "x", 20}, {"c", "x", 30}}
+	index, found := unify4g.BinarySearchBy(staff, 20, func(e employee) int { return e.Salary })
+	unify4g.AssertTrue(t, found)
+	unify4g.AssertEqual(t, index, 1)
+	index, found = unify4g.BinarySearchBy(staff, 25, func(e employee) int { return e.Salary })
+	unify4g.AssertFalse(t, found)
+	unify4g.AssertEqual(t, index, 2)
+
+	numbers := []int{5, 1, 9, 3, 9, 7, 2}
+	byValue := unify4g.Desc(func(n int) int { return n })
+	unify4g.AssertEqual(t, unify4g.TopK(numbers, 3, byValue), []int{9, 9, 7})
+	unify4g.AssertEqual(t, unify4g.TopK(numbers, 100, byValue), unify4g.SortBy(numbers, byValue))
+	unify4g.AssertEqual(t, unify4g.TopK(numbers, 0, byValue), []int{})
+}
+
+func TestSortMapByValue(t *testing.T) {
+	scores := map[string]int{"bob": 3, "alice": 5, "carol": 3}
+	unify4g.AssertEqual(t, unify4g.SortMapByValue(scores), []unify4g.MapEntry[string, int]{{Key: "bob", Value: 3}, {Key: "carol", Value: 3}, {Key: "alice", Value: 5}})
+	unify4g.AssertEqual(t, unify4g.SortMapByValueDesc(scores)[0], unify4g.MapEntry[string, int]{Key: "alice", Value: 5})
+}
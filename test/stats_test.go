@@ -0,0 +1,100 @@
+package example_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestStats_MinMax(t *testing.T) {
+	smallest, ok := unify4g.Min([]int{3, 1, 2})
+	unify4g.AssertTrue(t, ok)
+	unify4g.AssertEqual(t, smallest, 1)
+	largest, _ := unify4g.Max([]string{"b", "c", "a"})
+	unify4g.AssertEqual(t, largest, "c")
+	_, ok = unify4g.Min([]int{})
+	unify4g.AssertFalse(t, ok)
+
+	words := []string{"go", "rust", "c", "java"}
+	shortest, _ := unify4g.MinBy(words, func(s string) int { return len(s) })
+	longest, _ := unify4g.MaxBy(words, func(s string) int { return len(s) })
+	unify4g.AssertEqual(t, shortest, "c")
+	unify4g.AssertEqual(t, longest, "rust")
+}
+
+func TestStats_CentralTendency(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	mean, _ := unify4g.Mean(data)
+	variance, _ := unify4g.Variance(data)
+	deviation, _ := unify4g.StdDev(data)
+	sample, _ := unify4g.SampleVariance(data)
+	if !approxEqual(mean, 5) || !approxEqual(variance, 4) || !approxEqual(deviation, 2) || !approxEqual(sample, 32.0/7) {
+		t.Errorf("Unexpected statistics: mean=%v variance=%v stddev=%v sample=%v", mean, variance, deviation, sample)
+	}
+	median, _ := unify4g.Median([]int{5, 1, 4, 2})
+	unify4g.AssertEqual(t, median, 3.0)
+	unify4g.AssertEqual(t, unify4g.Mode([]int{1, 2, 2, 3, 3}), []int{2, 3})
+	_, ok := unify4g.SampleVariance([]int{1})
+	unify4g.AssertFalse(t, ok)
+}
+
+func TestStats_Percentile(t *testing.T) {
+	latencies := []int{40, 10, 30, 20}
+	cases := []struct {
+		method   unify4g.PercentileMethod
+		expected float64
+	}{
+		{unify4g.PercentileLinear, 37},
+		{unify4g.PercentileLower, 30},
+		{unify4g.PercentileHigher, 40},
+		{unify4g.PercentileNearest, 40},
+		{unify4g.PercentileMidpoint, 35},
+	}
+	for _, c := range cases {
+		got, ok := unify4g.Percentile(latencies, 90, c.method)
+		if !ok || !approxEqual(got, c.expected) {
+			t.Errorf("Expected %v for method %d but got %v", c.expected, c.method, got)
+		}
+	}
+	unify4g.AssertEqual(t, latencies, []int{40, 10, 30, 20})
+	_, ok := unify4g.Percentile(latencies, 101, unify4g.PercentileLinear)
+	unify4g.AssertFalse(t, ok)
+}
+
+func TestStats_Histogram(t *testing.T) {
+	buckets := unify4g.Histogram([]int{1, 5, 7, 10, 12}, []float64{0, 5, 10})
+	unify4g.AssertEqual(t, buckets, []unify4g.HistogramBucket{{Lower: 0, Upper: 5, Count: 1}, {Lower: 5, Upper: 10, Count: 3}})
+
+	buckets = unify4g.HistogramN([]int{0, 1, 2, 3, 4, 10}, 2)
+	unify4g.AssertEqual(t, buckets, []unify4g.HistogramBucket{{Lower: 0, Upper: 5, Count: 5}, {Lower: 5, Upper: 10, Count: 1}})
+	unify4g.AssertEqual(t, len(unify4g.Histogram([]int{1}, []float64{5, 1})), 0)
+}
+
+func TestStats_Welford(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	left := unify4g.NewWelford(data[:3]...)
+	right := unify4g.NewWelford(data[3:]...)
+	left.Merge(right)
+	unify4g.AssertEqual(t, left.Count(), 8)
+	if !approxEqual(left.Mean(), 5) || !approxEqual(left.Variance(), 4) || !approxEqual(left.StdDev(), 2) {
+		t.Errorf("Unexpected merged statistics: mean=%v variance=%v", left.Mean(), left.Variance())
+	}
+	unify4g.AssertEqual(t, left.Min(), 2.0)
+	unify4g.AssertEqual(t, left.Max(), 9.0)
+
+	// Large offsets lose precision with the naive sum-of-squares formula but not with Welford.
+	acc := unify4g.NewWelford()
+	for _, v := range []float64{4, 7, 13, 16} {
+		acc.Add(1e9 + v)
+	}
+	if !approxEqual(acc.SampleVariance(), 30) {
+		t.Errorf("Expected sample variance 30 but got %v", acc.SampleVariance())
+	}
+	acc.Reset()
+	unify4g.AssertEqual(t, acc.Count(), 0)
+}
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sivaosorg/unify4g"
 )
@@ -466,3 +467,140 @@ func TestAppendIfMissingIgnoreCase(t *testing.T) {
 		}
 	}
 }
+
+func TestPluralizeAndSingularize(t *testing.T) {
+	tests := []struct {
+		singular string
+		plural   string
+	}{
+		{"box", "boxes"},
+		{"city", "cities"},
+		{"day", "days"},
+		{"person", "people"},
+		{"child", "children"},
+		{"sheep", "sheep"},
+		{"knife", "knives"},
+		{"wolf", "wolves"},
+		{"matrix", "matrices"},
+		{"status", "statuses"},
+		{"analysis", "analyses"},
+		{"mouse", "mice"},
+		{"quiz", "quizzes"},
+		{"potato", "potatoes"},
+		{"Category", "Categories"},
+		{"USER", "USERS"},
+		{"Person", "People"},
+		{"user account", "user accounts"},
+		{"news", "news"},
+	}
+
+	for _, tt := range tests {
+		if result := unify4g.Pluralize(tt.singular); result != tt.plural {
+			t.Errorf("Pluralize(%q) = %q; want %q", tt.singular, result, tt.plural)
+		}
+		if result := unify4g.Singularize(tt.plural); result != tt.singular {
+			t.Errorf("Singularize(%q) = %q; want %q", tt.plural, result, tt.singular)
+		}
+	}
+	unify4g.AssertEqual(t, unify4g.Pluralize("people"), "people")
+	unify4g.AssertEqual(t, unify4g.Singularize("person"), "person")
+	unify4g.AssertEqual(t, unify4g.Pluralize(""), "")
+	unify4g.AssertEqual(t, unify4g.Pluralize("ID"), "IDs")
+	unify4g.AssertEqual(t, unify4g.Pluralize("API"), "APIs")
+	unify4g.AssertEqual(t, unify4g.Pluralize("user ID"), "user IDs")
+	unify4g.AssertEqual(t, unify4g.Pluralize("IDs"), "IDs")
+	unify4g.AssertEqual(t, unify4g.Singularize("URLs"), "URL")
+	unify4g.AssertEqual(t, unify4g.PluralizeCount("CPU", 4), "4 CPUs")
+}
+
+func TestInflectorCustomRules(t *testing.T) {
+	inflector := unify4g.NewInflector().
+		AddIrregular("cactus", "cacti").
+		AddPlural(`(rad)ius$`, "${1}ii").
+		AddSingular(`(rad)ii$`, "${1}ius").
+		AddUncountable("luggage")
+	unify4g.AssertEqual(t, inflector.Pluralize("cactus"), "cacti")
+	unify4g.AssertEqual(t, inflector.Singularize("cacti"), "cactus")
+	unify4g.AssertEqual(t, inflector.Pluralize("radius"), "radii")
+	unify4g.AssertEqual(t, inflector.Singularize("radii"), "radius")
+	unify4g.AssertEqual(t, inflector.Pluralize("luggage"), "luggage")
+	unify4g.AssertEqual(t, unify4g.Pluralize("luggage"), "luggages")
+	unify4g.AssertEqual(t, unify4g.PluralizeCount("file", 1), "1 file")
+	unify4g.AssertEqual(t, unify4g.PluralizeCount("file", 0), "0 files")
+}
+
+func TestHumanizeAndOrdinalize(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.Humanize("user_id"), "User ID")
+	unify4g.AssertEqual(t, unify4g.Humanize("createdAt"), "Created at")
+	unify4g.AssertEqual(t, unify4g.Humanize("HTTPStatusCode"), "HTTP status code")
+	unify4g.AssertEqual(t, unify4g.Humanize(""), "")
+
+	tests := map[int]string{0: "0th", 1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd", 112: "112th", 101: "101st", -1: "-1st"}
+	for number, expected := range tests {
+		unify4g.AssertEqual(t, unify4g.Ordinalize(number), expected)
+	}
+}
+
+func TestNumberToWords(t *testing.T) {
+	tests := map[int64]string{
+		0:                    "zero",
+		7:                    "seven",
+		15:                   "fifteen",
+		42:                   "forty-two",
+		100:                  "one hundred",
+		123:                  "one hundred twenty-three",
+		1000:                 "one thousand",
+		1250300:              "one million two hundred fifty thousand three hundred",
+		-42:                  "minus forty-two",
+		-9223372036854775808: "minus nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight",
+	}
+	for number, expected := range tests {
+		unify4g.AssertEqual(t, unify4g.NumberToWords(number), expected)
+	}
+}
+
+func TestHumanizeAndParseBytes(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.HumanizeBytes(0), "0 B")
+	unify4g.AssertEqual(t, unify4g.HumanizeBytes(512), "512 B")
+	unify4g.AssertEqual(t, unify4g.HumanizeBytes(1024), "1 KiB")
+	unify4g.AssertEqual(t, unify4g.HumanizeBytes(1572864), "1.5 MiB")
+	unify4g.AssertEqual(t, unify4g.HumanizeBytes(1048575), "1 MiB")
+	unify4g.AssertEqual(t, unify4g.HumanizeBytesSI(1500000), "1.5 MB")
+	unify4g.AssertEqual(t, unify4g.HumanizeBytesSI(999), "999 B")
+
+	tests := map[string]uint64{
+		"512":     512,
+		"1.5 MiB": 1572864,
+		"10MB":    10000000,
+		"2 kib":   2048,
+		"1 GB":    1000000000,
+		" 3Ki ":   3072,
+		"1 byte":  1,
+	}
+	for input, expected := range tests {
+		size, err := unify4g.ParseBytes(input)
+		unify4g.AssertNil(t, err)
+		unify4g.AssertEqual(t, size, expected)
+	}
+	for _, input := range []string{"", "MiB", "1.5 XB", "-1 KB", "100 EiB"} {
+		_, err := unify4g.ParseBytes(input)
+		unify4g.AssertNotNil(t, err)
+	}
+	size, _ := unify4g.ParseBytes(unify4g.HumanizeBytes(1572864))
+	unify4g.AssertEqual(t, size, uint64(1572864))
+}
+
+func TestHumanizeDurationAndRelativeTime(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.HumanizeDuration(0), "0s")
+	unify4g.AssertEqual(t, unify4g.HumanizeDuration(250*time.Millisecond), "250ms")
+	unify4g.AssertEqual(t, unify4g.HumanizeDuration(45*time.Second), "45s")
+	unify4g.AssertEqual(t, unify4g.HumanizeDuration(3*time.Hour+2*time.Minute+30*time.Second), "3h 2m")
+	unify4g.AssertEqual(t, unify4g.HumanizeDuration(24*time.Hour+5*time.Minute), "1d")
+	unify4g.AssertEqual(t, unify4g.HumanizeDuration(-90*time.Second), "-1m 30s")
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	unify4g.AssertEqual(t, unify4g.RelativeTime(now.Add(-3*time.Hour-2*time.Minute), now), "3h 2m ago")
+	unify4g.AssertEqual(t, unify4g.RelativeTime(now.Add(5*time.Minute), now), "in 5m")
+	unify4g.AssertEqual(t, unify4g.RelativeTime(now.Add(300*time.Millisecond), now), "just now")
+	unify4g.AssertEqual(t, unify4g.TimeAgo(time.Now().Add(-2*time.Hour)), "2h ago")
+}
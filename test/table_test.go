@@ -0,0 +1,114 @@
+package example_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.DisplayWidth("hello"), 5)
+	unify4g.AssertEqual(t, unify4g.DisplayWidth("日本語"), 6)
+	unify4g.AssertEqual(t, unify4g.DisplayWidth("café"), 4)
+	unify4g.AssertEqual(t, unify4g.DisplayWidth("café"), 4)
+	unify4g.AssertEqual(t, unify4g.DisplayWidth("🚀 go"), 5)
+	unify4g.AssertEqual(t, unify4g.DisplayWidth("\x1B[32mok\x1B[0m"), 2)
+	unify4g.AssertEqual(t, unify4g.RuneWidth('Ａ'), 2)
+}
+
+func TestTable_RenderText(t *testing.T) {
+	table := unify4g.NewTable("Name", "Age").
+		AddRow("Alice", 30).
+		AddRow("Bob", 4).
+		SetAlign(1, unify4g.TableAlignRight)
+	expected := "" +
+		"+-------+-----+\n" +
+		"| Name  | Age |\n" +
+		"+-------+-----+\n" +
+		"| Alice |  30 |\n" +
+		"| Bob   |   4 |\n" +
+		"+-------+-----+\n"
+	unify4g.AssertEqual(t, table.String(), expected)
+
+	expected = "" +
+		"┌──────┬───┐\n" +
+		"│ City │ # │\n" +
+		"├──────┼───┤\n" +
+		"│ 東京 │ 1 │\n" +
+		"│ Oslo │ 2 │\n" +
+		"└──────┴───┘\n"
+	unicode := unify4g.NewTableFromRows([][]string{{"City", "#"}, {"東京", "1"}, {"Oslo", "2"}})
+	unify4g.AssertEqual(t, unicode.Render(unify4g.TableFormatUnicode), expected)
+}
+
+func TestTable_OverflowAndSort(t *testing.T) {
+	table := unify4g.NewTable("File", "Note").
+		AddRow("file10", "a very long description").
+		AddRow("file2", "short").
+		SetMaxWidth(10).
+		SortByColumn(0, false)
+	rendered := table.String()
+	unify4g.AssertTrue(t, strings.Contains(rendered, "| file2  | short      |"))
+	unify4g.AssertTrue(t, strings.Contains(rendered, "| file10 | a very ... |"))
+	unify4g.AssertTrue(t, strings.Index(rendered, "file2 ") < strings.Index(rendered, "file10"))
+
+	table.SetOverflow(unify4g.TableOverflowWrap)
+	rendered = table.String()
+	unify4g.AssertTrue(t, strings.Contains(rendered, "| file10 | a very     |\n|        | long       |\n|        | descriptio |\n|        | n          |"))
+}
+
+type tableUser struct {
+	Name   string `table:"Name"`
+	Email  string `table:"E-mail"`
+	Secret string `table:"-"`
+	Tags   []string
+	hidden int
+}
+
+type tableLabel struct {
+	name string
+}
+
+func (label tableLabel) String() string {
+	return "#" + label.name
+}
+
+func TestTable_NilStringer(t *testing.T) {
+	table := unify4g.NewTable("a", "b").AddRow((*tableLabel)(nil), &tableLabel{name: "x"})
+	unify4g.AssertEqual(t, table.Render(unify4g.TableFormatCSV), "a,b\n,#x\n")
+}
+
+func TestTable_Sources(t *testing.T) {
+	table, err := unify4g.NewTableFromStructs([]*tableUser{{Name: "Ann", Email: "ann@x.io", Tags: []string{"a"}}, nil})
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, table.Render(unify4g.TableFormatCSV), "Name,E-mail,Tags\nAnn,ann@x.io,\"[\"\"a\"\"]\"\n,,\n")
+	_, err = unify4g.NewTableFromStructs([]int{1})
+	unify4g.AssertNotNil(t, err)
+
+	maps := unify4g.NewTableFromMaps([]map[string]interface{}{{"name": "A|B", "age": 3}, {"name": "C"}})
+	expected := "" +
+		"| age | name |\n" +
+		"| --: | ---- |\n" +
+		"|   3 | A\\|B |\n" +
+		"|     | C    |\n"
+	unify4g.AssertEqual(t, maps.SetAlign(0, unify4g.TableAlignRight).Render(unify4g.TableFormatMarkdown), expected)
+}
+
+func TestTable_HTMLAndStyle(t *testing.T) {
+	table := unify4g.NewTable("Key", "Value").AddRow("<b>", true).SetAlign(1, unify4g.TableAlignCenter)
+	expected := "<table>\n" +
+		"  <thead>\n" +
+		"    <tr><th>Key</th><th style=\"text-align: center\">Value</th></tr>\n" +
+		"  </thead>\n" +
+		"  <tbody>\n" +
+		"    <tr><td>&lt;b&gt;</td><td style=\"text-align: center\">true</td></tr>\n" +
+		"  </tbody>\n" +
+		"</table>\n"
+	unify4g.AssertEqual(t, table.Render(unify4g.TableFormatHTML), expected)
+
+	colored := table.SetStyle(unify4g.TerminalStyle).String()
+	unify4g.AssertTrue(t, strings.Contains(colored, unify4g.TerminalStyle.True[0]+"true"+unify4g.TerminalStyle.True[1]))
+	lines := strings.Split(colored, "\n")
+	unify4g.AssertEqual(t, unify4g.DisplayWidth(lines[1]), unify4g.DisplayWidth(lines[0]))
+}
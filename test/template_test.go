@@ -0,0 +1,104 @@
+package example_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+type templateUser struct {
+	Name  string   `json:"name"`
+	Email string   `json:"email"`
+	Tags  []string `json:"tags"`
+}
+
+func TestInterpolate(t *testing.T) {
+	data := map[string]interface{}{
+		"user":  map[string]interface{}{"name": "ada lovelace", "roles": []interface{}{"admin", "dev"}},
+		"count": 3,
+		"title": "A very long title that needs shortening",
+	}
+	cases := map[string]string{
+		"Hello ${user.name}":                   "Hello ada lovelace",
+		"Hello ${user.missing:-guest}":         "Hello guest",
+		"${user.name|title}":                   "Ada Lovelace",
+		"${user.name|upper|slug}":              "ada-lovelace",
+		"${title|abbrev:10}":                   "A very ...",
+		"${user.roles[1]} of ${count}":         "dev of 3",
+		"${user.roles|json}":                   `["admin","dev"]`,
+		"${user.missing}!":                     "!",
+		"cost: $${price} ${count}":             "cost: ${price} 3",
+		"${ user.name :-x|snake}":              "ada_lovelace",
+		"${user.name|truncate:6} / ${user.id}": "ada... / ",
+	}
+	for text, expected := range cases {
+		actual, err := unify4g.Interpolate(text, data)
+		unify4g.AssertNil(t, err)
+		unify4g.AssertEqual(t, actual, expected)
+	}
+
+	user := &templateUser{Name: "Bob", Tags: []string{"x"}}
+	actual, err := unify4g.Interpolate("${name} <${Email:-n/a}> ${tags[0]}", user)
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, actual, "Bob <n/a> x")
+}
+
+func TestTemplate_CompileErrors(t *testing.T) {
+	for _, text := range []string{"${unclosed", "${}", "${name|unknown}", "${name|abbrev:x}", "${name|upper:1}", "${a[}"} {
+		_, err := unify4g.NewTemplate(text, nil)
+		unify4g.AssertNotNil(t, err)
+	}
+}
+
+func TestTemplate_StrictAndEscaping(t *testing.T) {
+	strict, err := unify4g.NewTemplate("${name} ${nick:-none}", &unify4g.TemplateOptionsConfig{Strict: true})
+	unify4g.AssertNil(t, err)
+	actual, err := strict.Execute(map[string]string{"name": "x"})
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, actual, "x none")
+	_, err = strict.Execute(map[string]string{})
+	unify4g.AssertNotNil(t, err)
+	unify4g.AssertTrue(t, strings.Contains(err.Error(), "${name}"))
+
+	data := map[string]string{"dir": "it's here", "title": "Tom & Jerry", "table": `odd"name`}
+	shell, _ := unify4g.InterpolateWith("ls ${dir}", data, &unify4g.TemplateOptionsConfig{Escape: unify4g.TemplateEscapeShell})
+	unify4g.AssertEqual(t, shell, `ls 'it'\''s here'`)
+	page, _ := unify4g.InterpolateWith("<b>${title}</b>${title|raw}", data, &unify4g.TemplateOptionsConfig{Escape: unify4g.TemplateEscapeHTML})
+	unify4g.AssertEqual(t, page, "<b>Tom &amp; Jerry</b>Tom & Jerry")
+	query, _ := unify4g.Interpolate("SELECT * FROM ${table|sql}", data)
+	unify4g.AssertEqual(t, query, `SELECT * FROM "odd""name"`)
+
+	options := &unify4g.TemplateOptionsConfig{Escape: unify4g.TemplateEscapeShell}
+	command, _ := unify4g.InterpolateWith("echo ${x|html}", map[string]string{"x": "a; rm -rf ~ & <b>"}, options)
+	unify4g.AssertEqual(t, command, "echo 'a; rm -rf ~ &amp; &lt;b&gt;'")
+	command, _ = unify4g.InterpolateWith("echo ${x|shell} ${x|raw|upper} ${x|upper|raw}", map[string]string{"x": "a b"}, options)
+	unify4g.AssertEqual(t, command, "echo 'a b' 'A B' A B")
+	command, _ = unify4g.InterpolateWith("echo ${x|shell|abbrev:6} && ls", map[string]string{"x": "hello world"}, options)
+	unify4g.AssertEqual(t, command, `echo ''\''he...' && ls`)
+
+	html := &unify4g.TemplateOptionsConfig{Escape: unify4g.TemplateEscapeHTML}
+	page, _ = unify4g.InterpolateWith("${x|html|upper} ${x|html|truncate:3} ${x|html}", map[string]string{"x": "&"}, html)
+	unify4g.AssertEqual(t, page, "&amp;AMP; &amp;am &amp;")
+}
+
+type TemplateInner struct {
+	Name string
+}
+
+type templateOuter struct {
+	*TemplateInner
+}
+
+func TestTemplate_MissingRoots(t *testing.T) {
+	actual, err := unify4g.Interpolate("${a:-none}", nil)
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, actual, "none")
+	actual, err = unify4g.Interpolate("[${Name}]", templateOuter{})
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, actual, "[]")
+	_, err = unify4g.InterpolateWith("${Name}", templateOuter{}, &unify4g.TemplateOptionsConfig{Strict: true})
+	unify4g.AssertNotNil(t, err)
+	actual, _ = unify4g.Interpolate("${Name}", templateOuter{&TemplateInner{Name: "ada"}})
+	unify4g.AssertEqual(t, actual, "ada")
+}
@@ -0,0 +1,79 @@
+package example_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestTryMap(t *testing.T) {
+	numbers, err := unify4g.TryMap([]string{"1", "2", "3"}, strconv.Atoi)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	unify4g.AssertEqual(t, numbers, []int{1, 2, 3})
+
+	calls := 0
+	_, err = unify4g.TryMap([]string{"1", "x", "y"}, func(s string) (int, error) {
+		calls++
+		return strconv.Atoi(s)
+	})
+	var indexed *unify4g.IndexedError
+	if !errors.As(err, &indexed) || indexed.Index != 1 {
+		t.Fatalf("Expected IndexedError at index 1 but got %v", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Expected error to unwrap to strconv.ErrSyntax")
+	}
+	unify4g.AssertEqual(t, calls, 2)
+}
+
+func TestTryMapCollect(t *testing.T) {
+	numbers, err := unify4g.TryMapCollect([]string{"1", "x", "3", "y"}, strconv.Atoi)
+	unify4g.AssertEqual(t, numbers, []int{1, 0, 3, 0})
+	if err == nil || !strings.Contains(err.Error(), "index 1:") || !strings.Contains(err.Error(), "index 3:") {
+		t.Errorf("Expected joined errors for index 1 and 3 but got %v", err)
+	}
+}
+
+func TestTryFilterAndGroupBy(t *testing.T) {
+	isPositive := func(s string) (bool, error) {
+		n, err := strconv.Atoi(s)
+		return n > 0, err
+	}
+	kept, err := unify4g.TryFilterCollect([]string{"1", "-2", "z", "4"}, isPositive)
+	unify4g.AssertEqual(t, kept, []string{"1", "4"})
+	unify4g.AssertNotNil(t, err)
+
+	groups, err := unify4g.TryGroupBy([]string{"a1", "b2", "a3"}, func(s string) (string, error) {
+		return s[:1], nil
+	})
+	unify4g.AssertNil(t, err)
+	unify4g.AssertEqual(t, groups["a"], []string{"a1", "a3"})
+
+	_, err = unify4g.TrySliceToMap([]string{"1", "?"}, strconv.Atoi)
+	unify4g.AssertNotNil(t, err)
+}
+
+func TestTryReduceAndForEach(t *testing.T) {
+	total, err := unify4g.TryReduce([]string{"1", "2", "x", "4"}, func(acc int, s string) (int, error) {
+		n, err := strconv.Atoi(s)
+		return acc + n, err
+	}, 0)
+	unify4g.AssertEqual(t, total, 3)
+	unify4g.AssertNotNil(t, err)
+
+	visited := 0
+	err = unify4g.TryForEachCollect([]int{1, 2, 3}, func(n int) error {
+		visited++
+		if n%2 == 1 {
+			return errors.New("odd")
+		}
+		return nil
+	})
+	unify4g.AssertEqual(t, visited, 3)
+	unify4g.AssertEqual(t, err.Error(), "index 0: odd\nindex 2: odd")
+}
@@ -0,0 +1,197 @@
+package example_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+type validateAddress struct {
+	Street string `validate:"required"`
+	Zip    string `validate:"numeric,len=5"`
+}
+
+type validateSignUp struct {
+	ID       string                     `validate:"uuid"`
+	Name     string                     `validate:"required,min=3,max=10,alphanum"`
+	Email    string                     `validate:"required,email"`
+	Role     string                     `validate:"oneof=admin editor viewer"`
+	Password string                     `validate:"required,min=8"`
+	Confirm  string                     `validate:"eqfield=Password"`
+	Website  string                     `validate:"omitempty,url"`
+	Age      int                        `validate:"gte=18,lt=130"`
+	Tags     []string                   `validate:"max=3,dive,lowercase"`
+	Address  *validateAddress           `validate:"required"`
+	Others   []validateAddress          `validate:"omitempty"`
+	Labels   map[string]validateAddress ``
+	Start    time.Time
+	End      time.Time `validate:"gtfield=Start"`
+	Company  string    `validate:"required_if=Role admin"`
+	internal string
+}
+
+func validSignUp() validateSignUp {
+	now := time.Now()
+	return validateSignUp{
+		ID:       "123e4567-e89b-12d3-a456-426614174000",
+		Name:     "alice42",
+		Email:    "alice@example.com",
+		Role:     "editor",
+		Password: "s3cretpass",
+		Confirm:  "s3cretpass",
+		Age:      30,
+		Tags:     []string{"go"},
+		Address:  &validateAddress{Street: "Main", Zip: "12345"},
+		Start:    now,
+		End:      now.Add(time.Hour),
+	}
+}
+
+func TestValidate_Valid(t *testing.T) {
+	request := validSignUp()
+	unify4g.AssertNil(t, unify4g.Validate(request))
+	unify4g.AssertNil(t, unify4g.Validate(&request))
+}
+
+func TestValidate_Errors(t *testing.T) {
+	request := validSignUp()
+	request.Name = "al"
+	request.Email = "not-an-email"
+	request.Role = "root"
+	request.Confirm = "other"
+	request.Website = "example"
+	request.Age = 12
+	request.Tags = []string{"ok", "NotOk"}
+	request.Address.Zip = "12a45"
+	request.Others = []validateAddress{{Street: "", Zip: "00000"}}
+	request.Labels = map[string]validateAddress{"home": {Street: "x", Zip: "1"}}
+	request.End = request.Start
+
+	err := unify4g.Validate(request)
+	var fieldErrors unify4g.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		t.Fatalf("Expected ValidationErrors but got %v", err)
+	}
+	fields := fieldErrors.Fields()
+	expected := map[string]string{
+		"Name":             "min",
+		"Email":            "email",
+		"Role":             "oneof",
+		"Confirm":          "eqfield",
+		"Website":          "url",
+		"Age":              "gte",
+		"Tags[1]":          "lowercase",
+		"Address.Zip":      "numeric",
+		"Others[0].Street": "required",
+		"Labels[home].Zip": "len",
+		"End":              "gtfield",
+	}
+	for field, rule := range expected {
+		if fe, ok := fields[field]; !ok || fe.Rule != rule {
+			t.Errorf("Expected %s to fail rule %s but got %+v", field, rule, fe)
+		}
+	}
+	unify4g.AssertEqual(t, len(fieldErrors), len(expected))
+	unify4g.AssertEqual(t, fields["Name"].Message, "Name must be at least 3")
+	unify4g.AssertEqual(t, fields["Role"].Message, "Role must be one of [admin editor viewer]")
+}
+
+func TestValidate_CrossFieldAndRequired(t *testing.T) {
+	request := validSignUp()
+	request.Role = "admin"
+	request.Address = nil
+	err := unify4g.Validate(request)
+	fields := err.(unify4g.ValidationErrors).Fields()
+	unify4g.AssertEqual(t, fields["Company"].Rule, "required_if")
+	unify4g.AssertEqual(t, fields["Address"].Rule, "required")
+}
+
+type validateCustom struct {
+	Count int    `validate:"even"`
+	Code  string `validate:"required"`
+}
+
+func TestValidate_CustomRulesAndMessages(t *testing.T) {
+	validator := unify4g.NewValidator().
+		RegisterRule("even", func(f unify4g.ValidationField) bool { return f.Value.Int()%2 == 0 }).
+		SetMessage("even", "{field} doit être pair ({value})").
+		SetMessage("required", "{field} est obligatoire")
+	err := validator.Validate(validateCustom{Count: 3})
+	unify4g.AssertEqual(t, err.Error(), "Count doit être pair (3); Code est obligatoire")
+
+	err = unify4g.Validate(validateCustom{Count: 2, Code: "x"})
+	if err == nil || errors.As(err, new(unify4g.ValidationErrors)) {
+		t.Errorf("Expected an unknown rule error but got %v", err)
+	}
+	unify4g.AssertNotNil(t, unify4g.Validate(42))
+}
+
+type validateKindForm struct {
+	Kind  *string
+	Value string `validate:"required_if=Kind custom"`
+}
+
+type validateNode struct {
+	Name string `validate:"required"`
+	Next *validateNode
+}
+
+func TestValidate_NilSiblingAndCycles(t *testing.T) {
+	unify4g.AssertNil(t, unify4g.Validate(validateKindForm{}))
+	kind := "custom"
+	unify4g.AssertNotNil(t, unify4g.Validate(validateKindForm{Kind: &kind}))
+
+	node := &validateNode{}
+	node.Next = node
+	err := unify4g.Validate(node)
+	unify4g.AssertNotNil(t, err)
+	unify4g.AssertEqual(t, len(err.(unify4g.ValidationErrors)), 1)
+
+	second := &validateNode{Next: &validateNode{Name: "b"}}
+	second.Next.Next = second
+	err = unify4g.Validate(second)
+	unify4g.AssertEqual(t, len(err.(unify4g.ValidationErrors)), 1)
+	unify4g.AssertEqual(t, err.(unify4g.ValidationErrors)[0].Field, "Name")
+}
+
+type validateUnexportedSibling struct {
+	Tags []string `validate:"eqfield=tags"`
+	tags []string
+}
+
+type validatePeriod struct {
+	Start time.Time `validate:"ltfield=end"`
+	end   time.Time
+}
+
+type validateMisspelledSibling struct {
+	Email string `validate:"required_with=Phnoe"`
+	Phone string
+}
+
+func TestValidate_InvalidSiblings(t *testing.T) {
+	inputs := []interface{}{
+		validateUnexportedSibling{Tags: []string{"a"}, tags: []string{"a"}},
+		validatePeriod{Start: time.Now(), end: time.Now().Add(time.Hour)},
+		validateMisspelledSibling{Phone: "123"},
+	}
+	for _, input := range inputs {
+		err := unify4g.Validate(input)
+		if err == nil || errors.As(err, new(unify4g.ValidationErrors)) {
+			t.Errorf("Expected a configuration error for %T but got %v", input, err)
+		}
+	}
+}
+
+type validateOptionalTags struct {
+	Tags *[]string `validate:"dive,lowercase"`
+}
+
+func TestValidate_DiveNilPointer(t *testing.T) {
+	unify4g.AssertNil(t, unify4g.Validate(validateOptionalTags{}))
+	tags := []string{"ok", "BAD"}
+	err := unify4g.Validate(validateOptionalTags{Tags: &tags})
+	unify4g.AssertEqual(t, err.(unify4g.ValidationErrors)[0].Field, "Tags[1]")
+}
@@ -0,0 +1,70 @@
+package example_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestWindow(t *testing.T) {
+	numbers := []int{1, 2, 3, 4, 5}
+	unify4g.AssertEqual(t, unify4g.Window(numbers, 3, 1), [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}})
+	unify4g.AssertEqual(t, unify4g.Window(numbers, 2, 2), [][]int{{1, 2}, {3, 4}})
+	unify4g.AssertEqual(t, len(unify4g.Window(numbers, 6, 1)), 0)
+	unify4g.AssertNil(t, unify4g.Window(numbers, 0, 1))
+
+	windows := unify4g.Window(numbers, 2, 1)
+	_ = append(windows[0], 99)
+	unify4g.AssertEqual(t, numbers, []int{1, 2, 3, 4, 5})
+}
+
+func TestPairwiseAndChunkBy(t *testing.T) {
+	pairs := unify4g.Pairwise([]int{10, 12, 15})
+	unify4g.AssertEqual(t, pairs, []unify4g.Pair[int, int]{{First: 10, Second: 12}, {First: 12, Second: 15}})
+	unify4g.AssertEqual(t, len(unify4g.Pairwise([]int{1})), 0)
+
+	chunks := unify4g.ChunkBy([]int{1, 3, 2, 4, 5}, func(n int) bool { return n%2 == 0 })
+	unify4g.AssertEqual(t, chunks, [][]int{{1, 3}, {2, 4}, {5}})
+	unify4g.AssertNil(t, unify4g.ChunkBy([]int{}, func(n int) int { return n }))
+}
+
+func TestSplitAtInterleaveTranspose(t *testing.T) {
+	head, tail := unify4g.SplitAt([]int{1, 2, 3, 4}, 1)
+	unify4g.AssertEqual(t, head, []int{1})
+	unify4g.AssertEqual(t, tail, []int{2, 3, 4})
+	head, tail = unify4g.SplitAt([]int{1, 2}, 10)
+	unify4g.AssertEqual(t, head, []int{1, 2})
+	unify4g.AssertEqual(t, tail, []int{})
+
+	unify4g.AssertEqual(t, unify4g.Interleave([]int{1, 2, 3}, []int{10, 20}, []int{100}), []int{1, 10, 100, 2, 20, 3})
+	unify4g.AssertEqual(t, unify4g.Transpose([][]int{{1, 2, 3}, {4, 5, 6}}), [][]int{{1, 4}, {2, 5}, {3, 6}})
+	unify4g.AssertEqual(t, unify4g.Transpose([][]int{{1, 2}, {3}}), [][]int{{1, 3}, {2}})
+}
+
+func TestFillAndRange(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.Fill(make([]string, 3), "-"), []string{"-", "-", "-"})
+	unify4g.AssertEqual(t, unify4g.Range(0, 5), []int{0, 1, 2, 3, 4})
+	unify4g.AssertEqual(t, unify4g.RangeStep(0, 10, 3), []int{0, 3, 6, 9})
+	unify4g.AssertEqual(t, unify4g.RangeStep(3, 0, -1), []int{3, 2, 1})
+	unify4g.AssertEqual(t, unify4g.RangeStep(0.0, 1.0, 0.25), []float64{0, 0.25, 0.5, 0.75})
+	unify4g.AssertEqual(t, unify4g.RangeStep(0, 5, 0), []int{})
+	unify4g.AssertEqual(t, unify4g.RangeStep[uint8](250, 255, 3), []uint8{250, 253})
+	unify4g.AssertEqual(t, len(unify4g.RangeStep[int8](math.MaxInt8-1, math.MaxInt8, 5)), 1)
+}
+
+func TestSample(t *testing.T) {
+	colors := []string{"red", "green", "blue"}
+	color, ok := unify4g.Sample(colors)
+	unify4g.AssertTrue(t, ok)
+	unify4g.AssertTrue(t, unify4g.ContainsN(colors, color))
+	_, ok = unify4g.Sample([]string{})
+	unify4g.AssertFalse(t, ok)
+
+	numbers := unify4g.Range(0, 100)
+	picked := unify4g.SampleN(numbers, 10)
+	unify4g.AssertEqual(t, len(picked), 10)
+	unify4g.AssertEqual(t, len(unify4g.Unique(picked)), 10)
+	unify4g.AssertEqual(t, numbers, unify4g.Range(0, 100))
+	unify4g.AssertEqual(t, len(unify4g.SampleN(colors, 10)), 3)
+}
@@ -0,0 +1,48 @@
+package example_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sivaosorg/unify4g"
+)
+
+func TestWordWrap(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.WordWrap("The quick  brown fox jumps", 10), "The quick\nbrown fox\njumps")
+	unify4g.AssertEqual(t, unify4g.WordWrap("日本語のテキスト", 6), "日本語\nのテキ\nスト")
+	unify4g.AssertEqual(t, unify4g.WordWrap("first line\n\nthird", 20), "first line\n\nthird")
+	unify4g.AssertEqual(t, unify4g.WordWrap("unchanged", 0), "unchanged")
+	unify4g.AssertEqual(t, unify4g.WordWrap("cafés abc", 4), "café\ns\nabc")
+
+	hyphenated := unify4g.WordWrapWith("internationalization rocks", 8, &unify4g.WrapOptionsConfig{Hyphenate: true})
+	unify4g.AssertEqual(t, hyphenated, "interna-\ntionali-\nzation\nrocks")
+	soft := unify4g.WordWrapWith("see https://example.com/a/long/path", 10, &unify4g.WrapOptionsConfig{SoftBreak: true})
+	unify4g.AssertEqual(t, soft, "see\nhttps://example.com/a/long/path")
+}
+
+func TestWordWrap_ANSI(t *testing.T) {
+	wrapped := unify4g.WordWrap("\x1B[31mred words here\x1B[0m plain", 9)
+	unify4g.AssertEqual(t, wrapped, "\x1B[31mred words\x1B[0m\n\x1B[31mhere\x1B[0m\nplain")
+	for _, line := range strings.Split(wrapped, "\n") {
+		unify4g.AssertTrue(t, unify4g.DisplayWidth(line) <= 9)
+	}
+}
+
+func TestPadding(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.PadLeft("42", 5, '0'), "00042")
+	unify4g.AssertEqual(t, unify4g.PadLeft("日本", 6, ' '), "  日本")
+	unify4g.AssertEqual(t, unify4g.PadRight("名前", 6, '.'), "名前..")
+	unify4g.AssertEqual(t, unify4g.Center("go", 7, '*'), "**go***")
+	unify4g.AssertEqual(t, unify4g.PadLeft("a", 4, '世'), "世 a")
+	unify4g.AssertEqual(t, unify4g.PadRight("\x1B[1mok\x1B[0m", 4, ' '), "\x1B[1mok\x1B[0m  ")
+	unify4g.AssertEqual(t, unify4g.Center("too long", 3, ' '), "too long")
+}
+
+func TestTruncate(t *testing.T) {
+	unify4g.AssertEqual(t, unify4g.Truncate("Hello, World", 8), "Hello...")
+	unify4g.AssertEqual(t, unify4g.Truncate("日本語テキスト", 7), "日本...")
+	unify4g.AssertEqual(t, unify4g.Truncate("short", 10), "short")
+	unify4g.AssertEqual(t, unify4g.Truncate("abcdef", 3), "abc")
+	unify4g.AssertEqual(t, unify4g.TruncateWith("Hello, World", 6, "…"), "Hello…")
+	unify4g.AssertEqual(t, unify4g.Truncate("\x1B[32mgreen text\x1B[0m", 7), "\x1B[32mgree\x1B[0m...")
+}
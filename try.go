@@ -0,0 +1,335 @@
+package unify4g
+
+import (
+	"errors"
+	"fmt"
+)
+
+// IndexedError wraps an error returned while processing the element at position `Index`
+// of a slice. It is returned by the `Try*` collection helpers so that callers can tell
+// which element failed, and it unwraps to the original error for use with `errors.Is`
+// and `errors.As`.
+type IndexedError struct {
+	Index int   `json:"index"`
+	Err   error `json:"-"`
+}
+
+// Error returns the message of the wrapped error prefixed by the index of the failing element.
+//
+// Returns:
+//   - A string such as "index 3: invalid syntax".
+func (e *IndexedError) Error() string {
+	return fmt.Sprintf("index %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the wrapped error.
+//
+// Returns:
+//   - The error produced for the failing element.
+func (e *IndexedError) Unwrap() error {
+	return e.Err
+}
+
+// TryMap applies a fallible transformation `f` to each element of `list`, stopping at the first error.
+//
+// It behaves like `Map`, except that `f` may fail. As soon as `f` returns an error, processing stops
+// and the error is returned wrapped in an `*IndexedError` carrying the position of the failing element.
+//
+// Parameters:
+//   - `list`: The slice of elements to transform.
+//   - `f`: The transformation, returning the new value or an error.
+//
+// Returns:
+//   - A new slice with the transformed elements, or nil if an error occurred.
+//   - An `*IndexedError` describing the first failure, or nil.
+//
+// Example:
+//
+//	numbers, err := TryMap([]string{"1", "x", "3"}, strconv.Atoi)
+//	// numbers will be nil, err will be "index 1: strconv.Atoi: parsing \"x\": invalid syntax"
+func TryMap[T, U any](list []T, f func(T) (U, error)) ([]U, error) {
+	result := make([]U, len(list))
+	for i, item := range list {
+		v, err := f(item)
+		if err != nil {
+			return nil, &IndexedError{Index: i, Err: err}
+		}
+		result[i] = v
+	}
+	return result, nil
+}
+
+// TryMapCollect applies a fallible transformation `f` to every element of `list`, collecting all errors.
+//
+// Unlike `TryMap`, processing continues after a failure. The returned slice always has the length of
+// `list`; positions whose transformation failed hold the zero value of `U`. All failures are combined
+// with `errors.Join`, each wrapped in an `*IndexedError`.
+//
+// Parameters:
+//   - `list`: The slice of elements to transform.
+//   - `f`: The transformation, returning the new value or an error.
+//
+// Returns:
+//   - A new slice with the transformed elements (zero values where `f` failed).
+//   - The joined errors, or nil if every element succeeded.
+//
+// Example:
+//
+//	numbers, err := TryMapCollect([]string{"1", "x", "y"}, strconv.Atoi)
+//	// numbers will be []int{1, 0, 0}, err joins the failures at index 1 and 2
+func TryMapCollect[T, U any](list []T, f func(T) (U, error)) ([]U, error) {
+	result := make([]U, len(list))
+	var errs []error
+	for i, item := range list {
+		v, err := f(item)
+		if err != nil {
+			errs = append(errs, &IndexedError{Index: i, Err: err})
+			continue
+		}
+		result[i] = v
+	}
+	return result, errors.Join(errs...)
+}
+
+// TryFilter keeps the elements of `list` for which the fallible `condition` returns `true`,
+// stopping at the first error.
+//
+// Parameters:
+//   - `list`: The slice of elements to filter.
+//   - `condition`: The predicate, returning whether to keep the element or an error.
+//
+// Returns:
+//   - A new slice with the kept elements, or nil if an error occurred.
+//   - An `*IndexedError` describing the first failure, or nil.
+//
+// Example:
+//
+//	active, err := TryFilter(users, func(u User) (bool, error) { return isActive(u.ID) })
+func TryFilter[T any](list []T, condition func(T) (bool, error)) ([]T, error) {
+	filtered := make([]T, 0)
+	for i, item := range list {
+		ok, err := condition(item)
+		if err != nil {
+			return nil, &IndexedError{Index: i, Err: err}
+		}
+		if ok {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
+
+// TryFilterCollect keeps the elements of `list` for which the fallible `condition` returns `true`,
+// evaluating every element and collecting all errors. Elements whose evaluation failed are excluded.
+//
+// Parameters:
+//   - `list`: The slice of elements to filter.
+//   - `condition`: The predicate, returning whether to keep the element or an error.
+//
+// Returns:
+//   - A new slice with the kept elements.
+//   - The joined `*IndexedError` failures, or nil if every evaluation succeeded.
+//
+// Example:
+//
+//	active, err := TryFilterCollect(users, func(u User) (bool, error) { return isActive(u.ID) })
+func TryFilterCollect[T any](list []T, condition func(T) (bool, error)) ([]T, error) {
+	filtered := make([]T, 0)
+	var errs []error
+	for i, item := range list {
+		ok, err := condition(item)
+		if err != nil {
+			errs = append(errs, &IndexedError{Index: i, Err: err})
+			continue
+		}
+		if ok {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, errors.Join(errs...)
+}
+
+// TryReduce folds `slice` into a single value with a fallible `accumulator`, stopping at the first error.
+//
+// Because each step depends on the previous result, reduction cannot continue past a failure, so
+// there is no collecting variant.
+//
+// Parameters:
+//   - `slice`: The slice to reduce.
+//   - `accumulator`: Combines the running result with the next element, or returns an error.
+//   - `initialValue`: The starting value of the accumulation.
+//
+// Returns:
+//   - The accumulated value, or the value accumulated before the failure if an error occurred.
+//   - An `*IndexedError` describing the failure, or nil.
+//
+// Example:
+//
+//	total, err := TryReduce([]string{"1", "2"}, func(acc int, s string) (int, error) {
+//		n, err := strconv.Atoi(s)
+//		return acc + n, err
+//	}, 0)
+//	// total will be 3
+func TryReduce[T, U any](slice []T, accumulator func(U, T) (U, error), initialValue U) (U, error) {
+	result := initialValue
+	for i, item := range slice {
+		next, err := accumulator(result, item)
+		if err != nil {
+			return result, &IndexedError{Index: i, Err: err}
+		}
+		result = next
+	}
+	return result, nil
+}
+
+// TryGroupBy groups the elements of `slice` by a key computed with the fallible `getKey`,
+// stopping at the first error.
+//
+// Parameters:
+//   - `slice`: The slice to group.
+//   - `getKey`: Returns the grouping key of an element, or an error.
+//
+// Returns:
+//   - A map from each key to its elements in encounter order, or nil if an error occurred.
+//   - An `*IndexedError` describing the first failure, or nil.
+//
+// Example:
+//
+//	byDomain, err := TryGroupBy(emails, func(e string) (string, error) { return domainOf(e) })
+func TryGroupBy[T any, K comparable](slice []T, getKey func(T) (K, error)) (map[K][]T, error) {
+	result := make(map[K][]T)
+	for i, item := range slice {
+		key, err := getKey(item)
+		if err != nil {
+			return nil, &IndexedError{Index: i, Err: err}
+		}
+		result[key] = append(result[key], item)
+	}
+	return result, nil
+}
+
+// TryGroupByCollect groups the elements of `slice` by a key computed with the fallible `getKey`,
+// processing every element and collecting all errors. Elements whose key could not be computed
+// are left out of the result.
+//
+// Parameters:
+//   - `slice`: The slice to group.
+//   - `getKey`: Returns the grouping key of an element, or an error.
+//
+// Returns:
+//   - A map from each key to its elements in encounter order.
+//   - The joined `*IndexedError` failures, or nil if every key was computed.
+//
+// Example:
+//
+//	byDomain, err := TryGroupByCollect(emails, func(e string) (string, error) { return domainOf(e) })
+func TryGroupByCollect[T any, K comparable](slice []T, getKey func(T) (K, error)) (map[K][]T, error) {
+	result := make(map[K][]T)
+	var errs []error
+	for i, item := range slice {
+		key, err := getKey(item)
+		if err != nil {
+			errs = append(errs, &IndexedError{Index: i, Err: err})
+			continue
+		}
+		result[key] = append(result[key], item)
+	}
+	return result, errors.Join(errs...)
+}
+
+// TrySliceToMap builds a map from `slice` using the fallible `keyFunc`, stopping at the first error.
+// As with `SliceToMap`, later elements overwrite earlier ones sharing the same key.
+//
+// Parameters:
+//   - `slice`: The slice to index.
+//   - `keyFunc`: Returns the key of an element, or an error.
+//
+// Returns:
+//   - The resulting map, or nil if an error occurred.
+//   - An `*IndexedError` describing the first failure, or nil.
+//
+// Example:
+//
+//	byID, err := TrySliceToMap(rows, func(r Row) (int, error) { return strconv.Atoi(r.ID) })
+func TrySliceToMap[T any, K comparable](slice []T, keyFunc func(T) (K, error)) (map[K]T, error) {
+	result := make(map[K]T)
+	for i, item := range slice {
+		key, err := keyFunc(item)
+		if err != nil {
+			return nil, &IndexedError{Index: i, Err: err}
+		}
+		result[key] = item
+	}
+	return result, nil
+}
+
+// TrySliceToMapCollect builds a map from `slice` using the fallible `keyFunc`, processing every element
+// and collecting all errors. Elements whose key could not be computed are left out of the result.
+//
+// Parameters:
+//   - `slice`: The slice to index.
+//   - `keyFunc`: Returns the key of an element, or an error.
+//
+// Returns:
+//   - The resulting map.
+//   - The joined `*IndexedError` failures, or nil if every key was computed.
+//
+// Example:
+//
+//	byID, err := TrySliceToMapCollect(rows, func(r Row) (int, error) { return strconv.Atoi(r.ID) })
+func TrySliceToMapCollect[T any, K comparable](slice []T, keyFunc func(T) (K, error)) (map[K]T, error) {
+	result := make(map[K]T)
+	var errs []error
+	for i, item := range slice {
+		key, err := keyFunc(item)
+		if err != nil {
+			errs = append(errs, &IndexedError{Index: i, Err: err})
+			continue
+		}
+		result[key] = item
+	}
+	return result, errors.Join(errs...)
+}
+
+// TryForEach calls the fallible `f` for each element of `slice`, stopping at the first error.
+//
+// Parameters:
+//   - `slice`: The slice to iterate over.
+//   - `f`: The action executed for each element.
+//
+// Returns:
+//   - An `*IndexedError` describing the first failure, or nil.
+//
+// Example:
+//
+//	err := TryForEach(files, os.Remove)
+func TryForEach[T any](slice []T, f func(T) error) error {
+	for i, item := range slice {
+		if err := f(item); err != nil {
+			return &IndexedError{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// TryForEachCollect calls the fallible `f` for every element of `slice`, collecting all errors.
+//
+// Parameters:
+//   - `slice`: The slice to iterate over.
+//   - `f`: The action executed for each element.
+//
+// Returns:
+//   - The joined `*IndexedError` failures, or nil if every call succeeded.
+//
+// Example:
+//
+//	err := TryForEachCollect(files, os.Remove)
+func TryForEachCollect[T any](slice []T, f func(T) error) error {
+	var errs []error
+	for i, item := range slice {
+		if err := f(item); err != nil {
+			errs = append(errs, &IndexedError{Index: i, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
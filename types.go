@@ -1,5 +1,7 @@
 package unify4g
 
+import "reflect"
+
 // OptionsConfig defines the configuration options for pretty-printing JSON data.
 // It allows customization of width, prefix, indentation, and sorting of keys.
 // These options control how the JSON output will be formatted.
@@ -64,5 +66,282 @@ type byKeyVal struct {
 // It is used when no custom options are provided in the PrettyOptions function.
 var DefaultOptionsConfig = &OptionsConfig{Width: 80, Prefix: "", Indent: "  ", SortKeys: false}
 
+// DefaultMergeOptionsConfig is the default configuration used by `DeepMergeMapWith` when no options are provided.
+// Arrays are replaced and type conflicts are resolved in favour of the source value, as in `DeepMergeMap`.
+var DefaultMergeOptionsConfig = &MergeOptionsConfig{ArrayStrategy: MergeArrayReplace, StrictTypes: false}
+
+// DefaultStructMapOptionsConfig is the default configuration used by `StructToMap` when no options are provided.
+// Only fields tagged with omitempty are omitted and nested structs become nested maps.
+var DefaultStructMapOptionsConfig = &StructMapOptionsConfig{OmitEmpty: false, Flatten: false, Separator: "."}
+
+// DefaultFuzzyOptionsConfig is the default configuration used by `FuzzyFind`.
+// Matching ignores case but not accents.
+var DefaultFuzzyOptionsConfig = &FuzzyOptionsConfig{CaseSensitive: false, IgnoreAccents: false}
+
+// DefaultWrapOptionsConfig is the default configuration used by `WordWrap`.
+// Words longer than a line are broken without hyphen.
+var DefaultWrapOptionsConfig = &WrapOptionsConfig{SoftBreak: false, Hyphenate: false}
+
+// DefaultTemplateOptionsConfig is the default configuration used by `Interpolate`.
+// Missing values are replaced with their default or an empty string, and values are not escaped.
+var DefaultTemplateOptionsConfig = &TemplateOptionsConfig{Strict: false, Escape: TemplateEscapeNone}
+
+// DefaultDiffOptionsConfig is the default configuration used by `UnifiedDiff`.
+// Hunks have three lines of context, files are labelled "a" and "b", and the output is not colored.
+var DefaultDiffOptionsConfig = &DiffOptionsConfig{Context: 3, FromFile: "a", ToFile: "b", Color: false}
+
 // TerminalStyle is for terminals
 var TerminalStyle *Style
+
+// Pair holds two values of possibly different types, typically produced by positional
+// combination of two slices (see `Zip2`).
+//
+// Fields:
+//   - First: The value taken from the first source.
+//   - Second: The value taken from the second source.
+type Pair[A, B any] struct {
+	First  A `json:"first"`
+	Second B `json:"second"`
+}
+
+// Number is a constraint that permits any integer or floating-point type, including
+// named types whose underlying type is numeric. It is used by the statistics helpers.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// PercentileMethod selects how `Percentile` estimates a value that falls between two
+// data points of the sorted input.
+type PercentileMethod int
+
+// HistogramBucket describes one bucket of a histogram produced by `Histogram` or `HistogramN`.
+//
+// Fields:
+//   - Lower: The inclusive lower bound of the bucket.
+//   - Upper: The exclusive upper bound of the bucket (inclusive for the last bucket).
+//   - Count: The number of values that fell into the bucket.
+type HistogramBucket struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count int     `json:"count"`
+}
+
+// SortKey compares two values for one level of a multi-key ordering, returning a negative number,
+// zero or a positive number when `a` sorts before, together with, or after `b`.
+// Values are built with `Asc`, `Desc`, `AscBy` and `DescBy` and consumed by `SortBy`, `IsSorted` and `TopK`.
+type SortKey[T any] func(a, b T) int
+
+// MapEntry holds one key-value pair of a map, typically produced by `SortMapByValue`.
+//
+// Fields:
+//   - Key: The map key.
+//   - Value: The value associated with the key.
+type MapEntry[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// MergeArrayStrategy determines how `DeepMergeMapWith` combines two arrays found under the same key.
+type MergeArrayStrategy int
+
+// MergeOptionsConfig defines the configuration options for `DeepMergeMapWith`.
+//
+// Fields:
+//   - ArrayStrategy: How arrays ([]interface{}) present in both maps are combined. Default is `MergeArrayReplace`.
+//   - StrictTypes: When true, a key holding values of different types in both maps (e.g. a map and a string)
+//     makes the merge fail instead of letting the source value win. Default is false.
+type MergeOptionsConfig struct {
+	// ArrayStrategy is the strategy applied to arrays present in both maps
+	// Default is MergeArrayReplace
+	ArrayStrategy MergeArrayStrategy `json:"array_strategy"`
+	// StrictTypes reports an error on type conflicts instead of overwriting
+	// Default is false
+	StrictTypes bool `json:"strict_types"`
+}
+
+// TableFormat selects the output produced by `Table.Render`.
+type TableFormat int
+
+// TableAlign is the horizontal alignment of the cells of a table column.
+type TableAlign int
+
+// TableOverflow determines what `Table` does with cells wider than the maximum column width.
+type TableOverflow int
+
+// ValidationField describes the value being checked by a validation rule.
+//
+// Fields:
+//   - Path: The location of the value from the validated root, such as "Address.Street" or "Items[2].Name".
+//   - Value: The value being checked.
+//   - Param: The rule parameter written after '=' in the tag, e.g. "3" for `min=3`; empty if none.
+//   - Parent: The struct that declares the field, used by cross-field rules such as `eqfield`.
+type ValidationField struct {
+	Path   string
+	Value  reflect.Value
+	Param  string
+	Parent reflect.Value
+}
+
+// ValidationRuleFunc reports whether a field satisfies a validation rule.
+type ValidationRuleFunc func(field ValidationField) bool
+
+// FieldError describes a field that failed a validation rule.
+//
+// Fields:
+//   - Field: The path of the failing field, such as "Address.Street" or "Items[2].Name".
+//   - Rule: The name of the rule that failed, e.g. "required" or "min".
+//   - Param: The rule parameter, e.g. "3" for `min=3`.
+//   - Value: The value that was checked.
+//   - Message: A human-readable description produced from the validator's message templates.
+type FieldError struct {
+	Field   string      `json:"field"`
+	Rule    string      `json:"rule"`
+	Param   string      `json:"param,omitempty"`
+	Value   interface{} `json:"-"`
+	Message string      `json:"message"`
+}
+
+// ValidationErrors is the list of field errors returned by `Validator.Validate`.
+type ValidationErrors []*FieldError
+
+// CopyOptionsConfig defines the configuration options for `DeepCopyWith`.
+//
+// Fields:
+//   - ZeroUnexported: When true, unexported struct fields are left as zero values in the copy.
+//     When false (default), they are copied shallowly: the copy shares whatever they point to,
+//     because reflection cannot write unexported fields one by one.
+type CopyOptionsConfig struct {
+	// ZeroUnexported resets unexported fields instead of copying them shallowly
+	// Default is false
+	ZeroUnexported bool `json:"zero_unexported"`
+}
+
+// StructMapOptionsConfig defines the configuration options for `StructToMap`.
+//
+// Fields:
+//   - OmitEmpty: When true, every field holding a zero value is omitted, as if all fields were tagged
+//     with `json:",omitempty"`. Fields tagged with omitempty are always omitted when empty. Default is false.
+//   - Flatten: When true, nested structs and maps are flattened into a single level with `FlattenMap`. Default is false.
+//   - Separator: The separator used between path segments when flattening. Default is ".".
+type StructMapOptionsConfig struct {
+	// OmitEmpty omits every zero-valued field
+	// Default is false
+	OmitEmpty bool `json:"omit_empty"`
+	// Flatten produces a single-level map with joined keys
+	// Default is false
+	Flatten bool `json:"flatten"`
+	// Separator joins the keys of nested values when flattening
+	// Default is "."
+	Separator string `json:"separator"`
+}
+
+// EnvBindingReport describes the outcome of `BindEnv`.
+//
+// Fields:
+//   - Used: The environment variables that were found and assigned, in field order.
+//   - Missing: The environment variables declared by `env` tags that were not set, in field order,
+//     including the required ones reported by the returned error.
+type EnvBindingReport struct {
+	Used    []string `json:"used"`
+	Missing []string `json:"missing"`
+}
+
+// FuzzyOptionsConfig defines the configuration options for `FuzzyFindWith`.
+//
+// Fields:
+//   - CaseSensitive: When true, the query must match the case of the candidates. Default is false.
+//   - IgnoreAccents: When true, accents are removed with `RemoveAccents` before matching, so that
+//     "cafe" matches "Café". Default is false.
+type FuzzyOptionsConfig struct {
+	// CaseSensitive matches letters with their exact case
+	// Default is false
+	CaseSensitive bool `json:"case_sensitive"`
+	// IgnoreAccents matches accented letters with their unaccented form
+	// Default is false
+	IgnoreAccents bool `json:"ignore_accents"`
+}
+
+// FuzzyMatch is a candidate matched by `FuzzyFind`.
+//
+// Fields:
+//   - Value: The matched candidate.
+//   - Index: The position of the candidate in the input slice.
+//   - Score: The match score; higher is better.
+//   - Positions: The rune positions of the candidate matched by the query characters, for highlighting.
+type FuzzyMatch struct {
+	Value     string `json:"value"`
+	Index     int    `json:"index"`
+	Score     int    `json:"score"`
+	Positions []int  `json:"positions"`
+}
+
+// WrapOptionsConfig defines the configuration options for `WordWrapWith`.
+//
+// Fields:
+//   - SoftBreak: When true, words longer than a line are kept whole on a line of their own, which then
+//     exceeds the width; useful for URLs and paths. When false, they are broken across lines. Default is false.
+//   - Hyphenate: When true, a word broken across lines ends with a hyphen, counted in the width. Default is false.
+type WrapOptionsConfig struct {
+	// SoftBreak keeps long words whole instead of breaking them
+	// Default is false
+	SoftBreak bool `json:"soft_break"`
+	// Hyphenate marks broken words with a hyphen
+	// Default is false
+	Hyphenate bool `json:"hyphenate"`
+}
+
+// TemplateEscape selects how a `Template` escapes the values it inserts.
+type TemplateEscape int
+
+// TemplateOptionsConfig defines the configuration options for `NewTemplate` and `InterpolateWith`.
+//
+// Fields:
+//   - Strict: When true, a placeholder whose value is missing and that has no default makes the
+//     execution fail. When false, it is replaced with an empty string. Default is false.
+//   - Escape: The escaping applied to every inserted value, unless the last filter of the placeholder
+//     is `raw` or applies the same escaping. Default is `TemplateEscapeNone`.
+type TemplateOptionsConfig struct {
+	// Strict reports missing values as errors
+	// Default is false
+	Strict bool `json:"strict"`
+	// Escape is the escaping applied to inserted values
+	// Default is TemplateEscapeNone
+	Escape TemplateEscape `json:"escape"`
+}
+
+// DiffOperation is the kind of a `DiffEdit`.
+type DiffOperation int
+
+// DiffEdit is one step of an edit script produced by `DiffLines`, `DiffWords` or `DiffChars`.
+//
+// Fields:
+//   - Operation: Whether the text is kept, deleted from the first input or inserted from the second.
+//   - Text: The text concerned, made of one or more consecutive lines, words or characters.
+type DiffEdit struct {
+	Operation DiffOperation `json:"operation"`
+	Text      string        `json:"text"`
+}
+
+// DiffOptionsConfig defines the configuration options for `UnifiedDiff`.
+//
+// Fields:
+//   - Context: The number of unchanged lines shown around each change. Default is 3.
+//   - FromFile: The label of the original text in the "---" header. Default is "a".
+//   - ToFile: The label of the modified text in the "+++" header. Default is "b".
+//   - Color: When true, the output is colored with ANSI escape sequences for terminals. Default is false.
+type DiffOptionsConfig struct {
+	// Context is the number of unchanged lines around changes
+	// Default is 3
+	Context int `json:"context"`
+	// FromFile is the label of the original text
+	// Default is "a"
+	FromFile string `json:"from_file"`
+	// ToFile is the label of the modified text
+	// Default is "b"
+	ToFile string `json:"to_file"`
+	// Color enables ANSI colors
+	// Default is false
+	Color bool `json:"color"`
+}
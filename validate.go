@@ -0,0 +1,653 @@
+package unify4g
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validator checks structs against the rules declared in their `validate` struct tags.
+//
+// A tag lists comma-separated rules, some taking a parameter after '=':
+//
+//	type SignUp struct {
+//		Name     string   `validate:"required,min=3,max=64,alphanum"`
+//		Email    string   `validate:"required,email"`
+//		Role     string   `validate:"oneof=admin editor viewer"`
+//		Password string   `validate:"required,min=8"`
+//		Confirm  string   `validate:"eqfield=Password"`
+//		Tags     []string `validate:"max=5,dive,lowercase"`
+//		Address  *Address
+//	}
+//
+// Nested structs, and structs held in slices, arrays and maps, are validated recursively. The special
+// rule `omitempty` skips the remaining rules when the value is empty, and `dive` applies the rules that
+// follow it to every element of a slice, array or map. Validation of a field stops at its first failing rule.
+//
+// Built-in rules:
+//   - Presence: required, required_with=Field, required_without=Field, required_if=Field value.
+//   - Size (length of strings, slices and maps; value of numbers): min, max, len, eq, ne, gt, gte, lt, lte.
+//   - Content: oneof, alpha, alphanum, numeric, lowercase, uppercase, email, uuid, url,
+//     contains, startswith, endswith.
+//   - Cross-field: eqfield, nefield, gtfield, gtefield, ltfield, ltefield (numbers, strings and time.Time).
+//
+// Rules and messages can be customized with `RegisterRule` and `SetMessage`. A Validator must not be
+// modified while it is validating.
+type Validator struct {
+	rules    map[string]ValidationRuleFunc
+	messages map[string]string
+}
+
+// validationRule is one rule parsed from a `validate` tag.
+type validationRule struct {
+	name  string
+	param string
+}
+
+// defaultValidationMessages holds the English message templates of the built-in rules.
+// Templates may use the {field}, {param}, {rule} and {value} placeholders.
+var defaultValidationMessages = map[string]string{
+	"":                 "{field} failed the '{rule}' validation",
+	"required":         "{field} is required",
+	"required_with":    "{field} is required when {param} is present",
+	"required_without": "{field} is required when {param} is absent",
+	"required_if":      "{field} is required when {param}",
+	"min":              "{field} must be at least {param}",
+	"max":              "{field} must be at most {param}",
+	"len":              "{field} must have a length of {param}",
+	"eq":               "{field} must be equal to {param}",
+	"ne":               "{field} must not be equal to {param}",
+	"gt":               "{field} must be greater than {param}",
+	"gte":              "{field} must be greater than or equal to {param}",
+	"lt":               "{field} must be less than {param}",
+	"lte":              "{field} must be less than or equal to {param}",
+	"oneof":            "{field} must be one of [{param}]",
+	"alpha":            "{field} must contain only letters",
+	"alphanum":         "{field} must contain only letters and digits",
+	"numeric":          "{field} must contain only digits",
+	"lowercase":        "{field} must be lowercase",
+	"uppercase":        "{field} must be uppercase",
+	"email":            "{field} must be a valid email address",
+	"uuid":             "{field} must be a valid UUID",
+	"url":              "{field} must be a valid URL",
+	"contains":         "{field} must contain '{param}'",
+	"startswith":       "{field} must start with '{param}'",
+	"endswith":         "{field} must end with '{param}'",
+	"eqfield":          "{field} must be equal to {param}",
+	"nefield":          "{field} must not be equal to {param}",
+	"gtfield":          "{field} must be greater than {param}",
+	"gtefield":         "{field} must be greater than or equal to {param}",
+	"ltfield":          "{field} must be less than {param}",
+	"ltefield":         "{field} must be less than or equal to {param}",
+}
+
+// validationSiblingRules lists the built-in rules whose parameter starts with the name of a sibling
+// field, which must be an exported field of the parent struct.
+var validationSiblingRules = map[string]bool{
+	"required_with": true, "required_without": true, "required_if": true,
+	"eqfield": true, "nefield": true, "gtfield": true, "gtefield": true, "ltfield": true, "ltefield": true,
+}
+
+// defaultValidator is the Validator used by the package-level `Validate` function.
+var defaultValidator = NewValidator()
+
+// NewValidator creates a Validator with the built-in rules and English messages.
+//
+// Returns:
+//   - A pointer to a new Validator.
+//
+// Example:
+//
+//	validator := NewValidator().
+//		RegisterRule("even", func(f ValidationField) bool { return f.Value.Int()%2 == 0 }).
+//		SetMessage("even", "{field} must be an even number")
+//	err := validator.Validate(request)
+func NewValidator() *Validator {
+	validator := &Validator{rules: builtinValidationRules(), messages: make(map[string]string, len(defaultValidationMessages))}
+	return validator.SetMessages(defaultValidationMessages)
+}
+
+// Validate checks `value`, a struct or a pointer to a struct, with the default Validator.
+//
+// Parameters:
+//   - `value`: The struct to validate.
+//
+// Returns:
+//   - nil if every rule passes.
+//   - A `ValidationErrors` value listing every failing field.
+//   - Another error if `value` is not a struct, or a tag references an unknown rule or an unknown
+//     or unexported sibling field.
+//
+// Example:
+//
+//	if err := Validate(request); err != nil {
+//		var fieldErrors ValidationErrors
+//		if errors.As(err, &fieldErrors) {
+//			for _, fe := range fieldErrors {
+//				fmt.Println(fe.Field, fe.Message)
+//			}
+//		}
+//	}
+func Validate(value interface{}) error {
+	return defaultValidator.Validate(value)
+}
+
+// RegisterValidationRule adds a custom rule to the default Validator used by `Validate`.
+// It must be called before validation starts, typically from an init function.
+//
+// Parameters:
+//   - `name`: The rule name used in `validate` tags.
+//   - `rule`: The function reporting whether a field satisfies the rule.
+//
+// Example:
+//
+//	RegisterValidationRule("iso_country", func(f ValidationField) bool {
+//		return ContainsN(countryCodes, f.Value.String())
+//	})
+func RegisterValidationRule(name string, rule ValidationRuleFunc) {
+	defaultValidator.RegisterRule(name, rule)
+}
+
+// RegisterRule adds or replaces a rule.
+//
+// Parameters:
+//   - `name`: The rule name used in `validate` tags.
+//   - `rule`: The function reporting whether a field satisfies the rule.
+//
+// Returns:
+//   - The validator, for chaining.
+func (validator *Validator) RegisterRule(name string, rule ValidationRuleFunc) *Validator {
+	validator.rules[name] = rule
+	return validator
+}
+
+// SetMessage sets the message template of a rule. Templates may use the {field}, {param}, {rule}
+// and {value} placeholders. The template registered for the empty rule name is used for rules
+// without a template of their own.
+//
+// Parameters:
+//   - `rule`: The rule name.
+//   - `template`: The message template.
+//
+// Returns:
+//   - The validator, for chaining.
+//
+// Example:
+//
+//	french := NewValidator().
+//		SetMessage("required", "{field} est obligatoire").
+//		SetMessage("min", "{field} doit être au moins {param}")
+func (validator *Validator) SetMessage(rule, template string) *Validator {
+	validator.messages[rule] = template
+	return validator
+}
+
+// SetMessages sets several message templates at once, e.g. to install a translation.
+//
+// Parameters:
+//   - `templates`: The message templates, keyed by rule name.
+//
+// Returns:
+//   - The validator, for chaining.
+func (validator *Validator) SetMessages(templates map[string]string) *Validator {
+	for rule, template := range templates {
+		validator.messages[rule] = template
+	}
+	return validator
+}
+
+// Validate checks `value`, a struct or a pointer to a struct, against its `validate` tags.
+//
+// Parameters:
+//   - `value`: The struct to validate.
+//
+// Returns:
+//   - nil if every rule passes.
+//   - A `ValidationErrors` value listing every failing field.
+//   - Another error if `value` is not a struct, or a tag references an unknown rule or an unknown
+//     or unexported sibling field.
+func (validator *Validator) Validate(value interface{}) error {
+	root := reflect.ValueOf(value)
+	for root.Kind() == reflect.Ptr && !root.IsNil() {
+		root = root.Elem()
+	}
+	if root.Kind() != reflect.Struct {
+		return fmt.Errorf("validate: expected a struct or a pointer to a struct, got %T", value)
+	}
+	run := &validationRun{visited: make(map[copyKey]bool)}
+	if value := reflect.ValueOf(value); value.Kind() == reflect.Ptr {
+		run.visited[copyKey{pointer: value.Pointer(), typ: value.Type()}] = true
+	}
+	if err := validator.validateStruct(root, "", run); err != nil {
+		return err
+	}
+	if len(run.errs) == 0 {
+		return nil
+	}
+	return run.errs
+}
+
+// Error returns the message of the field error.
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// Error joins the messages of all field errors with "; ".
+func (errs ValidationErrors) Error() string {
+	return strings.Join(Map(errs, func(e *FieldError) string { return e.Message }), "; ")
+}
+
+// Fields returns the errors keyed by field path, keeping the first error of each field.
+//
+// Returns:
+//   - A map from field path to its error.
+func (errs ValidationErrors) Fields() map[string]*FieldError {
+	result := make(map[string]*FieldError, len(errs))
+	for _, e := range errs {
+		if _, exists := result[e.Field]; !exists {
+			result[e.Field] = e
+		}
+	}
+	return result
+}
+
+// validationRun carries the state of a single `Validate` call: the errors found so far, and the
+// pointers being validated, so that self-referencing structures are validated only once.
+type validationRun struct {
+	errs    ValidationErrors
+	visited map[copyKey]bool
+}
+
+// validateStruct checks the fields of the struct `value`, whose path is `prefix`.
+func (validator *Validator) validateStruct(value reflect.Value, prefix string, run *validationRun) error {
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := field.Tag.Get("validate")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		path := field.Name
+		if prefix != "" {
+			path = prefix + "." + field.Name
+		}
+		rules, err := parseValidationTag(tag)
+		if err != nil {
+			return fmt.Errorf("validate: field '%s': %v", path, err)
+		}
+		if err := validator.validateValue(value.Field(i), value, path, rules, run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateValue applies `rules` to `value`, then descends into it when it holds structs.
+func (validator *Validator) validateValue(value, parent reflect.Value, path string, rules []validationRule, run *validationRun) error {
+	for i, rule := range rules {
+		switch rule.name {
+		case "omitempty":
+			if isEmptyValue(value) {
+				return nil
+			}
+		case "dive":
+			return validator.dive(value, parent, path, rules[i+1:], run)
+		default:
+			check, exists := validator.rules[rule.name]
+			if !exists {
+				return fmt.Errorf("validate: field '%s': unknown rule '%s'", path, rule.name)
+			}
+			if validationSiblingRules[rule.name] {
+				name, _, _ := strings.Cut(rule.param, " ")
+				if _, err := validationSiblingField(parent, name); err != nil {
+					return fmt.Errorf("validate: field '%s': rule '%s': %v", path, rule.name, err)
+				}
+			}
+			if !check(ValidationField{Path: path, Value: value, Param: rule.param, Parent: parent}) {
+				run.errs = append(run.errs, validator.fieldError(path, rule, value))
+				return nil
+			}
+		}
+	}
+	return validator.descend(value, path, run)
+}
+
+// dive applies `rules` to every element of the slice, array or map `value`. A nil pointer to a
+// collection has no elements.
+func (validator *Validator) dive(value, parent reflect.Value, path string, rules []validationRule, run *validationRun) error {
+	if value.Kind() == reflect.Ptr && value.IsNil() && isCollectionKind(value.Type().Elem().Kind()) {
+		return nil
+	}
+	value = reflect.Indirect(value)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := validator.validateValue(value.Index(i), parent, fmt.Sprintf("%s[%d]", path, i), rules, run); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, key := range sortedMapKeys(value) {
+			if err := validator.validateValue(value.MapIndex(key), parent, fmt.Sprintf("%s[%v]", path, key.Interface()), rules, run); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("validate: field '%s': 'dive' requires a slice, array or map", path)
+	}
+	return nil
+}
+
+// descend validates the structs found in `value`, directly or as elements of a collection.
+// Pointers already being validated are skipped, to stop at cycles.
+func (validator *Validator) descend(value reflect.Value, path string, run *validationRun) error {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		if value.Kind() == reflect.Ptr {
+			key := copyKey{pointer: value.Pointer(), typ: value.Type()}
+			if run.visited[key] {
+				return nil
+			}
+			run.visited[key] = true
+			defer delete(run.visited, key)
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		if value.Type() == reflect.TypeOf(time.Time{}) {
+			return nil
+		}
+		return validator.validateStruct(value, path, run)
+	case reflect.Slice, reflect.Array:
+		if !holdsStructs(value.Type().Elem()) {
+			return nil
+		}
+		for i := 0; i < value.Len(); i++ {
+			if err := validator.descend(value.Index(i), fmt.Sprintf("%s[%d]", path, i), run); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if !holdsStructs(value.Type().Elem()) {
+			return nil
+		}
+		for _, key := range sortedMapKeys(value) {
+			if err := validator.descend(value.MapIndex(key), fmt.Sprintf("%s[%v]", path, key.Interface()), run); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldError builds the error reported when `value` at `path` fails `rule`.
+func (validator *Validator) fieldError(path string, rule validationRule, value reflect.Value) *FieldError {
+	var raw interface{}
+	if value.IsValid() && value.CanInterface() {
+		raw = value.Interface()
+	}
+	template, exists := validator.messages[rule.name]
+	if !exists {
+		template = validator.messages[""]
+	}
+	message := strings.NewReplacer(
+		"{field}", path,
+		"{param}", rule.param,
+		"{rule}", rule.name,
+		"{value}", fmt.Sprint(raw),
+	).Replace(template)
+	return &FieldError{Field: path, Rule: rule.name, Param: rule.param, Value: raw, Message: message}
+}
+
+// builtinValidationRules returns the rules documented on `Validator`.
+func builtinValidationRules() map[string]ValidationRuleFunc {
+	size := func(accept func(size, param float64) bool) ValidationRuleFunc {
+		return func(field ValidationField) bool {
+			param, err := strconv.ParseFloat(field.Param, 64)
+			n, ok := validationSize(field.Value)
+			return err == nil && ok && accept(n, param)
+		}
+	}
+	text := func(accept func(s, param string) bool) ValidationRuleFunc {
+		return func(field ValidationField) bool {
+			value := reflect.Indirect(field.Value)
+			return value.Kind() == reflect.String && accept(value.String(), field.Param)
+		}
+	}
+	crossField := func(accept func(c int) bool) ValidationRuleFunc {
+		return func(field ValidationField) bool {
+			other, ok := validationSibling(field)
+			if !ok {
+				return false
+			}
+			c, ok := compareValidationValues(field.Value, other)
+			return ok && accept(c)
+		}
+	}
+	return map[string]ValidationRuleFunc{
+		"required": func(field ValidationField) bool { return !isEmptyValue(field.Value) },
+		"required_with": func(field ValidationField) bool {
+			other, ok := validationSibling(field)
+			return ok && (isEmptyValue(other) || !isEmptyValue(field.Value))
+		},
+		"required_without": func(field ValidationField) bool {
+			other, ok := validationSibling(field)
+			return ok && (!isEmptyValue(other) || !isEmptyValue(field.Value))
+		},
+		"required_if": func(field ValidationField) bool {
+			name, expected, _ := strings.Cut(field.Param, " ")
+			other, ok := validationSibling(ValidationField{Param: name, Parent: field.Parent})
+			if !ok {
+				return false
+			}
+			other = reflect.Indirect(other)
+			if !other.IsValid() || !other.CanInterface() {
+				return true
+			}
+			return fmt.Sprint(other.Interface()) != expected || !isEmptyValue(field.Value)
+		},
+		"min": size(func(n, p float64) bool { return n >= p }),
+		"max": size(func(n, p float64) bool { return n <= p }),
+		"len": size(func(n, p float64) bool { return n == p }),
+		"eq":  size(func(n, p float64) bool { return n == p }),
+		"ne":  size(func(n, p float64) bool { return n != p }),
+		"gt":  size(func(n, p float64) bool { return n > p }),
+		"gte": size(func(n, p float64) bool { return n >= p }),
+		"lt":  size(func(n, p float64) bool { return n < p }),
+		"lte": size(func(n, p float64) bool { return n <= p }),
+		"oneof": func(field ValidationField) bool {
+			value := reflect.Indirect(field.Value)
+			if !value.IsValid() {
+				return false
+			}
+			return ContainsN(strings.Fields(field.Param), fmt.Sprint(value.Interface()))
+		},
+		"alpha":      text(func(s, _ string) bool { return IsAlpha(s) }),
+		"alphanum":   text(func(s, _ string) bool { return IsAlphanumeric(s) }),
+		"numeric":    text(func(s, _ string) bool { return IsNotEmpty(s) && IsNumeric(s) }),
+		"lowercase":  text(func(s, _ string) bool { return s == strings.ToLower(s) }),
+		"uppercase":  text(func(s, _ string) bool { return s == strings.ToUpper(s) }),
+		"email":      text(func(s, _ string) bool { return RegexpEmail.MatchString(s) }),
+		"uuid":       text(func(s, _ string) bool { return RegexpUUID.MatchString(s) }),
+		"contains":   text(strings.Contains),
+		"startswith": text(strings.HasPrefix),
+		"endswith":   text(strings.HasSuffix),
+		"url": text(func(s, _ string) bool {
+			u, err := url.ParseRequestURI(s)
+			return err == nil && u.Scheme != "" && u.Host != ""
+		}),
+		"eqfield": func(field ValidationField) bool {
+			other, ok := validationSibling(field)
+			if !ok {
+				return false
+			}
+			c, comparable := compareValidationValues(field.Value, other)
+			if comparable {
+				return c == 0
+			}
+			return field.Value.CanInterface() && other.CanInterface() && reflect.DeepEqual(field.Value.Interface(), other.Interface())
+		},
+		"nefield": func(field ValidationField) bool {
+			other, ok := validationSibling(field)
+			if !ok {
+				return false
+			}
+			c, comparable := compareValidationValues(field.Value, other)
+			if comparable {
+				return c != 0
+			}
+			return field.Value.CanInterface() && other.CanInterface() && !reflect.DeepEqual(field.Value.Interface(), other.Interface())
+		},
+		"gtfield":  crossField(func(c int) bool { return c > 0 }),
+		"gtefield": crossField(func(c int) bool { return c >= 0 }),
+		"ltfield":  crossField(func(c int) bool { return c < 0 }),
+		"ltefield": crossField(func(c int) bool { return c <= 0 }),
+	}
+}
+
+// parseValidationTag splits a `validate` tag into its rules.
+func parseValidationTag(tag string) ([]validationRule, error) {
+	var rules []validationRule
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(part, "=")
+		if name == "" {
+			return nil, fmt.Errorf("invalid rule '%s'", part)
+		}
+		rules = append(rules, validationRule{name: name, param: param})
+	}
+	return rules, nil
+}
+
+// isEmptyValue reports whether `value` is absent: invalid, nil, the zero value, an empty
+// collection or a blank string.
+func isEmptyValue(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return IsBlank(value.String())
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	default:
+		return value.IsZero()
+	}
+}
+
+// validationSize returns the size used by the size rules: the length of strings (in runes),
+// slices, arrays and maps, or the value of numbers.
+func validationSize(value reflect.Value) (float64, bool) {
+	value = reflect.Indirect(value)
+	switch value.Kind() {
+	case reflect.String:
+		return float64(Len(value.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// validationSibling returns the field of `field.Parent` named by `field.Param`.
+func validationSibling(field ValidationField) (reflect.Value, bool) {
+	other, err := validationSiblingField(field.Parent, field.Param)
+	return other, err == nil
+}
+
+// validationSiblingField returns the exported field `name` of the struct `parent`, or an error if
+// there is no such field or it is unexported. A field promoted through a nil embedded pointer is
+// returned as its zero value.
+func validationSiblingField(parent reflect.Value, name string) (reflect.Value, error) {
+	if parent.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("no struct holding field '%s'", name)
+	}
+	field, ok := parent.Type().FieldByName(name)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("unknown field '%s'", name)
+	}
+	if !field.IsExported() {
+		return reflect.Value{}, fmt.Errorf("unexported field '%s'", name)
+	}
+	other, err := parent.FieldByIndexErr(field.Index)
+	if err != nil {
+		// the field is promoted through a nil embedded pointer, so it holds nothing
+		return reflect.Zero(field.Type), nil
+	}
+	return other, nil
+}
+
+// compareValidationValues compares two numbers, strings or time.Time values.
+func compareValidationValues(a, b reflect.Value) (int, bool) {
+	a, b = reflect.Indirect(a), reflect.Indirect(b)
+	if !a.IsValid() || !b.IsValid() || !a.CanInterface() || !b.CanInterface() {
+		return 0, false
+	}
+	if ta, ok := a.Interface().(time.Time); ok {
+		if tb, ok := b.Interface().(time.Time); ok {
+			return ta.Compare(tb), true
+		}
+		return 0, false
+	}
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), true
+	}
+	if !isNumberKind(a.Kind()) || !isNumberKind(b.Kind()) {
+		return 0, false
+	}
+	x, _ := validationSize(a)
+	y, _ := validationSize(b)
+	switch {
+	case x < y:
+		return -1, true
+	case x > y:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+// isCollectionKind reports whether `kind` is a slice, array or map kind.
+func isCollectionKind(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map
+}
+
+// isNumberKind reports whether `kind` is an integer or floating-point kind.
+func isNumberKind(kind reflect.Kind) bool {
+	return (kind >= reflect.Int && kind <= reflect.Uintptr) || kind == reflect.Float32 || kind == reflect.Float64
+}
+
+// holdsStructs reports whether values of type `t` are, or point to, structs.
+func holdsStructs(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct || t.Kind() == reflect.Interface
+}
+
+// sortedMapKeys returns the keys of the map `value` in a deterministic order.
+func sortedMapKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return NaturalCompare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+	})
+	return keys
+}
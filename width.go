@@ -0,0 +1,469 @@
+package unify4g
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRunes lists the code points rendered two columns wide by terminals: the East Asian Wide (W)
+// and Fullwidth (F) ranges of Unicode Standard Annex #11, including CJK ideographs, Hangul syllables,
+// fullwidth forms and emoji presentation characters.
+var wideRunes = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115F, Stride: 1},
+		{Lo: 0x231A, Hi: 0x231B, Stride: 1},
+		{Lo: 0x2329, Hi: 0x232A, Stride: 1},
+		{Lo: 0x23E9, Hi: 0x23EC, Stride: 1},
+		{Lo: 0x23F0, Hi: 0x23F3, Stride: 3},
+		{Lo: 0x25FD, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267F, Hi: 0x267F, Stride: 1},
+		{Lo: 0x2693, Hi: 0x2693, Stride: 1},
+		{Lo: 0x26A1, Hi: 0x26A1, Stride: 1},
+		{Lo: 0x26AA, Hi: 0x26AB, Stride: 1},
+		{Lo: 0x26BD, Hi: 0x26BE, Stride: 1},
+		{Lo: 0x26C4, Hi: 0x26C5, Stride: 1},
+		{Lo: 0x26CE, Hi: 0x26CE, Stride: 1},
+		{Lo: 0x26D4, Hi: 0x26D4, Stride: 1},
+		{Lo: 0x26EA, Hi: 0x26EA, Stride: 1},
+		{Lo: 0x26F2, Hi: 0x26F3, Stride: 1},
+		{Lo: 0x26F5, Hi: 0x26F5, Stride: 1},
+		{Lo: 0x26FA, Hi: 0x26FD, Stride: 3},
+		{Lo: 0x2705, Hi: 0x2705, Stride: 1},
+		{Lo: 0x270A, Hi: 0x270B, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x274C, Hi: 0x274E, Stride: 2},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27B0, Hi: 0x27BF, Stride: 15},
+		{Lo: 0x2B1B, Hi: 0x2B1C, Stride: 1},
+		{Lo: 0x2B50, Hi: 0x2B55, Stride: 5},
+		{Lo: 0x2E80, Hi: 0x303E, Stride: 1},
+		{Lo: 0x3041, Hi: 0x33FF, Stride: 1},
+		{Lo: 0x3400, Hi: 0x4DBF, Stride: 1},
+		{Lo: 0x4E00, Hi: 0x9FFF, Stride: 1},
+		{Lo: 0xA000, Hi: 0xA4CF, Stride: 1},
+		{Lo: 0xA960, Hi: 0xA97F, Stride: 1},
+		{Lo: 0xAC00, Hi: 0xD7A3, Stride: 1},
+		{Lo: 0xF900, Hi: 0xFAFF, Stride: 1},
+		{Lo: 0xFE10, Hi: 0xFE19, Stride: 1},
+		{Lo: 0xFE30, Hi: 0xFE6F, Stride: 1},
+		{Lo: 0xFF00, Hi: 0xFF60, Stride: 1},
+		{Lo: 0xFFE0, Hi: 0xFFE6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16FE0, Hi: 0x16FE4, Stride: 1},
+		{Lo: 0x17000, Hi: 0x18CFF, Stride: 1},
+		{Lo: 0x1B000, Hi: 0x1B2FF, Stride: 1},
+		{Lo: 0x1F004, Hi: 0x1F004, Stride: 1},
+		{Lo: 0x1F0CF, Hi: 0x1F0CF, Stride: 1},
+		{Lo: 0x1F18E, Hi: 0x1F18E, Stride: 1},
+		{Lo: 0x1F191, Hi: 0x1F19A, Stride: 1},
+		{Lo: 0x1F200, Hi: 0x1F251, Stride: 1},
+		{Lo: 0x1F300, Hi: 0x1F64F, Stride: 1},
+		{Lo: 0x1F680, Hi: 0x1F6FF, Stride: 1},
+		{Lo: 0x1F900, Hi: 0x1F9FF, Stride: 1},
+		{Lo: 0x1FA70, Hi: 0x1FAFF, Stride: 1},
+		{Lo: 0x20000, Hi: 0x2FFFD, Stride: 1},
+		{Lo: 0x30000, Hi: 0x3FFFD, Stride: 1},
+	},
+}
+
+// RuneWidth returns the number of terminal columns occupied by the rune `r`.
+//
+// Control characters, combining marks and format characters (such as the zero width joiner)
+// occupy no column; East Asian wide and fullwidth characters, including most emoji, occupy two;
+// every other rune occupies one.
+//
+// Parameters:
+//   - `r`: The rune to measure.
+//
+// Returns:
+//   - 0, 1 or 2.
+//
+// Example:
+//
+//	RuneWidth('a')  // 1
+//	RuneWidth('世') // 2
+//	RuneWidth('\u0301') // 0 (combining acute accent)
+func RuneWidth(r rune) int {
+	switch {
+	case r == 0 || r < 32 || (r >= 0x7F && r < 0xA0):
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case unicode.Is(wideRunes, r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// DisplayWidth returns the number of terminal columns needed to display `str`, as measured by
+// `RuneWidth`. ANSI escape sequences (such as the colors of `TerminalStyle`) are ignored, so
+// colored text is measured by its visible content.
+//
+// Unlike `len`, which counts bytes, and `Len`, which counts runes, this is the measure to use when
+// aligning text that may contain accented, CJK or emoji characters.
+//
+// Parameters:
+//   - `str`: The string to measure.
+//
+// Returns:
+//   - The display width of `str`.
+//
+// Example:
+//
+//	DisplayWidth("hello") // 5
+//	DisplayWidth("日本語") // 6
+//	DisplayWidth("\x1B[32mok\x1B[0m") // 2
+func DisplayWidth(str string) int {
+	width := 0
+	for i := 0; i < len(str); {
+		if n := ansiSequenceLength(str[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(str[i:])
+		width += RuneWidth(r)
+		i += size
+	}
+	return width
+}
+
+// ansiSequenceLength returns the byte length of the ANSI CSI escape sequence at the start of `str`,
+// or 0 if `str` does not start with one.
+func ansiSequenceLength(str string) int {
+	if len(str) < 2 || str[0] != '\x1B' || str[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(str); i++ {
+		if c := str[i]; c >= 0x40 && c <= 0x7E {
+			return i + 1
+		}
+	}
+	return len(str)
+}
+
+// WordWrap wraps `str` into lines of at most `width` display columns, breaking at whitespace, and
+// returns the lines joined with '\n'. It is the display-width aware counterpart of wrapping by byte or
+// rune count: East Asian wide characters count for two columns, combining marks stay attached to the
+// character they modify, and ANSI escape sequences (such as the colors of `TerminalStyle`) take no room.
+// A style active at the end of a line is reset there and restored at the start of the next one, so that
+// every line can be printed on its own.
+//
+// Existing line breaks are preserved, while runs of spaces and tabs are collapsed into a single space.
+// Words longer than `width` are broken across lines; use `WordWrapWith` to keep them whole or to
+// hyphenate them.
+//
+// Parameters:
+//   - `str`: The text to wrap.
+//   - `width`: The maximum display width of a line; 0 or less returns `str` unchanged.
+//
+// Returns:
+//   - The wrapped text.
+//
+// Example:
+//
+//	WordWrap("The quick brown fox jumps", 10) // "The quick\nbrown fox\njumps"
+//	WordWrap("日本語のテキスト", 6)              // "日本語\nのテキ\nスト"
+func WordWrap(str string, width int) string {
+	return WordWrapWith(str, width, nil)
+}
+
+// WordWrapWith wraps `str` like `WordWrap`, using the given options to control how words longer than
+// a line are handled.
+//
+// Parameters:
+//   - `str`: The text to wrap.
+//   - `width`: The maximum display width of a line; 0 or less returns `str` unchanged.
+//   - `options`: The wrapping configuration; nil uses `DefaultWrapOptionsConfig`.
+//
+// Returns:
+//   - The wrapped text.
+//
+// Example:
+//
+//	WordWrapWith("internationalization rocks", 8, &WrapOptionsConfig{Hyphenate: true})
+//	// "interna-\ntionali-\nzation\nrocks"
+//	WordWrapWith("see https://example.com/a/long/path", 10, &WrapOptionsConfig{SoftBreak: true})
+//	// "see\nhttps://example.com/a/long/path"
+func WordWrapWith(str string, width int, options *WrapOptionsConfig) string {
+	if width <= 0 {
+		return str
+	}
+	if options == nil {
+		options = DefaultWrapOptionsConfig
+	}
+	wrapper := &lineWrapper{width: width, softBreak: options.SoftBreak, hyphenate: options.Hyphenate}
+	return wrapper.wrap(str)
+}
+
+// PadLeft pads `str` on the left with `pad` until it is `width` display columns wide, aligning it to
+// the right. Strings already as wide as `width` are returned unchanged. Display width is measured by
+// `DisplayWidth`, so wide characters and ANSI escape sequences are accounted for.
+//
+// Parameters:
+//   - `str`: The string to pad.
+//   - `width`: The target display width.
+//   - `pad`: The padding rune, usually a space. Columns that a wide padding rune cannot fill are padded with spaces.
+//
+// Returns:
+//   - The padded string.
+//
+// Example:
+//
+//	PadLeft("42", 5, '0')  // "00042"
+//	PadLeft("日本", 6, ' ') // "  日本"
+func PadLeft(str string, width int, pad rune) string {
+	return padding(width-DisplayWidth(str), pad) + str
+}
+
+// PadRight pads `str` on the right with `pad` until it is `width` display columns wide, aligning it to
+// the left, as described for `PadLeft`.
+//
+// Parameters:
+//   - `str`: The string to pad.
+//   - `width`: The target display width.
+//   - `pad`: The padding rune.
+//
+// Returns:
+//   - The padded string.
+//
+// Example:
+//
+//	PadRight("名前", 6, '.') // "名前.."
+func PadRight(str string, width int, pad rune) string {
+	return str + padding(width-DisplayWidth(str), pad)
+}
+
+// Center pads `str` on both sides with `pad` until it is `width` display columns wide. When the
+// padding cannot be split evenly, the extra column goes to the right.
+//
+// Parameters:
+//   - `str`: The string to center.
+//   - `width`: The target display width.
+//   - `pad`: The padding rune.
+//
+// Returns:
+//   - The centered string.
+//
+// Example:
+//
+//	Center("go", 7, '*') // "**go***"
+func Center(str string, width int, pad rune) string {
+	gap := width - DisplayWidth(str)
+	if gap <= 0 {
+		return str
+	}
+	return padding(gap/2, pad) + str + padding(gap-gap/2, pad)
+}
+
+// Truncate shortens `str` to at most `width` display columns, ending it with "..." when it is cut.
+// Unlike `Abbreviate`, which counts bytes, it measures wide characters and ignores ANSI escape
+// sequences, which are kept; a style left active by the cut is reset at the end. It also cuts
+// strings for widths under 4, which `Abbreviate` returns unchanged, dropping the ellipsis when
+// there is no room for it.
+//
+// Parameters:
+//   - `str`: The string to truncate.
+//   - `width`: The maximum display width.
+//
+// Returns:
+//   - `str` if it fits in `width` columns, and otherwise its longest prefix that fits with the ellipsis.
+//
+// Example:
+//
+//	Truncate("Hello, World", 8) // "Hello..."
+//	Truncate("日本語テキスト", 7)  // "日本..."
+//	Truncate("Hello, World", 3)   // "Hel"
+func Truncate(str string, width int) string {
+	return TruncateWith(str, width, "...")
+}
+
+// TruncateWith shortens `str` to at most `width` display columns like `Truncate`, ending it with
+// `ellipsis` when it is cut. When `width` is too small to hold the ellipsis and one more column,
+// the string is cut without it.
+//
+// Parameters:
+//   - `str`: The string to truncate.
+//   - `width`: The maximum display width.
+//   - `ellipsis`: The marker appended to a truncated string, such as "…".
+//
+// Returns:
+//   - The truncated string.
+//
+// Example:
+//
+//	TruncateWith("Hello, World", 6, "…") // "Hello…"
+func TruncateWith(str string, width int, ellipsis string) string {
+	return truncateDisplay(str, width, ellipsis)
+}
+
+// ansiReset is the escape sequence that clears every terminal style.
+const ansiReset = "\x1B[0m"
+
+// truncateDisplay shortens `str` to at most `width` columns, ending it with `ellipsis` when it is cut.
+// ANSI escape sequences are kept without taking room, and a style left active by the cut is reset at
+// the end. When `width` is too small to hold the ellipsis and one more column, the string is cut
+// without it.
+func truncateDisplay(str string, width int, ellipsis string) string {
+	if width <= 0 {
+		return ""
+	}
+	if DisplayWidth(str) <= width {
+		return str
+	}
+	limit := width - DisplayWidth(ellipsis)
+	if limit < 1 {
+		ellipsis, limit = "", width
+	}
+	var builder strings.Builder
+	used, styled := 0, false
+	for i := 0; i < len(str); {
+		if n := ansiSequenceLength(str[i:]); n > 0 {
+			builder.WriteString(str[i : i+n])
+			styled = !isANSIReset(str[i : i+n])
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(str[i:])
+		w := RuneWidth(r)
+		if used+w > limit {
+			break
+		}
+		builder.WriteRune(r)
+		used += w
+		i += size
+	}
+	if styled {
+		builder.WriteString(ansiReset)
+	}
+	return builder.String() + ellipsis
+}
+
+// lineWrapper splits text into lines of at most `width` columns, breaking at whitespace and inside
+// words that are longer than a line, unless `softBreak` keeps them whole. Existing line breaks are
+// preserved.
+type lineWrapper struct {
+	width     int
+	softBreak bool // keep long words whole, see `WrapOptionsConfig`
+	hyphenate bool // end broken words with a hyphen
+	result    strings.Builder
+	line      strings.Builder
+	used      int      // display width of the current line
+	styles    []string // escape sequences active at the current position
+}
+
+// wrap wraps `str` and returns the lines joined with '\n'.
+func (wrapper *lineWrapper) wrap(str string) string {
+	for i, paragraph := range strings.Split(str, "\n") {
+		if i > 0 {
+			wrapper.flush(true)
+		}
+		for _, word := range strings.Fields(paragraph) {
+			wrapper.writeWord(word)
+		}
+	}
+	wrapper.flush(false)
+	return wrapper.result.String()
+}
+
+// writeWord appends `word`, a whitespace-free token, to the current line or to a new one.
+func (wrapper *lineWrapper) writeWord(word string) {
+	wordWidth := DisplayWidth(word)
+	switch {
+	case wrapper.used > 0 && wrapper.used+1+wordWidth <= wrapper.width:
+		wrapper.line.WriteByte(' ')
+		wrapper.used++
+	case wrapper.used > 0 && wordWidth > 0:
+		wrapper.flush(true)
+	}
+	if wordWidth <= wrapper.width-wrapper.used || wrapper.softBreak {
+		wrapper.write(word)
+		return
+	}
+	hyphen := 0
+	if wrapper.hyphenate && wrapper.width > 1 {
+		hyphen = 1
+	}
+	remaining := wordWidth
+	for i := 0; i < len(word); {
+		if n := ansiSequenceLength(word[i:]); n > 0 {
+			wrapper.write(word[i : i+n])
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(word[i:])
+		w := RuneWidth(r)
+		if w > 0 && wrapper.used > 0 && remaining > wrapper.width-wrapper.used && wrapper.used+w > wrapper.width-hyphen {
+			if hyphen > 0 {
+				wrapper.line.WriteByte('-')
+			}
+			wrapper.flush(true)
+		}
+		wrapper.line.WriteRune(r)
+		wrapper.used += w
+		remaining -= w
+		i += size
+	}
+}
+
+// write appends `text` to the current line, tracking the escape sequences it contains.
+func (wrapper *lineWrapper) write(text string) {
+	for i := 0; i < len(text); {
+		n := ansiSequenceLength(text[i:])
+		if n == 0 {
+			r, size := utf8.DecodeRuneInString(text[i:])
+			wrapper.line.WriteRune(r)
+			wrapper.used += RuneWidth(r)
+			i += size
+			continue
+		}
+		sequence := text[i : i+n]
+		wrapper.line.WriteString(sequence)
+		if isANSIReset(sequence) {
+			wrapper.styles = wrapper.styles[:0]
+		} else {
+			wrapper.styles = append(wrapper.styles, sequence)
+		}
+		i += n
+	}
+}
+
+// flush ends the current line, resetting the active styles, and starts a new one restoring them when
+// `more` is true.
+func (wrapper *lineWrapper) flush(more bool) {
+	if len(wrapper.styles) > 0 && more {
+		wrapper.line.WriteString(ansiReset)
+	}
+	wrapper.result.WriteString(wrapper.line.String())
+	wrapper.line.Reset()
+	wrapper.used = 0
+	if more {
+		wrapper.result.WriteByte('\n')
+		for _, style := range wrapper.styles {
+			wrapper.line.WriteString(style)
+		}
+	}
+}
+
+// isANSIReset reports whether the escape `sequence` clears every style.
+func isANSIReset(sequence string) bool {
+	return sequence == ansiReset || sequence == "\x1B[m"
+}
+
+// padding returns `gap` columns of `pad`, completed with spaces when `pad` is wide.
+func padding(gap int, pad rune) string {
+	if gap <= 0 {
+		return ""
+	}
+	w := RuneWidth(pad)
+	if w <= 0 {
+		pad, w = ' ', 1
+	}
+	return strings.Repeat(string(pad), gap/w) + strings.Repeat(" ", gap%w)
+}